@@ -49,6 +49,7 @@ var (
 	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
 	stdoutPath          = flag.String("stdout_path", "", "If specified, file to copy stdout to")
 	stderrPath          = flag.String("stderr_path", "", "If specified, file to copy stderr to")
+	logPath             = flag.String("log_path", "", "If specified, file to copy both stdout and stderr to, in addition to the container output")
 	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, expect steps to not skip on failure")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
@@ -60,6 +61,8 @@ var (
 const (
 	defaultWaitPollingInterval = time.Second
 	breakpointExitSuffix       = ".breakpointexit"
+	// egressBridgeCommand runs a hermetic step with an egress allow-list in its network namespace
+	egressBridgeCommand = "egress-bridge"
 )
 
 func checkForBreakpointOnFailure(e entrypoint.Entrypointer, breakpointExitPostFile string) {
@@ -93,6 +96,11 @@ func main() {
 	if err := flag.CommandLine.Parse(args); err != nil {
 		os.Exit(1)
 	}
+	// Hermetic steps with an egress allow-list are run through the entrypoint itself, which
+	// forwards their connections to the egress proxy from their network namespace.
+	if args := flag.CommandLine.Args(); len(args) == 2 && args[0] == egressBridgeCommand {
+		os.Exit(runEgressBridge(args[1], commandArgs))
+	}
 	if err := subcommands.Process(flag.CommandLine.Args()); err != nil {
 		log.Println(err.Error())
 		switch err.(type) {
@@ -153,6 +161,7 @@ func main() {
 		Runner: &realRunner{
 			stdoutPath: *stdoutPath,
 			stderrPath: *stderrPath,
+			logPath:    *logPath,
 		},
 		PostWriter:          &realPostWriter{},
 		Results:             strings.Split(*results, ","),
@@ -10,3 +10,11 @@ import "os/exec"
 func dropNetworking(cmd *exec.Cmd) { //nolint:deadcode
 	panic("only implemented on linux")
 }
+
+func proxyEgress(cmd *exec.Cmd, allowList []string) (func(), error) { //nolint:deadcode
+	panic("only implemented on linux")
+}
+
+func runEgressBridge(socketPath string, command []string) int {
+	panic("only implemented on linux")
+}
@@ -1,9 +1,19 @@
 package main
 
 import (
+	"errors"
+	"log"
 	"math"
+	"net"
+	"net/http"
+	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"syscall"
+
+	"github.com/tektoncd/pipeline/pkg/egress"
+	"golang.org/x/sys/unix"
 )
 
 // We need the max value of an unsigned 32 bit integer (4294967295), but we also need this number
@@ -63,3 +73,103 @@ func dropNetworking(cmd *exec.Cmd) {
 		},
 	}
 }
+
+// proxyEgress modifies the supplied exec.Cmd, which runs without network access, so that it
+// can still connect to the destinations of an allow-list. It starts an HTTP proxy enforcing
+// the allow-list on a Unix socket, and runs the command through the entrypoint, which forwards
+// the connections to the proxy from the network namespace of the command. The returned
+// function stops the proxy.
+func proxyEgress(cmd *exec.Cmd, allowList []string) (func(), error) {
+	a, err := egress.ParseAllowList(allowList)
+	if err != nil {
+		return nil, err
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "tekton-egress-")
+	if err != nil {
+		return nil, err
+	}
+	socketPath := filepath.Join(dir, "proxy.sock")
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	server := &http.Server{Handler: egress.NewProxy(a)} //nolint:gosec
+	go func() {
+		if err := server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Error serving the egress proxy: %v", err)
+		}
+	}()
+
+	cmd.Args = append([]string{self, egressBridgeCommand, socketPath, "--", cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = self
+	return func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}, nil
+}
+
+// runEgressBridge runs the command in the network namespace created by dropNetworking, with a
+// proxy on the loopback interface which forwards its connections to the egress proxy listening
+// on socketPath. It returns the exit code of the command.
+func runEgressBridge(socketPath string, command []string) int {
+	if len(command) == 0 {
+		log.Print("No command to run")
+		return 1
+	}
+	if err := setLoopbackUp(); err != nil {
+		log.Printf("Error setting up the loopback interface: %v", err)
+		return 1
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Printf("Error listening for the egress proxy: %v", err)
+		return 1
+	}
+	go egress.Forward(l, socketPath) //nolint:errcheck
+
+	proxyURL := "http://" + l.Addr().String()
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), "HTTP_PROXY="+proxyURL, "HTTPS_PROXY="+proxyURL, "http_proxy="+proxyURL, "https_proxy="+proxyURL)
+
+	// Signals are sent to the process group of the command as well, so they are
+	// caught here to keep forwarding its connections until it exits.
+	signal.Notify(make(chan os.Signal, 1))
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal())
+		}
+		return exitErr.ExitCode()
+	default:
+		log.Printf("Error running %s: %v", command[0], err)
+		return 1
+	}
+}
+
+// setLoopbackUp brings up the loopback interface, which is down in a new network namespace.
+func setLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return err
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr)
+}
@@ -27,6 +27,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -44,6 +45,7 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	logPath       string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -84,12 +86,26 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	cmd := exec.CommandContext(ctx, name, args...)
 
+	// When the step log is archived, both stdout and stderr are copied to the
+	// log file, in addition to the container streams, so the logs of the step
+	// are still available from the container.
+	var stdoutWriters, stderrWriters []io.Writer
+	if rr.logPath != "" {
+		logFile, err := openAppendFile(rr.logPath)
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+		stdoutWriters = []io.Writer{os.Stdout, logFile}
+		stderrWriters = []io.Writer{os.Stderr, logFile}
+	}
+
 	// Build a list of tee readers that we'll read from after the command is
 	// is started. If we are not configured to tee stdout/stderr this will be
 	// empty and contents will not be copied.
 	var readers []*namedReader
-	if rr.stdoutPath != "" {
-		stdout, err := newTeeReader(cmd.StdoutPipe, rr.stdoutPath)
+	if rr.stdoutPath != "" || len(stdoutWriters) > 0 {
+		stdout, err := newTeeReader(cmd.StdoutPipe, rr.stdoutPath, stdoutWriters...)
 		if err != nil {
 			return err
 		}
@@ -98,8 +114,8 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 		// This needs to be set in an else since StdoutPipe will fail if cmd.Stdout is already set.
 		cmd.Stdout = os.Stdout
 	}
-	if rr.stderrPath != "" {
-		stderr, err := newTeeReader(cmd.StderrPipe, rr.stderrPath)
+	if rr.stderrPath != "" || len(stderrWriters) > 0 {
+		stderr, err := newTeeReader(cmd.StderrPipe, rr.stderrPath, stderrWriters...)
 		if err != nil {
 			return err
 		}
@@ -113,6 +129,13 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
 	if os.Getenv("TEKTON_RESOURCE_NAME") == "" && os.Getenv(pod.TektonHermeticEnvVar) == "1" {
+		if allowList := os.Getenv(pod.TektonHermeticEgressEnvVar); allowList != "" {
+			stop, err := proxyEgress(cmd, strings.Split(allowList, ","))
+			if err != nil {
+				return err
+			}
+			defer stop()
+		}
 		dropNetworking(cmd)
 	}
 
@@ -162,18 +185,38 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 }
 
 // newTeeReader creates a new Reader that copies data from the given pipe function
-// (i.e. cmd.StdoutPipe, cmd.StderrPipe) into a file specified by path.
+// (i.e. cmd.StdoutPipe, cmd.StderrPipe) into a file specified by path, if any,
+// and into the given writers.
 // The file is opened with os.O_WRONLY|os.O_CREATE|os.O_APPEND, and will not
 // override any existing content in the path. This means that the same file can
 // be used for multiple streams if desired.
 // The behavior of the Reader is the same as io.TeeReader - reads from the pipe
-// will be written to the file.
-func newTeeReader(pipe func() (io.ReadCloser, error), path string) (*namedReader, error) {
+// will be written to the file and the writers.
+func newTeeReader(pipe func() (io.ReadCloser, error), path string, writers ...io.Writer) (*namedReader, error) {
 	in, err := pipe()
 	if err != nil {
 		return nil, fmt.Errorf("error creating pipe: %w", err)
 	}
 
+	name := "the container output"
+	if path != "" {
+		f, err := openAppendFile(path)
+		if err != nil {
+			return nil, err
+		}
+		name = path
+		writers = append([]io.Writer{f}, writers...)
+	}
+
+	return &namedReader{
+		name:   name,
+		Reader: io.TeeReader(in, io.MultiWriter(writers...)),
+	}, nil
+}
+
+// openAppendFile opens the file at path for appending, creating it and its
+// parent directory if needed.
+func openAppendFile(path string) (*os.File, error) {
 	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
 		return nil, fmt.Errorf("error creating parent directory: %w", err)
 	}
@@ -181,11 +224,7 @@ func newTeeReader(pipe func() (io.ReadCloser, error), path string) (*namedReader
 	if err != nil {
 		return nil, fmt.Errorf("error opening %s: %w", path, err)
 	}
-
-	return &namedReader{
-		name:   path,
-		Reader: io.TeeReader(in, f),
-	}, nil
+	return f, nil
 }
 
 // namedReader is just a helper struct that lets us give a reader a name for
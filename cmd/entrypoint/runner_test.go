@@ -79,6 +79,36 @@ func TestRealRunnerStdoutAndStderrSamePath(t *testing.T) {
 	}
 }
 
+func TestRealRunnerLogPath(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	expectedString := "hello world"
+	rr := realRunner{
+		stdoutPath: filepath.Join(tmp, "stdout"),
+		logPath:    filepath.Join(tmp, "logs/step.log"),
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", fmt.Sprintf("echo %s && echo %s >&2", expectedString, expectedString)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got, err := ioutil.ReadFile(filepath.Join(tmp, "stdout")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if gotString := strings.TrimSpace(string(got)); gotString != expectedString {
+		t.Errorf("stdout: got: %v, wanted: %v", gotString, expectedString)
+	}
+	// Since writes to stdout and stderr might be racy, we only check for lengths here.
+	expectedSize := (len(expectedString) + 1) * 2
+	if got, err := ioutil.ReadFile(filepath.Join(tmp, "logs/step.log")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if gotSize := len(got); gotSize != expectedSize {
+		t.Errorf("logs/step.log: got: %v, wanted: %v", gotSize, expectedSize)
+	}
+}
+
 func TestRealRunnerStdoutPathWithSignal(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "")
 	if err != nil {
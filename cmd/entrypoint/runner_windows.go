@@ -35,6 +35,7 @@ import (
 type realRunner struct {
 	stdoutPath string
 	stderrPath string
+	logPath    string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -43,6 +44,9 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if rr.stdoutPath != "" || rr.stderrPath != "" {
 		return errors.New("step.StdoutPath and step.StderrPath not supported on Windows")
 	}
+	if rr.logPath != "" {
+		return errors.New("archiving step logs is not supported on Windows")
+	}
 	if len(args) == 0 {
 		return nil
 	}
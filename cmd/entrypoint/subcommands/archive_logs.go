@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ArchiveLogsCommand is the name of the command uploading the logs of the steps
+// to the logs archive.
+const ArchiveLogsCommand = "archive-logs"
+
+var (
+	// archiveLogsPollInterval is the interval at which the completion of the steps is
+	// checked. Included as a global variable to allow overriding for tests.
+	archiveLogsPollInterval = time.Second
+	// archiveLogsStopTimeout is the time left to the uploads in progress once the
+	// archiver is stopped, within the termination grace period of the Pod.
+	archiveLogsStopTimeout = 20 * time.Second
+	// archiveLogsResultsPath is the termination message path of the archiver, which
+	// reports the logs it archived to the controller.
+	archiveLogsResultsPath = corev1.TerminationMessagePathDefault
+)
+
+// archiveLogs uploads the logs of each of the steps to the destination URI once
+// the step completes, and writes which logs were archived to the termination
+// message of the archiver.
+// This expects the list of steps (in order matching the Task spec).
+// The controller stops the archiver like a sidecar once the steps completed, in
+// which case the uploads in progress are given archiveLogsStopTimeout to complete.
+func archiveLogs(destination string, steps []string) error {
+	backend, err := logarchive.NewBackend(destination)
+	if err != nil {
+		results := make([]logarchive.Result, 0, len(steps))
+		for _, step := range steps {
+			results = append(results, logarchive.Result{Step: step, Error: err.Error()})
+		}
+		return logarchive.WriteResults(archiveLogsResultsPath, results)
+	}
+	archiver := logarchive.Archiver{
+		Backend:      backend,
+		RunDir:       filepath.Join(tektonRoot, "run"),
+		LogsDir:      filepath.Join(tektonRoot, "logs"),
+		PollInterval: archiveLogsPollInterval,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan os.Signal, 1)
+	signal.Notify(stopped, syscall.SIGTERM)
+	defer signal.Stop(stopped)
+	go func() {
+		select {
+		case <-stopped:
+			time.AfterFunc(archiveLogsStopTimeout, cancel)
+		case <-ctx.Done():
+		}
+	}()
+
+	return logarchive.WriteResults(archiveLogsResultsPath, archiver.Archive(ctx, steps))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subcommands
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+)
+
+func TestArchiveLogs(t *testing.T) {
+	tektonRoot = t.TempDir()
+	archiveLogsPollInterval = time.Millisecond
+	archiveLogsResultsPath = filepath.Join(t.TempDir(), "termination-log")
+	for path, content := range map[string]string{
+		"run/0/out":  "",
+		"logs/0.log": "hello world\n",
+	} {
+		path = filepath.Join(tektonRoot, path)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatalf("error creating directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("error writing file: %v", err)
+		}
+	}
+
+	objects := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		objects[r.URL.Path] = string(body)
+	}))
+	defer server.Close()
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+
+	returnValue := Process([]string{ArchiveLogsCommand, "s3://tekton-logs/foo/pod", "build"})
+	if _, ok := returnValue.(SubcommandSuccessful); !ok || strings.HasPrefix(returnValue.Error(), "Error") {
+		t.Errorf("unexpected return value from archive-logs command: %v", returnValue)
+	}
+	if got := objects["/tekton-logs/foo/pod/build.log"]; got != "hello world\n" {
+		t.Errorf("unexpected archived logs: %q", got)
+	}
+	if got := readResults(t); len(got) != 1 || got[0] != (logarchive.Result{Step: "build"}) {
+		t.Errorf("unexpected results of archive-logs command: %v", got)
+	}
+}
+
+func TestArchiveLogsInvalidDestination(t *testing.T) {
+	archiveLogsResultsPath = filepath.Join(t.TempDir(), "termination-log")
+	returnValue := Process([]string{ArchiveLogsCommand, "gs://tekton-logs/foo/pod", "build"})
+	if _, ok := returnValue.(SubcommandSuccessful); !ok || strings.HasPrefix(returnValue.Error(), "Error") {
+		t.Errorf("unexpected return value from archive-logs command: %v", returnValue)
+	}
+	if got := readResults(t); len(got) != 1 || !strings.Contains(got[0].Error, "unsupported logs archive") {
+		t.Errorf("unexpected results of archive-logs command: %v", got)
+	}
+}
+
+func readResults(t *testing.T) []logarchive.Result {
+	t.Helper()
+	message, err := os.ReadFile(archiveLogsResultsPath)
+	if err != nil {
+		t.Fatalf("error reading the termination message: %v", err)
+	}
+	results, err := logarchive.ParseResults(string(message))
+	if err != nil {
+		t.Fatalf("error parsing the termination message: %v", err)
+	}
+	return results
+}
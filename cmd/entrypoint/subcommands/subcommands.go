@@ -84,6 +84,19 @@ func Process(args []string) error {
 			}
 			return SubcommandSuccessful{message: fmt.Sprintf("Decoded script %s", src)}
 		}
+	case ArchiveLogsCommand:
+		// If invoked in "archive-logs" mode (`entrypoint archive-logs <destination> [<step-name>]`),
+		// upload the logs of the steps to the destination once each of them completes.
+		// Errors are reported in the termination message of the container rather than
+		// by failing it, which would fail the Pod and with it the TaskRun, whatever the
+		// outcome of its steps.
+		if len(args) >= 2 {
+			destination := args[1]
+			if err := archiveLogs(destination, args[2:]); err != nil {
+				return SubcommandSuccessful{message: fmt.Sprintf("Error reporting the logs of the steps archived to %s: %v", destination, err)}
+			}
+			return SubcommandSuccessful{message: fmt.Sprintf("Archived the logs of the steps to %s", destination)}
+		}
 	case StepInitCommand:
 		if err := stepInit(args[1:]); err != nil {
 			return SubcommandError{subcommand: StepInitCommand, message: err.Error()}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CloudEventsSink is a sink the cloud events of the runs of a namespace are sent to.
+// +k8s:deepcopy-gen=true
+type CloudEventsSink struct {
+	// Namespace is the namespace of the runs whose events are sent to the sink
+	Namespace string `json:"namespace"`
+	// URL is the address of the sink
+	URL string `json:"sink"`
+	// Types restricts the events sent to the sink to the given event types. A type
+	// ending with "*" matches all the event types starting with the same prefix.
+	// +optional
+	Types []string `json:"types,omitempty"`
+	// Labels restricts the events sent to the sink to the runs with all the given labels
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (s CloudEventsSink) validate() error {
+	if s.Namespace == "" {
+		return errors.New("cloud events sink requires a namespace")
+	}
+	if s.URL == "" {
+		return fmt.Errorf("cloud events sink of namespace %q requires a sink", s.Namespace)
+	}
+	if _, err := url.ParseRequestURI(s.URL); err != nil {
+		return fmt.Errorf("invalid cloud events sink of namespace %q: %w", s.Namespace, err)
+	}
+	return nil
+}
+
+// ValidateRunCloudEventsSink checks that the sink of a run, set with the
+// tekton.dev/cloudEventsSink annotation, is an http(s) URL under one of the
+// DefaultCloudEventsAllowedRunSinks. Runs may not set their sink when none are allowed.
+func (cfg *Defaults) ValidateRunCloudEventsSink(sink string) error {
+	if err := validateSinkURL(sink); err != nil {
+		return err
+	}
+	u, _ := url.Parse(sink)
+	for _, allowed := range cfg.DefaultCloudEventsAllowedRunSinks {
+		a, err := url.Parse(allowed)
+		if err != nil {
+			continue
+		}
+		if u.Scheme == a.Scheme && u.Host == a.Host && pathHasPrefix(u.Path, a.Path) {
+			return nil
+		}
+	}
+	return fmt.Errorf("cloud events sink %q is not allowed for runs", sink)
+}
+
+// validateSinkURL checks that a sink is an absolute http(s) URL.
+func validateSinkURL(sink string) error {
+	u, err := url.ParseRequestURI(sink)
+	if err != nil {
+		return fmt.Errorf("invalid cloud events sink %q: %w", sink, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid cloud events sink %q: the scheme must be http or https", sink)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid cloud events sink %q: missing host", sink)
+	}
+	if u.User != nil {
+		return fmt.Errorf("invalid cloud events sink %q: credentials are not allowed", sink)
+	}
+	return nil
+}
+
+// pathHasPrefix returns true if path is prefix, or a sub-path of prefix.
+func pathHasPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
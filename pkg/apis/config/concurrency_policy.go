@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ConcurrencyScopePipeline groups the PipelineRuns referencing the same Pipeline.
+	ConcurrencyScopePipeline = "Pipeline"
+	// ConcurrencyScopeLabel groups the PipelineRuns with the same value for a label.
+	ConcurrencyScopeLabel = "Label"
+	// ConcurrencyScopeNamespace groups all the PipelineRuns of a namespace.
+	ConcurrencyScopeNamespace = "Namespace"
+
+	// ConcurrencyStrategyQueue keeps the PipelineRun queued until a slot is free.
+	ConcurrencyStrategyQueue = "Queue"
+	// ConcurrencyStrategyCancelOldest cancels the oldest running PipelineRuns to make room.
+	ConcurrencyStrategyCancelOldest = "CancelOldest"
+	// ConcurrencyStrategyCancelNewest cancels the PipelineRun exceeding the limit.
+	ConcurrencyStrategyCancelNewest = "CancelNewest"
+
+	// ConcurrencyOrderFIFO starts queued PipelineRuns in order of creation.
+	ConcurrencyOrderFIFO = "FIFO"
+	// ConcurrencyOrderPriority starts queued PipelineRuns by descending priority, and then
+	// in order of creation.
+	ConcurrencyOrderPriority = "Priority"
+)
+
+// ConcurrencyPolicy limits the number of PipelineRuns of a namespace sharing a concurrency
+// key that are allowed to run at the same time.
+// +k8s:deepcopy-gen=true
+type ConcurrencyPolicy struct {
+	// Namespace restricts the policy to the PipelineRuns of a namespace. The policy applies
+	// to the PipelineRuns of every namespace if it is empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Scope determines which PipelineRuns share the concurrency key: all the PipelineRuns
+	// referencing the same Pipeline, all the PipelineRuns with the same value for LabelKey,
+	// or all the PipelineRuns of the namespace. PipelineRuns without a pipelineRef with a
+	// name, or without the label, are not limited by the policy.
+	Scope string `json:"scope"`
+	// LabelKey is the label whose value is used to build the concurrency key when Scope is "Label".
+	// +optional
+	LabelKey string `json:"labelKey,omitempty"`
+	// MaxRuns is the maximum number of PipelineRuns sharing the concurrency key that may be
+	// running at the same time.
+	MaxRuns int `json:"maxRuns"`
+	// Strategy determines what happens when MaxRuns is reached: the PipelineRun is queued
+	// until a slot is free, the oldest running PipelineRuns are cancelled to make room for
+	// it, or the PipelineRun itself is cancelled. Defaults to "Queue".
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+	// Order determines in which order queued PipelineRuns are started: in order of creation,
+	// or by descending spec.concurrency.priority and then in order of creation. Defaults to "FIFO".
+	// +optional
+	Order string `json:"order,omitempty"`
+}
+
+func (p *ConcurrencyPolicy) setDefaults() {
+	if p.Strategy == "" {
+		p.Strategy = ConcurrencyStrategyQueue
+	}
+	if p.Order == "" {
+		p.Order = ConcurrencyOrderFIFO
+	}
+}
+
+func (p ConcurrencyPolicy) validate() error {
+	if p.MaxRuns < 1 {
+		return fmt.Errorf("concurrency policy requires a maxRuns >= 1, got %d", p.MaxRuns)
+	}
+	switch p.Scope {
+	case ConcurrencyScopePipeline, ConcurrencyScopeNamespace:
+		if p.LabelKey != "" {
+			return fmt.Errorf("labelKey can only be used with the %s scope", ConcurrencyScopeLabel)
+		}
+	case ConcurrencyScopeLabel:
+		if p.LabelKey == "" {
+			return errors.New("concurrency policy with the Label scope requires a labelKey")
+		}
+	default:
+		return fmt.Errorf("concurrency policy scope %q should be %s, %s or %s", p.Scope,
+			ConcurrencyScopePipeline, ConcurrencyScopeLabel, ConcurrencyScopeNamespace)
+	}
+	switch p.Strategy {
+	case ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOldest, ConcurrencyStrategyCancelNewest:
+	default:
+		return fmt.Errorf("concurrency policy strategy %q should be %s, %s or %s", p.Strategy,
+			ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOldest, ConcurrencyStrategyCancelNewest)
+	}
+	switch p.Order {
+	case ConcurrencyOrderFIFO, ConcurrencyOrderPriority:
+	default:
+		return fmt.Errorf("concurrency policy order %q should be %s or %s", p.Order,
+			ConcurrencyOrderFIFO, ConcurrencyOrderPriority)
+	}
+	return nil
+}
+
+// ConcurrencyPolicies returns the concurrency policies which apply to the PipelineRuns of a namespace.
+func (cfg *Defaults) ConcurrencyPolicies(namespace string) []ConcurrencyPolicy {
+	var policies []ConcurrencyPolicy
+	for _, p := range cfg.DefaultConcurrencyPolicies {
+		if p.Namespace == "" || p.Namespace == namespace {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
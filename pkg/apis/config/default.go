@@ -19,8 +19,11 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
@@ -39,8 +42,33 @@ const (
 	DefaultManagedByLabelValue = "tekton-pipelines"
 	// DefaultCloudEventSinkValue is the default value for cloud event sinks.
 	DefaultCloudEventSinkValue = ""
+	// DefaultCloudEventsFormatValue is the default value for cloud event formats, which
+	// sends events in the Tekton format only.
+	DefaultCloudEventsFormatValue = ""
+	// CloudEventsFormatTektonV1 is the format of the Tekton cloud events, whose payload is
+	// the run object.
+	CloudEventsFormatTektonV1 = "tektonv1"
+	// CloudEventsFormatCDEvents is the format of the CDEvents specification.
+	CloudEventsFormatCDEvents = "cdevents"
+	// DefaultCloudEventsMaxRetries is the number of times the delivery of a cloud event is
+	// retried when no max retries are specified.
+	DefaultCloudEventsMaxRetries = 10
+	// DefaultCloudEventsRetryBackoff is the delay before the first retry of the delivery of
+	// a cloud event when no retry backoff is specified. The delay doubles at each retry.
+	DefaultCloudEventsRetryBackoff = 10 * time.Millisecond
 	// DefaultMaxMatrixCombinationsCount is used when no max matrix combinations count is specified.
 	DefaultMaxMatrixCombinationsCount = 256
+	// DefaultPodEvictionRetries is used when no pod eviction retries are specified.
+	DefaultPodEvictionRetries = 0
+	// DefaultPendingTimeoutMinutes is used when no pending timeout is specified.
+	// A value of 0 means that a TaskRun Pod may stay pending until the TaskRun times out.
+	DefaultPendingTimeoutMinutes = 0
+	// LogsArchiveSchemeS3 is the scheme of the URIs of step logs archived in the bucket
+	// of an S3-compatible object storage, e.g. s3://bucket/prefix.
+	LogsArchiveSchemeS3 = "s3"
+	// LogsArchiveSchemePVC is the scheme of the URIs of step logs archived in a
+	// PersistentVolumeClaim, e.g. pvc://claim/prefix.
+	LogsArchiveSchemePVC = "pvc"
 
 	defaultTimeoutMinutesKey             = "default-timeout-minutes"
 	defaultServiceAccountKey             = "default-service-account"
@@ -48,8 +76,20 @@ const (
 	defaultPodTemplateKey                = "default-pod-template"
 	defaultAAPodTemplateKey              = "default-affinity-assistant-pod-template"
 	defaultCloudEventsSinkKey            = "default-cloud-events-sink"
+	defaultCloudEventsFormatKey          = "default-cloud-events-format"
+	defaultCloudEventsNamespaceSinksKey  = "default-cloud-events-namespace-sinks"
+	defaultCloudEventsMaxRetriesKey      = "default-cloud-events-max-retries"
+	defaultCloudEventsRetryBackoffKey    = "default-cloud-events-retry-backoff"
+	defaultCloudEventsDeadLetterSinkKey  = "default-cloud-events-dead-letter-sink"
+	defaultCloudEventsAllowedRunSinksKey = "default-cloud-events-allowed-run-sinks"
 	defaultTaskRunWorkspaceBinding       = "default-task-run-workspace-binding"
 	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
+	defaultPodEvictionRetriesKey         = "default-pod-eviction-retries"
+	defaultPendingTimeoutMinutesKey      = "default-pending-timeout-minutes"
+	defaultLogsArchiveKey                = "default-logs-archive"
+	defaultLogsArchiveEndpointKey        = "default-logs-archive-endpoint"
+	defaultLogsArchiveSecretKey          = "default-logs-archive-secret"
+	defaultConcurrencyPoliciesKey        = "default-concurrency-policies"
 )
 
 // Defaults holds the default configurations
@@ -61,8 +101,20 @@ type Defaults struct {
 	DefaultPodTemplate                *pod.Template
 	DefaultAAPodTemplate              *pod.AffinityAssistantTemplate
 	DefaultCloudEventsSink            string
+	DefaultCloudEventsFormat          string
+	DefaultCloudEventsNamespaceSinks  []CloudEventsSink
+	DefaultCloudEventsMaxRetries      int
+	DefaultCloudEventsRetryBackoff    time.Duration
+	DefaultCloudEventsDeadLetterSink  string
+	DefaultCloudEventsAllowedRunSinks []string
 	DefaultTaskRunWorkspaceBinding    string
 	DefaultMaxMatrixCombinationsCount int
+	DefaultPodEvictionRetries         int
+	DefaultPendingTimeoutMinutes      int
+	DefaultLogsArchive                string
+	DefaultLogsArchiveEndpoint        string
+	DefaultLogsArchiveSecret          string
+	DefaultConcurrencyPolicies        []ConcurrencyPolicy
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -90,8 +142,20 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultPodTemplate.Equals(cfg.DefaultPodTemplate) &&
 		other.DefaultAAPodTemplate.Equals(cfg.DefaultAAPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
+		other.DefaultCloudEventsFormat == cfg.DefaultCloudEventsFormat &&
+		reflect.DeepEqual(other.DefaultCloudEventsNamespaceSinks, cfg.DefaultCloudEventsNamespaceSinks) &&
+		other.DefaultCloudEventsMaxRetries == cfg.DefaultCloudEventsMaxRetries &&
+		other.DefaultCloudEventsRetryBackoff == cfg.DefaultCloudEventsRetryBackoff &&
+		other.DefaultCloudEventsDeadLetterSink == cfg.DefaultCloudEventsDeadLetterSink &&
+		reflect.DeepEqual(other.DefaultCloudEventsAllowedRunSinks, cfg.DefaultCloudEventsAllowedRunSinks) &&
 		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
-		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount
+		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultPodEvictionRetries == cfg.DefaultPodEvictionRetries &&
+		other.DefaultPendingTimeoutMinutes == cfg.DefaultPendingTimeoutMinutes &&
+		other.DefaultLogsArchive == cfg.DefaultLogsArchive &&
+		other.DefaultLogsArchiveEndpoint == cfg.DefaultLogsArchiveEndpoint &&
+		other.DefaultLogsArchiveSecret == cfg.DefaultLogsArchiveSecret &&
+		reflect.DeepEqual(other.DefaultConcurrencyPolicies, cfg.DefaultConcurrencyPolicies)
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -101,7 +165,12 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultServiceAccount:             DefaultServiceAccountValue,
 		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
 		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
+		DefaultCloudEventsFormat:          DefaultCloudEventsFormatValue,
+		DefaultCloudEventsMaxRetries:      DefaultCloudEventsMaxRetries,
+		DefaultCloudEventsRetryBackoff:    DefaultCloudEventsRetryBackoff,
 		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
+		DefaultPodEvictionRetries:         DefaultPodEvictionRetries,
+		DefaultPendingTimeoutMinutes:      DefaultPendingTimeoutMinutes,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -140,6 +209,65 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultCloudEventsSink = defaultCloudEventsSink
 	}
 
+	if defaultCloudEventsFormat, ok := cfgMap[defaultCloudEventsFormatKey]; ok {
+		for _, format := range strings.Split(defaultCloudEventsFormat, ",") {
+			if f := strings.TrimSpace(format); f != CloudEventsFormatTektonV1 && f != CloudEventsFormatCDEvents {
+				return nil, fmt.Errorf("failed parsing defaults config %q: unsupported format %q", defaultCloudEventsFormatKey, f)
+			}
+		}
+		tc.DefaultCloudEventsFormat = defaultCloudEventsFormat
+	}
+
+	if namespaceSinks, ok := cfgMap[defaultCloudEventsNamespaceSinksKey]; ok {
+		var sinks []CloudEventsSink
+		if err := yaml.UnmarshalStrict([]byte(namespaceSinks), &sinks); err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultCloudEventsNamespaceSinksKey, err)
+		}
+		for _, sink := range sinks {
+			if err := sink.validate(); err != nil {
+				return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultCloudEventsNamespaceSinksKey, err)
+			}
+		}
+		tc.DefaultCloudEventsNamespaceSinks = sinks
+	}
+
+	if maxRetries, ok := cfgMap[defaultCloudEventsMaxRetriesKey]; ok {
+		retries, err := strconv.ParseInt(maxRetries, 10, 0)
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultCloudEventsMaxRetriesKey)
+		}
+		tc.DefaultCloudEventsMaxRetries = int(retries)
+	}
+
+	if retryBackoff, ok := cfgMap[defaultCloudEventsRetryBackoffKey]; ok {
+		backoff, err := time.ParseDuration(retryBackoff)
+		if err != nil || backoff <= 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultCloudEventsRetryBackoffKey)
+		}
+		tc.DefaultCloudEventsRetryBackoff = backoff
+	}
+
+	if deadLetterSink, ok := cfgMap[defaultCloudEventsDeadLetterSinkKey]; ok {
+		if deadLetterSink != "" {
+			if _, err := url.ParseRequestURI(deadLetterSink); err != nil {
+				return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultCloudEventsDeadLetterSinkKey, err)
+			}
+		}
+		tc.DefaultCloudEventsDeadLetterSink = deadLetterSink
+	}
+
+	if allowedRunSinks, ok := cfgMap[defaultCloudEventsAllowedRunSinksKey]; ok {
+		for _, sink := range strings.Split(allowedRunSinks, ",") {
+			if sink = strings.TrimSpace(sink); sink == "" {
+				continue
+			}
+			if err := validateSinkURL(sink); err != nil {
+				return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultCloudEventsAllowedRunSinksKey, err)
+			}
+			tc.DefaultCloudEventsAllowedRunSinks = append(tc.DefaultCloudEventsAllowedRunSinks, sink)
+		}
+	}
+
 	if bindingYAML, ok := cfgMap[defaultTaskRunWorkspaceBinding]; ok {
 		tc.DefaultTaskRunWorkspaceBinding = bindingYAML
 	}
@@ -152,9 +280,89 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultMaxMatrixCombinationsCount = int(matrixCombinationsCount)
 	}
 
+	if defaultPodEvictionRetries, ok := cfgMap[defaultPodEvictionRetriesKey]; ok {
+		podEvictionRetries, err := strconv.ParseInt(defaultPodEvictionRetries, 10, 0)
+		if err != nil || podEvictionRetries < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultPodEvictionRetriesKey)
+		}
+		tc.DefaultPodEvictionRetries = int(podEvictionRetries)
+	}
+
+	if defaultPendingTimeoutMin, ok := cfgMap[defaultPendingTimeoutMinutesKey]; ok {
+		pendingTimeout, err := strconv.ParseInt(defaultPendingTimeoutMin, 10, 0)
+		if err != nil || pendingTimeout < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultPendingTimeoutMinutesKey)
+		}
+		tc.DefaultPendingTimeoutMinutes = int(pendingTimeout)
+	}
+
+	if logsArchive, ok := cfgMap[defaultLogsArchiveKey]; ok {
+		if logsArchive != "" {
+			if err := validateLogsArchive(logsArchive); err != nil {
+				return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultLogsArchiveKey, err)
+			}
+		}
+		tc.DefaultLogsArchive = logsArchive
+	}
+
+	if endpoint, ok := cfgMap[defaultLogsArchiveEndpointKey]; ok {
+		if endpoint != "" {
+			if _, err := url.ParseRequestURI(endpoint); err != nil {
+				return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultLogsArchiveEndpointKey, err)
+			}
+		}
+		tc.DefaultLogsArchiveEndpoint = endpoint
+	}
+
+	if secret, ok := cfgMap[defaultLogsArchiveSecretKey]; ok {
+		tc.DefaultLogsArchiveSecret = secret
+	}
+
+	if concurrencyPolicies, ok := cfgMap[defaultConcurrencyPoliciesKey]; ok {
+		var policies []ConcurrencyPolicy
+		if err := yaml.UnmarshalStrict([]byte(concurrencyPolicies), &policies); err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultConcurrencyPoliciesKey, err)
+		}
+		for i := range policies {
+			policies[i].setDefaults()
+			if err := policies[i].validate(); err != nil {
+				return nil, fmt.Errorf("failed parsing defaults config %q: %w", defaultConcurrencyPoliciesKey, err)
+			}
+		}
+		tc.DefaultConcurrencyPolicies = policies
+	}
+
 	return &tc, nil
 }
 
+// validateLogsArchive checks that the logs archive is the URI of a bucket or of a
+// PersistentVolumeClaim, optionally followed by a prefix.
+func validateLogsArchive(logsArchive string) error {
+	u, err := url.Parse(logsArchive)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != LogsArchiveSchemeS3 && u.Scheme != LogsArchiveSchemePVC {
+		return fmt.Errorf("unsupported logs archive %q, expected a %s:// or %s:// URI", logsArchive, LogsArchiveSchemeS3, LogsArchiveSchemePVC)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("logs archive %q has no bucket or claim name", logsArchive)
+	}
+	return nil
+}
+
+// CloudEventsFormats returns the formats cloud events are sent in.
+func (cfg *Defaults) CloudEventsFormats() []string {
+	if cfg.DefaultCloudEventsFormat == "" {
+		return []string{CloudEventsFormatTektonV1}
+	}
+	var formats []string
+	for _, format := range strings.Split(cfg.DefaultCloudEventsFormat, ",") {
+		formats = append(formats, strings.TrimSpace(format))
+	}
+	return formats
+}
+
 func yamlUnmarshal(s string, key string, o interface{}) error {
 	b := []byte(s)
 	if err := yaml.UnmarshalStrict(b, o); err != nil {
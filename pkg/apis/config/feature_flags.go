@@ -60,6 +60,8 @@ const (
 	DefaultEnableAPIFields = StableAPIFields
 	// DefaultSendCloudEventsForRuns is the default value for "send-cloudevents-for-runs".
 	DefaultSendCloudEventsForRuns = false
+	// DefaultEmitPipelineTaskEvents is the default value for "emit-pipelinetask-events".
+	DefaultEmitPipelineTaskEvents = false
 	// DefaultEmbeddedStatus is the default value for "embedded-status".
 	DefaultEmbeddedStatus = FullEmbeddedStatus
 	// DefaultEnableSpire is the default value for "enable-spire".
@@ -74,6 +76,7 @@ const (
 	enableCustomTasks                   = "enable-custom-tasks"
 	enableAPIFields                     = "enable-api-fields"
 	sendCloudEventsForRuns              = "send-cloudevents-for-runs"
+	emitPipelineTaskEvents              = "emit-pipelinetask-events"
 	embeddedStatus                      = "embedded-status"
 	enableSpire                         = "enable-spire"
 )
@@ -90,6 +93,7 @@ type FeatureFlags struct {
 	ScopeWhenExpressionsToTask       bool
 	EnableAPIFields                  string
 	SendCloudEventsForRuns           bool
+	EmitPipelineTaskEvents           bool
 	AwaitSidecarReadiness            bool
 	EmbeddedStatus                   string
 	EnableSpire                      bool
@@ -141,6 +145,9 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(sendCloudEventsForRuns, DefaultSendCloudEventsForRuns, &tc.SendCloudEventsForRuns); err != nil {
 		return nil, err
 	}
+	if err := setFeature(emitPipelineTaskEvents, DefaultEmitPipelineTaskEvents, &tc.EmitPipelineTaskEvents); err != nil {
+		return nil, err
+	}
 	if err := setEmbeddedStatus(cfgMap, DefaultEmbeddedStatus, &tc.EmbeddedStatus); err != nil {
 		return nil, err
 	}
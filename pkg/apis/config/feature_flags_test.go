@@ -58,6 +58,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableCustomTasks:                true,
 				EnableAPIFields:                  "alpha",
 				SendCloudEventsForRuns:           true,
+				EmitPipelineTaskEvents:           true,
 				EmbeddedStatus:                   "both",
 				EnableSpire:                      true,
 			},
@@ -148,6 +149,7 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		EnableCustomTasks:                config.DefaultEnableCustomTasks,
 		EnableAPIFields:                  config.DefaultEnableAPIFields,
 		SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
+		EmitPipelineTaskEvents:           config.DefaultEmitPipelineTaskEvents,
 		EmbeddedStatus:                   config.DefaultEmbeddedStatus,
 		EnableSpire:                      config.DefaultEnableSpire,
 	}
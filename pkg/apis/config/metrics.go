@@ -17,7 +17,12 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/metrics"
 )
 
@@ -35,6 +40,12 @@ const (
 	// metricsDurationPipelinerunType determines what type of
 	// metrics to use for aggregating duration for pipelinerun
 	metricsDurationPipelinerunType = "metrics.pipelinerun.duration-type"
+	// metricsLabelsKey is the comma-separated list of the labels of the
+	// pipelineruns and taskruns copied onto the tags of their metrics
+	metricsLabelsKey = "metrics.labels"
+	// metricsLabelsMaxValuesKey is the maximum number of combinations of the
+	// values of the labels copied onto the tags of metrics recorded
+	metricsLabelsMaxValuesKey = "metrics.labels.max-values"
 
 	// DefaultTaskrunLevel determines to what level to aggregate metrics
 	// when it isn't specified in configmap
@@ -80,6 +91,11 @@ const (
 	// DurationPipelinerunTypeLastValue specify that lastValue or
 	// gauge type metrics need to be use for Duration of Pipelinerun
 	DurationPipelinerunTypeLastValue = "lastvalue"
+
+	// DefaultMetricsLabelsMaxValues is the maximum number of combinations of
+	// the values of the labels copied onto the tags of metrics recorded when
+	// it isn't specified in configmap
+	DefaultMetricsLabelsMaxValues = 100
 )
 
 // Metrics holds the configurations for the metrics
@@ -89,6 +105,8 @@ type Metrics struct {
 	PipelinerunLevel        string
 	DurationTaskrunType     string
 	DurationPipelinerunType string
+	Labels                  []string
+	LabelsMaxValues         int
 }
 
 // GetMetricsConfigName returns the name of the configmap containing all
@@ -110,7 +128,21 @@ func (cfg *Metrics) Equals(other *Metrics) bool {
 	return other.TaskrunLevel == cfg.TaskrunLevel &&
 		other.PipelinerunLevel == cfg.PipelinerunLevel &&
 		other.DurationTaskrunType == cfg.DurationTaskrunType &&
-		other.DurationPipelinerunType == cfg.DurationPipelinerunType
+		other.DurationPipelinerunType == cfg.DurationPipelinerunType &&
+		equalStrings(other.Labels, cfg.Labels) &&
+		other.LabelsMaxValues == cfg.LabelsMaxValues
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // newMetricsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -120,6 +152,7 @@ func newMetricsFromMap(cfgMap map[string]string) (*Metrics, error) {
 		PipelinerunLevel:        DefaultPipelinerunLevel,
 		DurationTaskrunType:     DefaultDurationTaskrunType,
 		DurationPipelinerunType: DefaultDurationPipelinerunType,
+		LabelsMaxValues:         DefaultMetricsLabelsMaxValues,
 	}
 
 	if taskrunLevel, ok := cfgMap[metricsTaskrunLevelKey]; ok {
@@ -135,6 +168,25 @@ func newMetricsFromMap(cfgMap map[string]string) (*Metrics, error) {
 	if durationPipelinerun, ok := cfgMap[metricsDurationPipelinerunType]; ok {
 		tc.DurationPipelinerunType = durationPipelinerun
 	}
+	if labels, ok := cfgMap[metricsLabelsKey]; ok {
+		for _, label := range strings.Split(labels, ",") {
+			label = strings.TrimSpace(label)
+			if label == "" {
+				continue
+			}
+			if errs := validation.IsQualifiedName(label); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid label %q in %s: %s", label, metricsLabelsKey, strings.Join(errs, ", "))
+			}
+			tc.Labels = append(tc.Labels, label)
+		}
+	}
+	if maxValues, ok := cfgMap[metricsLabelsMaxValuesKey]; ok {
+		value, err := strconv.Atoi(maxValues)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("%s must be a positive integer, got %q", metricsLabelsMaxValuesKey, maxValues)
+		}
+		tc.LabelsMaxValues = value
+	}
 	return &tc, nil
 }
 
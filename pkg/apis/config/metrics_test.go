@@ -23,6 +23,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestNewMetricsFromConfigMap(t *testing.T) {
@@ -38,6 +39,7 @@ func TestNewMetricsFromConfigMap(t *testing.T) {
 				PipelinerunLevel:        config.PipelinerunLevelAtPipelinerun,
 				DurationTaskrunType:     config.DurationPipelinerunTypeHistogram,
 				DurationPipelinerunType: config.DurationPipelinerunTypeHistogram,
+				LabelsMaxValues:         config.DefaultMetricsLabelsMaxValues,
 			},
 			fileName: config.GetMetricsConfigName(),
 		},
@@ -47,9 +49,21 @@ func TestNewMetricsFromConfigMap(t *testing.T) {
 				PipelinerunLevel:        config.PipelinerunLevelAtNS,
 				DurationTaskrunType:     config.DurationTaskrunTypeHistogram,
 				DurationPipelinerunType: config.DurationPipelinerunTypeLastValue,
+				LabelsMaxValues:         config.DefaultMetricsLabelsMaxValues,
 			},
 			fileName: "config-observability-namespacelevel",
 		},
+		{
+			expectedConfig: &config.Metrics{
+				TaskrunLevel:            config.TaskrunLevelAtTask,
+				PipelinerunLevel:        config.PipelinerunLevelAtPipeline,
+				DurationTaskrunType:     config.DurationTaskrunTypeHistogram,
+				DurationPipelinerunType: config.DurationPipelinerunTypeHistogram,
+				Labels:                  []string{"team", "app.kubernetes.io/name", "tekton.dev/pipeline"},
+				LabelsMaxValues:         20,
+			},
+			fileName: "config-observability-labels",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -64,10 +78,30 @@ func TestNewMetricsFromEmptyConfigMap(t *testing.T) {
 		PipelinerunLevel:        config.PipelinerunLevelAtPipeline,
 		DurationTaskrunType:     config.DurationPipelinerunTypeHistogram,
 		DurationPipelinerunType: config.DurationPipelinerunTypeHistogram,
+		LabelsMaxValues:         config.DefaultMetricsLabelsMaxValues,
 	}
 	verifyConfigFileWithExpectedMetricsConfig(t, MetricsConfigEmptyName, expectedConfig)
 }
 
+func TestNewMetricsFromConfigMapWithError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data map[string]string
+	}{{
+		name: "invalid label",
+		data: test.ConfigMapFromTestFile(t, "config-observability-labels-err").Data,
+	}, {
+		name: "invalid max values",
+		data: map[string]string{"metrics.labels.max-values": "0"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := config.NewMetricsFromConfigMap(&corev1.ConfigMap{Data: tc.data}); err == nil {
+				t.Error("Expected an error but got none")
+			}
+		})
+	}
+}
+
 func verifyConfigFileWithExpectedMetricsConfig(t *testing.T, fileName string, expectedConfig *config.Metrics) {
 	cm := test.ConfigMapFromTestFile(t, fileName)
 	if ab, err := config.NewMetricsFromConfigMap(cm); err == nil {
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// tracingEnabledKey determines whether the controllers export traces
+	tracingEnabledKey = "tracing.enabled"
+	// tracingEndpointKey is the URL of the OTLP/HTTP collector the traces are exported to
+	tracingEndpointKey = "tracing.endpoint"
+
+	// DefaultTracingEnabled determines whether traces are exported when it isn't
+	// specified in configmap
+	DefaultTracingEnabled = false
+	// DefaultTracingEndpoint is the collector traces are exported to when it isn't
+	// specified in configmap
+	DefaultTracingEndpoint = "http://otel-collector.observability.svc.cluster.local:4318"
+)
+
+// Tracing holds the configurations for tracing
+// +k8s:deepcopy-gen=true
+type Tracing struct {
+	Enabled  bool
+	Endpoint string
+}
+
+// GetTracingConfigName returns the name of the configmap containing all
+// customizations for tracing, which is the observability configmap.
+func GetTracingConfigName() string {
+	return GetMetricsConfigName()
+}
+
+// Equals returns true if two Configs are identical
+func (cfg *Tracing) Equals(other *Tracing) bool {
+	if cfg == nil && other == nil {
+		return true
+	}
+
+	if cfg == nil || other == nil {
+		return false
+	}
+
+	return other.Enabled == cfg.Enabled &&
+		other.Endpoint == cfg.Endpoint
+}
+
+// NewTracingFromMap returns a Config given a map corresponding to a ConfigMap
+func NewTracingFromMap(cfgMap map[string]string) (*Tracing, error) {
+	tc := Tracing{
+		Enabled:  DefaultTracingEnabled,
+		Endpoint: DefaultTracingEndpoint,
+	}
+
+	if enabled, ok := cfgMap[tracingEnabledKey]; ok {
+		value, err := strconv.ParseBool(enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q: %w", enabled, err)
+		}
+		tc.Enabled = value
+	}
+
+	if endpoint, ok := cfgMap[tracingEndpointKey]; ok {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing endpoint %q: %w", endpoint, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("tracing endpoint %q must be an http or https URL", endpoint)
+		}
+		tc.Endpoint = endpoint
+	}
+	return &tc, nil
+}
+
+// NewTracingFromConfigMap returns a Config for the given configmap
+func NewTracingFromConfigMap(config *corev1.ConfigMap) (*Tracing, error) {
+	return NewTracingFromMap(config.Data)
+}
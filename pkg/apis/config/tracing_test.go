@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewTracingFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		expectedConfig *config.Tracing
+		fileName       string
+	}{{
+		expectedConfig: &config.Tracing{
+			Enabled:  false,
+			Endpoint: config.DefaultTracingEndpoint,
+		},
+		fileName: "config-observability-empty",
+	}, {
+		expectedConfig: &config.Tracing{
+			Enabled:  true,
+			Endpoint: "https://collector.example.com:4318/v1/traces",
+		},
+		fileName: "config-observability-tracing",
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			tracing, err := config.NewTracingFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewTracingFromConfigMap(actual) = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, tracing); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewTracingFromConfigMapErrors(t *testing.T) {
+	cm := test.ConfigMapFromTestFile(t, "config-observability-tracing-invalid-endpoint")
+	if _, err := config.NewTracingFromConfigMap(cm); err == nil {
+		t.Error("expected error but received nil")
+	}
+}
@@ -57,6 +57,50 @@ func (in *ArtifactPVC) DeepCopy() *ArtifactPVC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsSink) DeepCopyInto(out *CloudEventsSink) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsSink.
+func (in *CloudEventsSink) DeepCopy() *CloudEventsSink {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyPolicy) DeepCopyInto(out *ConcurrencyPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyPolicy.
+func (in *ConcurrencyPolicy) DeepCopy() *ConcurrencyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaults) DeepCopyInto(out *Defaults) {
 	*out = *in
@@ -70,6 +114,23 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 		*out = new(pod.AffinityAssistantTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultCloudEventsNamespaceSinks != nil {
+		in, out := &in.DefaultCloudEventsNamespaceSinks, &out.DefaultCloudEventsNamespaceSinks
+		*out = make([]CloudEventsSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultCloudEventsAllowedRunSinks != nil {
+		in, out := &in.DefaultCloudEventsAllowedRunSinks, &out.DefaultCloudEventsAllowedRunSinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultConcurrencyPolicies != nil {
+		in, out := &in.DefaultConcurrencyPolicies, &out.DefaultConcurrencyPolicies
+		*out = make([]ConcurrencyPolicy, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -102,6 +163,11 @@ func (in *FeatureFlags) DeepCopy() *FeatureFlags {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -114,3 +180,19 @@ func (in *Metrics) DeepCopy() *Metrics {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
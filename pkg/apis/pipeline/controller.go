@@ -36,4 +36,7 @@ const (
 
 	// RunControllerName holds the name of the Custom Task controller
 	RunControllerName = "Run"
+
+	// ApprovalTaskControllerName holds the name of the ApprovalTask controller
+	ApprovalTaskControllerName = "ApprovalTask"
 )
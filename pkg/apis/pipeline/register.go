@@ -46,6 +46,18 @@ const (
 	// MemberOfLabelKey is used as the label identifier for a PipelineTask
 	// Set to Tasks/Finally depending on the position of the PipelineTask
 	MemberOfLabelKey = GroupName + "/memberOf"
+
+	// CacheKeyLabelKey is used as the label identifier for the cache key of a TaskRun
+	// created for a PipelineTask with a cache
+	CacheKeyLabelKey = GroupName + "/cacheKey"
+
+	// CacheContextAnnotationKey is used as the annotation identifier for the digest of the
+	// inputs of the cache key of a TaskRun which its spec doesn't hold
+	CacheContextAnnotationKey = GroupName + "/cacheContext"
+
+	// LoopIterationLabelKey is used as the label identifier for the iteration of a TaskRun
+	// created for a PipelineTask with a loop
+	LoopIterationLabelKey = GroupName + "/loopIteration"
 )
 
 var (
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// EventDeliveryState is the state of the delivery of a cloud event
+type EventDeliveryState string
+
+const (
+	// EventDeliveryStatePending is the state of a cloud event which is being sent
+	EventDeliveryStatePending EventDeliveryState = "Pending"
+	// EventDeliveryStateDelivered is the state of a cloud event delivered to its sink
+	EventDeliveryStateDelivered EventDeliveryState = "Delivered"
+	// EventDeliveryStateDeadLettered is the state of a cloud event which could not be
+	// delivered to its sink and was delivered to the dead-letter sink instead
+	EventDeliveryStateDeadLettered EventDeliveryState = "DeadLettered"
+	// EventDeliveryStateFailed is the state of a cloud event which could not be delivered
+	EventDeliveryStateFailed EventDeliveryState = "Failed"
+)
+
+// EventDelivery is the state of the delivery of a cloud event about the lifecycle
+// of a run to one of its sinks
+type EventDelivery struct {
+	// Type is the type of the cloud event
+	Type string `json:"type"`
+	// Sink is the sink the cloud event is sent to
+	Sink string `json:"sink"`
+	// State is the state of the delivery of the cloud event
+	State EventDeliveryState `json:"state"`
+	// Attempts is the number of attempts to send the cloud event to its sink
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+	// LastError is the error of the last failed attempt to send the cloud event
+	// to its sink
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// CompletionTime is the time the delivery of the cloud event completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -328,6 +328,10 @@ func validatePipelineParametersVariablesInMatrixParameters(matrix []Param, prefi
 func validateParametersInTaskMatrix(matrix *Matrix) (errs *apis.FieldError) {
 	if matrix != nil {
 		for _, param := range matrix.Params {
+			// A reference to a whole array result is resolved to an array before the fan out.
+			if param.Value.Type == ParamTypeString && isWholeArrayResultRef(param.Value.StringVal) {
+				continue
+			}
 			if param.Value.Type != ParamTypeArray {
 				errs = errs.Also(apis.ErrInvalidValue("parameters of type array only are allowed in matrix", "").ViaFieldKey("matrix", param.Name))
 			}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -176,6 +177,21 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetryPolicy defines when and how soon a failed PipelineTask is retried.
+	// It requires Retries to be set.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Cache enables reusing the results of a previous successful TaskRun of
+	// this PipelineTask with the same cache key instead of running it again.
+	// +optional
+	Cache *PipelineTaskCache `json:"cache,omitempty"`
+
+	// Loop re-runs the PipelineTask, creating a new TaskRun for each iteration,
+	// until a condition over its own results holds.
+	// +optional
+	Loop *PipelineTaskLoop `json:"loop,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -202,12 +218,239 @@ type PipelineTask struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnError defines the exiting behavior of the PipelineRun when this PipelineTask fails.
+	// Set to "continue" to record the failure and keep running the other PipelineTasks,
+	// including the ones depending on this PipelineTask. Defaults to "stopAndFail".
+	// +optional
+	OnError PipelineTaskOnErrorType `json:"onError,omitempty"`
+}
+
+// PipelineTaskOnErrorType defines the supported exiting behaviors of a PipelineRun
+// when one of its PipelineTasks fails
+type PipelineTaskOnErrorType string
+
+const (
+	// PipelineTaskStopAndFail stops scheduling new PipelineTasks and fails the PipelineRun
+	PipelineTaskStopAndFail PipelineTaskOnErrorType = "stopAndFail"
+	// PipelineTaskContinue records the failure and keeps running the PipelineRun
+	PipelineTaskContinue PipelineTaskOnErrorType = "continue"
+)
+
+// PipelineTaskCache defines how the cache key of a PipelineTask is computed and
+// how long a cached TaskRun can be reused. The cache key is computed from the
+// resolved TaskSpec, the params, the workspace bindings and the TaskRun spec of the
+// PipelineTask, its Inputs and the files of its Workspaces.
+type PipelineTaskCache struct {
+	// Inputs are additional values mixed into the cache key, e.g. the digest of
+	// the files of a Workspace produced as a result by a previous PipelineTask.
+	// +optional
+	// +listType=atomic
+	Inputs []string `json:"inputs,omitempty"`
+
+	// Workspaces are the names of the workspaces of the PipelineTask whose files
+	// are mixed into the cache key. They must be bound to a ConfigMap or a Secret.
+	// +optional
+	// +listType=atomic
+	Workspaces []string `json:"workspaces,omitempty"`
+
+	// MaxAge is how long after its completion a TaskRun can be reused.
+	// Defaults to reusing TaskRuns of any age.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// PipelineTaskLoop defines when a PipelineTask is run again. Each iteration runs
+// in a new TaskRun, created once the TaskRun of the previous iteration succeeded.
+type PipelineTaskLoop struct {
+	// Until is a list of when expressions over the results of the PipelineTask,
+	// referenced as $(tasks.<pipelineTask>.results.<result>). The PipelineTask is
+	// run again until all of them evaluate to true.
+	// +listType=atomic
+	Until WhenExpressions `json:"until"`
+
+	// MaxIterations is the maximum number of times the PipelineTask runs. The
+	// PipelineTask fails if Until doesn't hold after the last iteration.
+	MaxIterations int `json:"maxIterations"`
+
+	// Delay is how long to wait after an iteration completed before starting the
+	// next one. Defaults to starting the next iteration immediately.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+// RetryPolicy defines when and how soon a failed PipelineTask is retried
+type RetryPolicy struct {
+	// Backoff is the delay before the first retry. The delay is doubled on each
+	// following retry. Defaults to retrying immediately.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// MaxBackoff is the upper bound of the delay between two retries.
+	// Defaults to one hour.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// RetryOn is the list of failure reasons of the TaskRun or Run that are retried,
+	// e.g. "TaskRunTimeout". Failures with any other reason are not retried.
+	// +optional
+	// +listType=atomic
+	RetryOn []string `json:"retryOn,omitempty"`
+
+	// NoRetryOn is the list of failure reasons of the TaskRun or Run that are never retried.
+	// +optional
+	// +listType=atomic
+	NoRetryOn []string `json:"noRetryOn,omitempty"`
+}
+
+// IsRetryable returns true if a TaskRun or Run that failed with the given reason
+// may be retried according to the RetryPolicy.
+func (rp *RetryPolicy) IsRetryable(reason string) bool {
+	if rp == nil {
+		return true
+	}
+	if len(rp.RetryOn) > 0 {
+		return sets.NewString(rp.RetryOn...).Has(reason)
+	}
+	return !sets.NewString(rp.NoRetryOn...).Has(reason)
+}
+
+// DefaultRetryMaxBackoff is the upper bound of the delay between two retries of a
+// RetryPolicy without MaxBackoff.
+const DefaultRetryMaxBackoff = time.Hour
+
+// Delay returns how long to wait before the next retry, given the number of retries
+// already done.
+func (rp *RetryPolicy) Delay(retriesDone int) time.Duration {
+	if rp == nil || rp.Backoff == nil || rp.Backoff.Duration <= 0 {
+		return 0
+	}
+	maxBackoff := DefaultRetryMaxBackoff
+	if rp.MaxBackoff != nil {
+		maxBackoff = rp.MaxBackoff.Duration
+	}
+	delay := rp.Backoff.Duration
+	for i := 0; i < retriesDone && delay < maxBackoff; i++ {
+		// Saturate before doubling, which could overflow
+		if delay > maxBackoff/2 {
+			return maxBackoff
+		}
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
+
+func (pt PipelineTask) validateRetryPolicy(ctx context.Context) (errs *apis.FieldError) {
+	rp := pt.RetryPolicy
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "retryPolicy", config.AlphaAPIFields).ViaField("retryPolicy"))
+	if pt.Retries <= 0 {
+		errs = errs.Also(apis.ErrGeneric("retryPolicy requires retries to be set", "retryPolicy", "retries"))
+	}
+	if rp.Backoff != nil && rp.Backoff.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(rp.Backoff.Duration.String()+" should be >= 0", "retryPolicy.backoff"))
+	}
+	if rp.MaxBackoff != nil {
+		if rp.MaxBackoff.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(rp.MaxBackoff.Duration.String()+" should be >= 0", "retryPolicy.maxBackoff"))
+		} else if rp.Backoff != nil && rp.MaxBackoff.Duration < rp.Backoff.Duration {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= backoff %s", rp.MaxBackoff.Duration, rp.Backoff.Duration), "retryPolicy.maxBackoff"))
+		}
+	}
+	if len(rp.RetryOn) > 0 && len(rp.NoRetryOn) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("retryPolicy.retryOn", "retryPolicy.noRetryOn"))
+	}
+	return errs
+}
+
+func (pt PipelineTask) validateCache(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "cache", config.AlphaAPIFields).ViaField("cache"))
+	if (pt.TaskRef != nil && pt.TaskRef.APIVersion != "") || (pt.TaskSpec != nil && pt.TaskSpec.APIVersion != "") {
+		errs = errs.Also(apis.ErrGeneric("cache is not supported for custom tasks", "cache"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrMultipleOneOf("cache", "matrix"))
+	}
+	for i, input := range pt.Cache.Inputs {
+		if input == "" {
+			errs = errs.Also(apis.ErrInvalidValue("empty input", "").ViaFieldIndex("inputs", i).ViaField("cache"))
+		}
+	}
+	workspaces := sets.NewString()
+	for _, ws := range pt.Workspaces {
+		workspaces.Insert(ws.Name)
+	}
+	for i, name := range pt.Cache.Workspaces {
+		if !workspaces.Has(name) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a workspace of the pipeline task", name), "").ViaFieldIndex("workspaces", i).ViaField("cache"))
+		}
+	}
+	if pt.Cache.MaxAge != nil && pt.Cache.MaxAge.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(pt.Cache.MaxAge.Duration.String()+" should be >= 0", "cache.maxAge"))
+	}
+	return errs
+}
+
+func (pt PipelineTask) validateLoop(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "loop", config.AlphaAPIFields).ViaField("loop"))
+	if (pt.TaskRef != nil && pt.TaskRef.APIVersion != "") || (pt.TaskSpec != nil && pt.TaskSpec.APIVersion != "") {
+		errs = errs.Also(apis.ErrGeneric("loop is not supported for custom tasks", "loop"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrMultipleOneOf("loop", "matrix"))
+	}
+	if pt.Cache != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("loop", "cache"))
+	}
+	if len(pt.Loop.Until) == 0 {
+		errs = errs.Also(apis.ErrMissingField("loop.until"))
+	} else {
+		errs = errs.Also(pt.Loop.Until.validateWhenExpressionsFields().ViaField("until").ViaField("loop"))
+	}
+	for i, we := range pt.Loop.Until {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		for _, expression := range expressions {
+			ref := NewResultRefs([]string{expression})
+			switch {
+			case len(ref) != 1 || ref[0].PipelineTask != pt.Name:
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("$(%s) is not a result of pipeline task %q", expression, pt.Name), "").ViaFieldIndex("until", i).ViaField("loop"))
+			case ref[0].Property != "" || arrayIndexingRegex.MatchString(expression) || !pt.isStringResult(ref[0].Result):
+				// Only the string results of the TaskRun are substituted in until
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("$(%s) is not a string result, array and object results are not supported in loop.until", expression), "").ViaFieldIndex("until", i).ViaField("loop"))
+			}
+		}
+	}
+	if pt.Loop.MaxIterations <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be > 0", pt.Loop.MaxIterations), "loop.maxIterations"))
+	}
+	if pt.Loop.Delay != nil && pt.Loop.Delay.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(pt.Loop.Delay.Duration.String()+" should be >= 0", "loop.delay"))
+	}
+	return errs
+}
+
+// isStringResult returns false if the embedded TaskSpec of the PipelineTask declares the
+// result with another type than string. The type of the results of a referenced Task is
+// unknown until it is resolved.
+func (pt PipelineTask) isStringResult(name string) bool {
+	if pt.TaskSpec == nil {
+		return true
+	}
+	for _, r := range pt.TaskSpec.Results {
+		if r.Name == name {
+			return r.Type == "" || r.Type == ResultsTypeString
+		}
+	}
+	return true
 }
 
 // Matrix is used to fan out Tasks in a Pipeline
 type Matrix struct {
 	// Params is a list of parameters used to fan out the pipelineTask
-	// Params takes only `Parameters` of type `"array"`
+	// Params takes only `Parameters` of type `"array"`, or references to whole array results
+	// of other `PipelineTasks` which are resolved before fanning out.
 	// Each array element is supplied to the `PipelineTask` by substituting `params` of type `"string"` in the underlying `Task`.
 	// The names of the `params` in the `Matrix` must match the names of the `params` in the underlying `Task` that they will be substituting.
 	// +listType=atomic
@@ -479,6 +722,25 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	default:
 		errs = errs.Also(pt.validateTask(ctx))
 	}
+
+	if pt.RetryPolicy != nil {
+		errs = errs.Also(pt.validateRetryPolicy(ctx))
+	}
+
+	if pt.Cache != nil {
+		errs = errs.Also(pt.validateCache(ctx))
+	}
+
+	if pt.Loop != nil {
+		errs = errs.Also(pt.validateLoop(ctx))
+	}
+
+	if pt.OnError != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "onError", config.AlphaAPIFields).ViaField("onError"))
+		if pt.OnError != PipelineTaskContinue && pt.OnError != PipelineTaskStopAndFail {
+			errs = errs.Also(apis.ErrInvalidValue(pt.OnError, "onError", fmt.Sprintf("PipelineTask onError must be either %q or %q", PipelineTaskContinue, PipelineTaskStopAndFail)))
+		}
+	}
 	return
 }
 
@@ -19,12 +19,15 @@ package v1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -321,6 +324,261 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 			Paths:   []string{"taskRef.kind"},
 		},
 		wc: enableFeatures(t, []string{"enable-custom-tasks"}),
+	}, {
+		name: "invalid onError value",
+		p: PipelineTask{
+			Name:    "invalid-on-error",
+			TaskRef: &TaskRef{Name: "foo"},
+			OnError: "ignore",
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: ignore`,
+			Paths:   []string{"onError"},
+			Details: `PipelineTask onError must be either "continue" or "stopAndFail"`,
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "onError disallowed without alpha feature gate",
+		p: PipelineTask{
+			Name:    "on-error",
+			TaskRef: &TaskRef{Name: "foo"},
+			OnError: PipelineTaskContinue,
+		},
+		expectedError: apis.FieldError{
+			Message: `onError requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
+	}, {
+		name: "retryPolicy without retries",
+		p: PipelineTask{
+			Name:        "retry-policy",
+			TaskRef:     &TaskRef{Name: "foo"},
+			RetryPolicy: &RetryPolicy{RetryOn: []string{"TaskRunTimeout"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `retryPolicy requires retries to be set`,
+			Paths:   []string{"retries", "retryPolicy"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "retryPolicy with maxBackoff lower than backoff",
+		p: PipelineTask{
+			Name:    "retry-policy",
+			TaskRef: &TaskRef{Name: "foo"},
+			Retries: 3,
+			RetryPolicy: &RetryPolicy{
+				Backoff:    &metav1.Duration{Duration: time.Minute},
+				MaxBackoff: &metav1.Duration{Duration: time.Second},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: 1s should be >= backoff 1m0s`,
+			Paths:   []string{"retryPolicy.maxBackoff"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "retryPolicy with both retryOn and noRetryOn",
+		p: PipelineTask{
+			Name:    "retry-policy",
+			TaskRef: &TaskRef{Name: "foo"},
+			Retries: 3,
+			RetryPolicy: &RetryPolicy{
+				RetryOn:   []string{"TaskRunTimeout"},
+				NoRetryOn: []string{"Failed"},
+			},
+		},
+		expectedError: *apis.ErrMultipleOneOf("retryPolicy.retryOn", "retryPolicy.noRetryOn"),
+		wc:            config.EnableAlphaAPIFields,
+	}, {
+		name: "retryPolicy disallowed without alpha feature gate",
+		p: PipelineTask{
+			Name:        "retry-policy",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Retries:     3,
+			RetryPolicy: &RetryPolicy{RetryOn: []string{"TaskRunTimeout"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `retryPolicy requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
+	}, {
+		name: "cache with a negative maxAge",
+		p: PipelineTask{
+			Name:    "cached",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &PipelineTaskCache{MaxAge: &metav1.Duration{Duration: -time.Hour}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1h0m0s should be >= 0`,
+			Paths:   []string{"cache.maxAge"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "cache with an empty input",
+		p: PipelineTask{
+			Name:    "cached",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &PipelineTaskCache{Inputs: []string{"$(tasks.digest.results.sha)", ""}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: empty input`,
+			Paths:   []string{"cache.inputs[1]"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "cache with a custom task",
+		p: PipelineTask{
+			Name:    "cached",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: "foo"},
+			Cache:   &PipelineTaskCache{},
+		},
+		expectedError: apis.FieldError{
+			Message: `cache is not supported for custom tasks`,
+			Paths:   []string{"cache"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "cache disallowed without alpha feature gate",
+		p: PipelineTask{
+			Name:    "cached",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &PipelineTaskCache{},
+		},
+		expectedError: apis.FieldError{
+			Message: `cache requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
+	}, {
+		name: "loop until referencing the results of another pipeline task",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop: &PipelineTaskLoop{
+				Until: WhenExpressions{
+					{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}},
+					{Input: "$(tasks.deploy.results.status)", Operator: selection.In, Values: []string{"done"}},
+				},
+				MaxIterations: 10,
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(tasks.deploy.results.status) is not a result of pipeline task "poll"`,
+			Paths:   []string{"loop.until[1]"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "loop until referencing an element of an array result",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop: &PipelineTaskLoop{
+				Until:         WhenExpressions{{Input: "$(tasks.poll.results.statuses[0])", Operator: selection.In, Values: []string{"healthy"}}},
+				MaxIterations: 10,
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(tasks.poll.results.statuses[0]) is not a string result, array and object results are not supported in loop.until`,
+			Paths:   []string{"loop.until[0]"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "loop until referencing a property of an object result",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop: &PipelineTaskLoop{
+				Until:         WhenExpressions{{Input: "$(tasks.poll.results.canary.status)", Operator: selection.In, Values: []string{"healthy"}}},
+				MaxIterations: 10,
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(tasks.poll.results.canary.status) is not a string result, array and object results are not supported in loop.until`,
+			Paths:   []string{"loop.until[0]"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "loop until referencing an array result of the embedded task",
+		p: PipelineTask{
+			Name: "poll",
+			TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+				Steps:   []Step{{Name: "poll", Image: "busybox"}},
+				Results: []TaskResult{{Name: "status"}, {Name: "statuses", Type: ResultsTypeArray}},
+			}},
+			Loop: &PipelineTaskLoop{
+				Until: WhenExpressions{
+					{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}},
+					{Input: "$(tasks.poll.results.statuses)", Operator: selection.In, Values: []string{"healthy"}},
+				},
+				MaxIterations: 10,
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(tasks.poll.results.statuses) is not a string result, array and object results are not supported in loop.until`,
+			Paths:   []string{"loop.until[1]"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "loop without until",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop:    &PipelineTaskLoop{MaxIterations: 10},
+		},
+		expectedError: *apis.ErrMissingField("loop.until"),
+		wc:            config.EnableAlphaAPIFields,
+	}, {
+		name: "loop without maxIterations",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop: &PipelineTaskLoop{
+				Until: WhenExpressions{{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: 0 should be > 0`,
+			Paths:   []string{"loop.maxIterations"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "loop with a negative delay",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop: &PipelineTaskLoop{
+				Until:         WhenExpressions{{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}}},
+				MaxIterations: 10,
+				Delay:         &metav1.Duration{Duration: -time.Minute},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1m0s should be >= 0`,
+			Paths:   []string{"loop.delay"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "loop with a cache",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Cache:   &PipelineTaskCache{},
+			Loop: &PipelineTaskLoop{
+				Until:         WhenExpressions{{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}}},
+				MaxIterations: 10,
+			},
+		},
+		expectedError: *apis.ErrMultipleOneOf("loop", "cache"),
+		wc:            config.EnableAlphaAPIFields,
+	}, {
+		name: "loop disallowed without alpha feature gate",
+		p: PipelineTask{
+			Name:    "poll",
+			TaskRef: &TaskRef{Name: "foo"},
+			Loop: &PipelineTaskLoop{
+				Until:         WhenExpressions{{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}}},
+				MaxIterations: 10,
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `loop requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -656,6 +914,28 @@ func TestPipelineTask_validateMatrix(t *testing.T) {
 					Name: "a-param", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"$(tasks.foo-task.results.a-result)"}},
 				}}},
 		},
+	}, {
+		name: "parameters in matrix contain whole array results references",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: &Matrix{
+				Params: []Param{{
+					Name: "a-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.foo-task.results.an-array-result[*])"},
+				}}},
+		},
+	}, {
+		name: "parameters in matrix contain string results references",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: &Matrix{
+				Params: []Param{{
+					Name: "a-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.foo-task.results.a-result)"},
+				}}},
+		},
+		wantErrs: &apis.FieldError{
+			Message: "invalid value: parameters of type array only are allowed in matrix",
+			Paths:   []string{"matrix[a-param]"},
+		},
 	}, {
 		name: "count of combinations of parameters in the matrix exceeds the maximum",
 		pt: &PipelineTask{
@@ -250,6 +250,7 @@ func filter(arr []string, cond func(string) bool) []string {
 func validatePipelineResults(results []PipelineResult, tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
 	pipelineTaskNames := getPipelineTasksNames(tasks)
 	pipelineFinallyTaskNames := getPipelineTasksNames(finally)
+	declaredResults := embeddedTaskResults(tasks, finally)
 	for idx, result := range results {
 		expressions, ok := GetVarSubstitutionExpressionsForPipelineResult(result)
 		if !ok {
@@ -269,15 +270,111 @@ func validatePipelineResults(results []PipelineResult, tasks []PipelineTask, fin
 				"value").ViaFieldIndex("results", idx))
 		}
 
-		if !taskContainsResult(result.Value.StringVal, pipelineTaskNames, pipelineFinallyTaskNames) {
-			errs = errs.Also(apis.ErrInvalidValue("referencing a nonexistent task",
-				"value").ViaFieldIndex("results", idx))
+		// The elements of array and object values may also reference tasks
+		values := []string{result.Value.StringVal}
+		for _, v := range append(result.Value.ArrayVal, objectValues(result.Value.ObjectVal)...) {
+			if strings.Contains(v, "$(") {
+				values = append(values, v)
+			}
+		}
+		for _, value := range values {
+			if !taskContainsResult(value, pipelineTaskNames, pipelineFinallyTaskNames) {
+				errs = errs.Also(apis.ErrInvalidValue("referencing a nonexistent task",
+					"value").ViaFieldIndex("results", idx))
+				break
+			}
 		}
+
+		errs = errs.Also(validatePipelineResultTypes(result, expressions, declaredResults).ViaFieldIndex("results", idx))
 	}
 
 	return errs
 }
 
+func objectValues(object map[string]string) []string {
+	var values []string
+	for _, v := range object {
+		values = append(values, v)
+	}
+	return values
+}
+
+// embeddedTaskResults returns the results declared by the embedded Tasks of the pipeline tasks,
+// keyed by the "tasks.<name>" or "finally.<name>" prefix of their references and by result name.
+func embeddedTaskResults(tasks []PipelineTask, finally []PipelineTask) map[string]map[string]TaskResult {
+	declared := map[string]map[string]TaskResult{}
+	for prefix, pipelineTasks := range map[string][]PipelineTask{ResultTaskPart: tasks, ResultFinallyPart: finally} {
+		for _, pt := range pipelineTasks {
+			if pt.TaskSpec == nil || pt.TaskSpec.APIVersion != "" {
+				continue
+			}
+			results := map[string]TaskResult{}
+			for _, r := range pt.TaskSpec.Results {
+				results[r.Name] = r
+			}
+			declared[prefix+"."+pt.Name] = results
+		}
+	}
+	return declared
+}
+
+// validatePipelineResultTypes ensures that the results of embedded Tasks referenced by a pipeline result
+// are referenced according to their type: string results as a whole, array results by index or as a whole,
+// and object results by key or as a whole. A whole array or object result must be the entire value of the
+// pipeline result, whose type must then match, or for an array result an element of an array value.
+func validatePipelineResultTypes(result PipelineResult, expressions []string, declaredResults map[string]map[string]TaskResult) (errs *apis.FieldError) {
+	for _, expression := range expressions {
+		parts := strings.Split(expression, ".")
+		if len(parts) > 5 {
+			continue
+		}
+		resultName, idx := ParseResultName(parts[3])
+		taskResult, ok := declaredResults[parts[0]+"."+parts[1]][resultName]
+		if !ok {
+			continue
+		}
+		resultType := taskResult.Type
+		if resultType == "" {
+			resultType = ResultsTypeString
+		}
+		reference := fmt.Sprintf("$(%s)", expression)
+
+		var compatible bool
+		switch {
+		case len(parts) == 5:
+			compatible = resultType == ResultsTypeObject && idx == ""
+			if _, ok := taskResult.Properties[parts[4]]; compatible && len(taskResult.Properties) > 0 && !ok {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("object result %q of pipeline task %q has no key %q", resultName, parts[1], parts[4]), "value"))
+				continue
+			}
+		case idx == "":
+			compatible = resultType == ResultsTypeString
+		case idx == "*":
+			compatible = resultType == ResultsTypeArray || resultType == ResultsTypeObject
+		default:
+			compatible = resultType == ResultsTypeArray
+		}
+		if !compatible {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("cannot reference the %s result %q of pipeline task %q as %s", resultType, resultName, parts[1], reference), "value"))
+			continue
+		}
+		if idx != "*" {
+			continue
+		}
+
+		switch {
+		case result.Value.Type == ParamTypeString && result.Value.StringVal == reference:
+			if result.Type != "" && result.Type != resultType {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline result of type %s cannot be set to the %s result %q of pipeline task %q", result.Type, resultType, resultName, parts[1]), "type"))
+			}
+		case result.Value.Type == ParamTypeArray && resultType == ResultsTypeArray && sets.NewString(result.Value.ArrayVal...).Has(reference):
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must be the entire value of the pipeline result, or an element of an array value for an array result", reference), "value"))
+		}
+	}
+	return errs
+}
+
 // put task names in a set
 func getPipelineTasksNames(pipelineTasks []PipelineTask) sets.String {
 	pipelineTaskNames := make(sets.String)
@@ -297,16 +394,16 @@ func taskContainsResult(resultExpression string, pipelineTaskNames sets.String,
 	for _, expression := range split {
 		if expression != "" {
 			value := stripVarSubExpression("$" + expression)
-			pipelineTaskName, _, _, _, err := parseExpression(value)
+			resultRef, err := parseExpression(value)
 
 			if err != nil {
 				return false
 			}
 
-			if strings.HasPrefix(value, "tasks") && !pipelineTaskNames.Has(pipelineTaskName) {
+			if strings.HasPrefix(value, "tasks") && !pipelineTaskNames.Has(resultRef.PipelineTask) {
 				return false
 			}
-			if strings.HasPrefix(value, "finally") && !pipelineFinallyTaskNames.Has(pipelineTaskName) {
+			if strings.HasPrefix(value, "finally") && !pipelineFinallyTaskNames.Has(resultRef.PipelineTask) {
 				return false
 			}
 
@@ -811,6 +811,99 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineResults_ResultTypes(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name: "a-task",
+		TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+			Results: []TaskResult{
+				{Name: "string"},
+				{Name: "array", Type: ResultsTypeArray},
+				{Name: "object", Type: ResultsTypeObject, Properties: map[string]PropertySpec{"key": {Type: ParamTypeString}}},
+			},
+		}},
+	}}
+	finally := []PipelineTask{{
+		Name: "a-final-task",
+		TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+			Results: []TaskResult{{Name: "object", Type: ResultsTypeObject}},
+		}},
+	}}
+	for _, tc := range []struct {
+		desc          string
+		result        PipelineResult
+		expectedError *apis.FieldError
+	}{{
+		desc: "string, array element and object key in a string",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Value: *NewStructuredValues("$(tasks.a-task.results.string) $(tasks.a-task.results.array[1]) $(tasks.a-task.results.object.key)"),
+		},
+	}, {
+		desc: "whole array and string in an array",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: *NewStructuredValues("$(tasks.a-task.results.array[*])", "$(tasks.a-task.results.string)"),
+		},
+	}, {
+		desc: "whole object of a finally task",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeObject,
+			Value: *NewStructuredValues("$(finally.a-final-task.results.object[*])"),
+		},
+	}, {
+		desc: "array without index",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Value: *NewStructuredValues("$(tasks.a-task.results.array)"),
+		},
+		expectedError: apis.ErrInvalidValue(`cannot reference the array result "array" of pipeline task "a-task" as $(tasks.a-task.results.array)`, "results[0].value"),
+	}, {
+		desc: "indexed object",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Value: *NewStructuredValues("$(tasks.a-task.results.object[0])"),
+		},
+		expectedError: apis.ErrInvalidValue(`cannot reference the object result "object" of pipeline task "a-task" as $(tasks.a-task.results.object[0])`, "results[0].value"),
+	}, {
+		desc: "undeclared object key",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Value: *NewStructuredValues("$(tasks.a-task.results.object.missing)"),
+		},
+		expectedError: apis.ErrInvalidValue(`object result "object" of pipeline task "a-task" has no key "missing"`, "results[0].value"),
+	}, {
+		desc: "whole array in a string",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Value: *NewStructuredValues("$(tasks.a-task.results.string) $(tasks.a-task.results.array[*])"),
+		},
+		expectedError: apis.ErrInvalidValue(`$(tasks.a-task.results.array[*]) must be the entire value of the pipeline result, or an element of an array value for an array result`, "results[0].value"),
+	}, {
+		desc: "whole object with a different type",
+		result: PipelineResult{
+			Name:  "my-pipeline-result",
+			Type:  ResultsTypeArray,
+			Value: *NewStructuredValues("$(finally.a-final-task.results.object[*])"),
+		},
+		expectedError: apis.ErrInvalidValue(`pipeline result of type array cannot be set to the object result "object" of pipeline task "a-final-task"`, "results[0].type"),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validatePipelineResults([]PipelineResult{tc.result}, tasks, finally)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("Pipeline.validatePipelineResults() returned error for valid pipeline results: %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("Pipeline.validatePipelineResults() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestFinallyTaskResultsToPipelineResults_Success(t *testing.T) {
 	tests := []struct {
 		name string
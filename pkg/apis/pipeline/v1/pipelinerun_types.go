@@ -149,6 +149,11 @@ func (pr *PipelineRun) IsPending() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusPending
 }
 
+// IsDryRun returns true if the PipelineRun's spec status is set to DryRun state
+func (pr *PipelineRun) IsDryRun() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusDryRun
+}
+
 // GetNamespacedName returns a k8s namespaced name that identifies this PipelineRun
 func (pr *PipelineRun) GetNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}
@@ -249,6 +254,25 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Concurrency holds the priority of the PipelineRun in the queue of the
+	// concurrency policies limiting it.
+	// +optional
+	Concurrency *PipelineRunConcurrency `json:"concurrency,omitempty"`
+	// ResumeFrom is the name of a completed PipelineRun in the same namespace to resume.
+	// The PipelineTasks which succeeded in that PipelineRun, or which it skipped because
+	// of their when expressions, are not run again: their TaskRuns, Runs and results are
+	// reused, and only the remaining PipelineTasks are scheduled.
+	// +optional
+	ResumeFrom string `json:"resumeFrom,omitempty"`
+}
+
+// PipelineRunConcurrency holds the concurrency settings of a PipelineRun. The concurrency
+// policies limiting the PipelineRuns are set by the administrators in config-defaults.
+type PipelineRunConcurrency struct {
+	// Priority of the PipelineRun in the queue of a concurrency policy with the
+	// "Priority" order. Higher values are started first.
+	// +optional
+	Priority int `json:"priority,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -281,6 +305,10 @@ const (
 	// PipelineRunSpecStatusPending indicates that the user wants to postpone starting a PipelineRun
 	// until some condition is met
 	PipelineRunSpecStatusPending = "PipelineRunPending"
+
+	// PipelineRunSpecStatusDryRun indicates that the user wants to compute the execution plan
+	// of the PipelineRun without running any of its PipelineTasks
+	PipelineRunSpecStatusDryRun = "DryRun"
 )
 
 // PipelineRunStatus defines the observed state of PipelineRun
@@ -303,6 +331,9 @@ const (
 	PipelineRunReasonSuccessful PipelineRunReason = "Succeeded"
 	// PipelineRunReasonCompleted is the reason set when the PipelineRun completed successfully with one or more skipped Tasks
 	PipelineRunReasonCompleted PipelineRunReason = "Completed"
+	// PipelineRunReasonCompletedWithFailures is the reason set when the PipelineRun completed successfully
+	// but one or more PipelineTasks with onError set to continue failed
+	PipelineRunReasonCompletedWithFailures PipelineRunReason = "CompletedWithFailures"
 	// PipelineRunReasonFailed is the reason set when the PipelineRun completed with a failure
 	PipelineRunReasonFailed PipelineRunReason = "Failed"
 	// PipelineRunReasonCancelled is the reason set when the PipelineRun cancelled by the user
@@ -311,6 +342,9 @@ const (
 	PipelineRunReasonCancelled PipelineRunReason = "Cancelled"
 	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
 	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
+	// PipelineRunReasonConcurrencyLimitReached is the reason set when the PipelineRun is cancelled
+	// because its concurrency limit was reached
+	PipelineRunReasonConcurrencyLimitReached PipelineRunReason = "ConcurrencyLimitReached"
 	// PipelineRunReasonTimedOut is the reason set when the PipelineRun has timed out
 	PipelineRunReasonTimedOut PipelineRunReason = "PipelineRunTimeout"
 	// PipelineRunReasonStopping indicates that no new Tasks will be scheduled by the controller, and the
@@ -430,6 +464,118 @@ type PipelineRunStatusFields struct {
 
 	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
 	Provenance *Provenance `json:"provenance,omitempty"`
+
+	// Concurrency contains the concurrency key of the policy which queued or
+	// cancelled the PipelineRun and, while it is queued, its position in the queue.
+	// +optional
+	Concurrency *PipelineRunConcurrencyStatus `json:"concurrency,omitempty"`
+
+	// CachedTasks is the list of PipelineTasks which reused a previous TaskRun
+	// with the same cache key instead of running.
+	// +optional
+	// +listType=atomic
+	CachedTasks []CachedTask `json:"cachedTasks,omitempty"`
+
+	// Plan is the execution plan computed for a PipelineRun whose spec status is DryRun.
+	// +optional
+	Plan *PipelineRunPlan `json:"plan,omitempty"`
+
+	// EventDeliveries is the state of the delivery of the cloud events about the
+	// lifecycle of the PipelineRun to each of their sinks.
+	// +optional
+	// +listType=atomic
+	EventDeliveries []EventDelivery `json:"eventDeliveries,omitempty"`
+}
+
+// PipelineRunPlan describes what a PipelineRun would run, as far as it can be known
+// before any of its PipelineTasks runs.
+type PipelineRunPlan struct {
+	// Tasks is the plan of the PipelineTasks in the tasks section of the Pipeline.
+	// +optional
+	// +listType=atomic
+	Tasks []PlannedPipelineTask `json:"tasks,omitempty"`
+
+	// Finally is the plan of the PipelineTasks in the finally section of the Pipeline.
+	// +optional
+	// +listType=atomic
+	Finally []PlannedPipelineTask `json:"finally,omitempty"`
+}
+
+// PlannedPipelineTask describes how a PipelineTask would run.
+type PlannedPipelineTask struct {
+	// Name is the PipelineTask name
+	Name string `json:"name"`
+
+	// TaskRef is the reference to the Task, ClusterTask or Custom Task the PipelineTask runs,
+	// if it doesn't embed its spec.
+	// +optional
+	TaskRef *TaskRef `json:"taskRef,omitempty"`
+
+	// RunAfter is the list of PipelineTasks which must complete before this PipelineTask runs,
+	// whether they are listed in its runAfter or it consumes their results.
+	// +optional
+	// +listType=atomic
+	RunAfter []string `json:"runAfter,omitempty"`
+
+	// Params are the params of the PipelineTask, once the params and context of the
+	// PipelineRun are substituted. References to results are left as they are.
+	// +optional
+	// +listType=atomic
+	Params []Param `json:"params,omitempty"`
+
+	// MatrixCombinations are the params of each TaskRun or Run the matrix of the PipelineTask
+	// fans out to. It is empty if the matrix references results.
+	// +optional
+	// +listType=atomic
+	MatrixCombinations []PlannedMatrixCombination `json:"matrixCombinations,omitempty"`
+
+	// Outcome is whether the PipelineTask would run, would be skipped, or if this depends on
+	// the results of other PipelineTasks.
+	Outcome PlannedOutcome `json:"outcome"`
+
+	// SkippingReason is the reason why the PipelineTask would be skipped.
+	// +optional
+	SkippingReason SkippingReason `json:"skippingReason,omitempty"`
+}
+
+// PlannedMatrixCombination is one combination of the params of a matrix.
+type PlannedMatrixCombination struct {
+	// Params are the params of the TaskRun or Run created for this combination.
+	// +listType=atomic
+	Params []Param `json:"params"`
+}
+
+// PlannedOutcome describes whether a PipelineTask would run.
+type PlannedOutcome string
+
+const (
+	// PlannedOutcomeRun means the PipelineTask would run
+	PlannedOutcomeRun PlannedOutcome = "Run"
+	// PlannedOutcomeSkip means the PipelineTask would be skipped
+	PlannedOutcomeSkip PlannedOutcome = "Skip"
+	// PlannedOutcomeUnknown means whether the PipelineTask runs depends on the results
+	// or the execution status of other PipelineTasks
+	PlannedOutcomeUnknown PlannedOutcome = "Unknown"
+)
+
+// CachedTask describes a PipelineTask which reused a previous TaskRun.
+type CachedTask struct {
+	// Name is the PipelineTask name
+	Name string `json:"name"`
+	// TaskRunName is the name of the reused TaskRun
+	TaskRunName string `json:"taskRunName"`
+	// CacheKey is the cache key shared by the PipelineTask and the reused TaskRun
+	CacheKey string `json:"cacheKey"`
+}
+
+// PipelineRunConcurrencyStatus holds the concurrency information of a PipelineRun.
+type PipelineRunConcurrencyStatus struct {
+	// Key is the concurrency key shared by the PipelineRuns limited together.
+	Key string `json:"key"`
+	// QueuePosition is the 1-based position of the PipelineRun in the queue,
+	// or 0 once it is no longer queued.
+	// +optional
+	QueuePosition int `json:"queuePosition,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -468,6 +614,8 @@ const (
 	TasksTimedOutSkip SkippingReason = "PipelineRun Tasks timeout has been reached"
 	// FinallyTimedOutSkip means the task was skipped because the PipelineRun has passed its Timeouts.Finally.
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
+	// EmptyMatrixSkip means the task was skipped because its matrix resolved to no combinations
+	EmptyMatrixSkip SkippingReason = "Matrix resolved to no combinations"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -94,7 +94,7 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
-	errs = errs.Also(validateSpecStatus(ps.Status))
+	errs = errs.Also(validateSpecStatus(ctx, ps.Status))
 
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
@@ -110,6 +110,18 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(validateTaskRunSpec(ctx, trs).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
 
+	if ps.ResumeFrom != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "resumeFrom", config.AlphaAPIFields).ViaField("resumeFrom"))
+		// Resuming co-owns the TaskRuns and Runs of the resumed PipelineRun, which a DryRun must not change
+		if ps.Status == PipelineRunSpecStatusDryRun {
+			errs = errs.Also(apis.ErrGeneric("resumeFrom cannot be used with the DryRun status", "resumeFrom", "status"))
+		}
+	}
+
+	if ps.Concurrency != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "concurrency", config.AlphaAPIFields).ViaField("concurrency"))
+	}
+
 	return errs
 }
 
@@ -210,12 +222,14 @@ func appendParam(paramSpec []ParamSpec, params []Param) []ParamSpec {
 	return paramSpec
 }
 
-func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
+func validateSpecStatus(ctx context.Context, status PipelineRunSpecStatus) *apis.FieldError {
 	switch status {
 	case "":
 		return nil
 	case PipelineRunSpecStatusPending:
 		return nil
+	case PipelineRunSpecStatusDryRun:
+		return version.ValidateEnabledAPIFields(ctx, "DryRun status", config.AlphaAPIFields).ViaField("status")
 	case PipelineRunSpecStatusCancelled,
 		PipelineRunSpecStatusCancelledRunFinally,
 		PipelineRunSpecStatusStoppedRunFinally:
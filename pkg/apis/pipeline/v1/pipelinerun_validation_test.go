@@ -473,6 +473,36 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "concurrency disallowed without alpha feature gate",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{Priority: 1},
+		},
+		wantErr: apis.ErrGeneric("concurrency requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "resumeFrom disallowed without alpha feature gate",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			ResumeFrom:  "foo-run-1",
+		},
+		wantErr: apis.ErrGeneric("resumeFrom requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "DryRun status disallowed without alpha feature gate",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Status:      v1.PipelineRunSpecStatusDryRun,
+		},
+		wantErr: apis.ErrGeneric("DryRun status requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "resumeFrom with the DryRun status",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			ResumeFrom:  "foo-run-1",
+			Status:      v1.PipelineRunSpecStatusDryRun,
+		},
+		wantErr:     apis.ErrGeneric("resumeFrom cannot be used with the DryRun status", "resumeFrom", "status"),
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -544,6 +574,27 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid concurrency priority",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "deploy"},
+			Concurrency: &v1.PipelineRunConcurrency{Priority: 5},
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid resumeFrom",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "build"},
+			ResumeFrom:  "build-run-1",
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid DryRun status",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "build"},
+			Status:      v1.PipelineRunSpecStatusDryRun,
+		},
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -29,6 +29,10 @@ type ResultRef struct {
 	Result       string `json:"result"`
 	ResultsIndex int    `json:"resultsIndex"`
 	Property     string `json:"property"`
+	// WholeArray is true if the reference is to all the elements of an array result,
+	// e.g. `$(tasks.taskName.results.resultName[*])`, rather than to one of them.
+	// +optional
+	WholeArray bool `json:"wholeArray,omitempty"`
 }
 
 const (
@@ -62,17 +66,12 @@ var arrayIndexingRegex = regexp.MustCompile(arrayIndexing)
 func NewResultRefs(expressions []string) []*ResultRef {
 	var resultRefs []*ResultRef
 	for _, expression := range expressions {
-		pipelineTask, result, index, property, err := parseExpression(expression)
+		resultRef, err := parseExpression(expression)
 		// If the expression isn't a result but is some other expression,
 		// parseExpression will return an error, in which case we just skip that expression,
 		// since although it's not a result ref, it might be some other kind of reference
 		if err == nil {
-			resultRefs = append(resultRefs, &ResultRef{
-				PipelineTask: pipelineTask,
-				Result:       result,
-				ResultsIndex: index,
-				Property:     property,
-			})
+			resultRefs = append(resultRefs, resultRef)
 		}
 	}
 	return resultRefs
@@ -98,6 +97,15 @@ func looksLikeResultRef(expression string) bool {
 	return len(subExpressions) >= 4 && (subExpressions[0] == ResultTaskPart || subExpressions[0] == ResultFinallyPart) && subExpressions[2] == ResultResultPart
 }
 
+// isWholeArrayResultRef returns true if the given string is nothing but a reference to a
+// whole array result, e.g. "$(tasks.taskName.results.resultName[*])".
+func isWholeArrayResultRef(value string) bool {
+	if !exactVariableSubstitutionRegex.MatchString(value) || !strings.HasSuffix(value, "[*])") {
+		return false
+	}
+	return looksLikeResultRef(stripVarSubExpression(value))
+}
+
 // GetVarSubstitutionExpressionsForParam extracts all the value between "$(" and ")"" for a parameter
 func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 	var allExpressions []string
@@ -150,37 +158,46 @@ func stripVarSubExpression(expression string) string {
 }
 
 // parseExpression parses "task name", "result name", "array index" (iff it's an array result) and "object key name" (iff it's an object result)
+// into a ResultRef
 // Valid Example 1:
 // - Input: tasks.myTask.results.aStringResult
-// - Output: "myTask", "aStringResult", -1, "", nil
+// - Output: {PipelineTask: "myTask", Result: "aStringResult"}, nil
 // Valid Example 2:
 // - Input: tasks.myTask.results.anObjectResult.key1
-// - Output: "myTask", "anObjectResult", 0, "key1", nil
+// - Output: {PipelineTask: "myTask", Result: "anObjectResult", Property: "key1"}, nil
 // Valid Example 3:
 // - Input: tasks.myTask.results.anArrayResult[1]
-// - Output: "myTask", "anArrayResult", 1, "", nil
+// - Output: {PipelineTask: "myTask", Result: "anArrayResult", ResultsIndex: 1}, nil
+// Valid Example 4:
+// - Input: tasks.myTask.results.anArrayResult[*]
+// - Output: {PipelineTask: "myTask", Result: "anArrayResult", WholeArray: true}, nil
 // Invalid Example 1:
 // - Input: tasks.myTask.results.resultName.foo.bar
-// - Output: "", "", 0, "", error
+// - Output: nil, error
 // TODO: may use regex for each type to handle possible reference formats
-func parseExpression(substitutionExpression string) (string, string, int, string, error) {
+func parseExpression(substitutionExpression string) (*ResultRef, error) {
 	if looksLikeResultRef(substitutionExpression) {
 		subExpressions := strings.Split(substitutionExpression, ".")
 		// For string result: tasks.<taskName>.results.<stringResultName>
-		// For array result: tasks.<taskName>.results.<arrayResultName>[index]
+		// For array result: tasks.<taskName>.results.<arrayResultName>[index] or tasks.<taskName>.results.<arrayResultName>[*]
 		if len(subExpressions) == 4 {
 			resultName, stringIdx := ParseResultName(subExpressions[3])
-			if stringIdx != "" {
-				intIdx, _ := strconv.Atoi(stringIdx)
-				return subExpressions[1], resultName, intIdx, "", nil
+			resultRef := &ResultRef{PipelineTask: subExpressions[1], Result: resultName}
+			switch stringIdx {
+			case "":
+			case "*":
+				resultRef.WholeArray = true
+			default:
+				resultRef.ResultsIndex, _ = strconv.Atoi(stringIdx)
 			}
-			return subExpressions[1], resultName, 0, "", nil
+			return resultRef, nil
 		} else if len(subExpressions) == 5 {
 			// For object type result: tasks.<taskName>.results.<objectResultName>.<individualAttribute>
-			return subExpressions[1], subExpressions[3], 0, subExpressions[4], nil
+			return &ResultRef{PipelineTask: subExpressions[1], Result: subExpressions[3], Property: subExpressions[4]}, nil
 		}
 	}
-	return "", "", 0, "", fmt.Errorf("Must be one of the form 1). %q; 2). %q", resultExpressionFormat, objectResultExpressionFormat)
+
+	return nil, fmt.Errorf("Must be one of the form 1). %q; 2). %q", resultExpressionFormat, objectResultExpressionFormat)
 }
 
 // ParseResultName parse the input string to extract resultName and result index.
@@ -214,5 +231,11 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
 
+	if pt.Cache != nil {
+		for _, input := range pt.Cache.Inputs {
+			refs = append(refs, NewResultRefs(validateString(input))...)
+		}
+	}
+
 	return refs
 }
@@ -50,6 +50,7 @@ func TestNewResultReference(t *testing.T) {
 		want: []*v1.ResultRef{{
 			PipelineTask: "sumTask",
 			Result:       "sumResult",
+			WholeArray:   true,
 		}},
 	}, {
 		name: "Test valid expression with single object result property",
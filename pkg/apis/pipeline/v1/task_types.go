@@ -93,6 +93,37 @@ type TaskSpec struct {
 	// Results are values that this Task can output
 	// +listType=atomic
 	Results []TaskResult `json:"results,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Hermetic runs all the Steps of the Task without network access.
+	// +optional
+	Hermetic bool `json:"hermetic,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Egress lists the destinations which the hermetic Steps of the Task can still connect
+	// to, through an HTTP proxy. Each entry is a host name, a host name starting with "*."
+	// to match its subdomains, an IP address or a CIDR, which may end with a port, e.g.
+	// "proxy.golang.org:443".
+	// +optional
+	// +listType=atomic
+	Egress []string `json:"egress,omitempty"`
+}
+
+// HasHermeticSteps returns true if any of the Steps of the Task runs without network access
+func (ts *TaskSpec) HasHermeticSteps() bool {
+	if ts.Hermetic {
+		return true
+	}
+	for _, s := range ts.Steps {
+		if s.Hermetic {
+			return true
+		}
+	}
+	return false
 }
 
 // TaskList contains a list of Task
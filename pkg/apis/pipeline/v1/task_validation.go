@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
+	"github.com/tektoncd/pipeline/pkg/egress"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -92,6 +93,8 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
+	errs = errs.Also(validateHermetic(ctx, ts))
+	errs = errs.Also(validateScriptRefs(ts))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
@@ -99,6 +102,87 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateHermetic validates that the Task can only run hermetic steps with the alpha
+// feature gate enabled, that egress allow-lists are only set for hermetic steps, and that
+// the Task does not run hermetic steps alongside sidecars exposing ports, since hermetic
+// steps cannot reach them over the network.
+func validateHermetic(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	if ts.Hermetic {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "hermetic", config.AlphaAPIFields).ViaField("hermetic"))
+	}
+	if len(ts.Egress) > 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "egress", config.AlphaAPIFields).ViaField("egress"))
+		errs = errs.Also(validateEgress(ts.Egress).ViaField("egress"))
+		if !ts.HasHermeticSteps() {
+			errs = errs.Also(apis.ErrGeneric("egress can only be set for a Task with hermetic steps", "egress"))
+		}
+	}
+	for i, s := range ts.Steps {
+		if len(s.Egress) > 0 && !s.Hermetic && !ts.Hermetic {
+			errs = errs.Also(apis.ErrGeneric("egress can only be set for a hermetic step", "egress").ViaFieldIndex("steps", i))
+		}
+	}
+	if !ts.HasHermeticSteps() {
+		return errs
+	}
+	for i, s := range ts.Sidecars {
+		if len(s.Ports) > 0 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar %q exposes ports that hermetic steps cannot reach because they run without network access", s.Name), "ports").ViaFieldIndex("sidecars", i))
+		}
+	}
+	return errs
+}
+
+// validateEgress validates the entries of an egress allow-list.
+func validateEgress(entries []string) (errs *apis.FieldError) {
+	for i, entry := range entries {
+		if err := egress.ValidateEntry(entry); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(entry, apis.CurrentField, err.Error()).ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+// validateScriptRefs validates that the Workspaces holding the scripts referenced by the
+// Steps are declared by the Task, and that the Task does not run Windows scripts alongside
+// referenced scripts, since referenced scripts are placed with a Linux shell.
+func validateScriptRefs(ts *TaskSpec) (errs *apis.FieldError) {
+	hasScriptRefs := false
+	for i, s := range ts.Steps {
+		if s.ScriptRef == nil {
+			continue
+		}
+		hasScriptRefs = true
+		if s.ScriptRef.Workspace == nil || s.ScriptRef.Workspace.Name == "" {
+			continue
+		}
+		declared := false
+		for _, w := range ts.Workspaces {
+			if w.Name == s.ScriptRef.Workspace.Name {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", s.ScriptRef.Workspace.Name), "scriptRef.workspace.name").ViaFieldIndex("steps", i))
+		}
+	}
+	if !hasScriptRefs {
+		return errs
+	}
+	for i, s := range ts.Steps {
+		if strings.HasPrefix(strings.TrimSpace(s.Script), "#!win") {
+			errs = errs.Also(apis.ErrGeneric("windows scripts cannot be used with scriptRef", "script").ViaFieldIndex("steps", i))
+		}
+	}
+	for i, s := range ts.Sidecars {
+		if strings.HasPrefix(strings.TrimSpace(s.Script), "#!win") {
+			errs = errs.Also(apis.ErrGeneric("windows scripts cannot be used with scriptRef", "script").ViaFieldIndex("sidecars", i))
+		}
+	}
+	return errs
+}
+
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
@@ -273,6 +357,58 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 	if s.StderrConfig != nil {
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
 	}
+	// Hermetic is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.Hermetic {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "hermetic", config.AlphaAPIFields).ViaField("hermetic"))
+	}
+	// Egress is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if len(s.Egress) > 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "egress", config.AlphaAPIFields).ViaField("egress"))
+		errs = errs.Also(validateEgress(s.Egress).ViaField("egress"))
+	}
+	// ScriptRef is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.ScriptRef != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "scriptRef", config.AlphaAPIFields).ViaField("scriptRef"))
+		errs = errs.Also(validateScriptRef(s))
+	}
+	return errs
+}
+
+// validateScriptRef validates that a Step referencing a script does not also specify
+// a Script or a Command, and that it references exactly one complete source.
+func validateScriptRef(s Step) (errs *apis.FieldError) {
+	if s.Script != "" || len(s.Command) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "scriptRef cannot be used with script or command",
+			Paths:   []string{"scriptRef"},
+		})
+	}
+	ref := s.ScriptRef
+	switch {
+	case ref.ConfigMap == nil && ref.Workspace == nil:
+		errs = errs.Also(apis.ErrMissingOneOf("configMap", "workspace").ViaField("scriptRef"))
+	case ref.ConfigMap != nil && ref.Workspace != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("configMap", "workspace").ViaField("scriptRef"))
+	case ref.ConfigMap != nil:
+		if ref.ConfigMap.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaField("configMap").ViaField("scriptRef"))
+		}
+		if ref.ConfigMap.Key == "" {
+			errs = errs.Also(apis.ErrMissingField("key").ViaField("configMap").ViaField("scriptRef"))
+		}
+	default:
+		if ref.Workspace.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaField("workspace").ViaField("scriptRef"))
+		}
+		if ref.Workspace.Path == "" {
+			errs = errs.Also(apis.ErrMissingField("path").ViaField("workspace").ViaField("scriptRef"))
+		} else if p := filepath.Clean(ref.Workspace.Path); filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+			errs = errs.Also(apis.ErrInvalidValue(ref.Workspace.Path, "path", "path must be relative to the root of the workspace").ViaField("workspace").ViaField("scriptRef"))
+		}
+	}
 	return errs
 }
 
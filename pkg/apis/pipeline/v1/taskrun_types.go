@@ -81,6 +81,27 @@ type TaskRunSpec struct {
 	SidecarSpecs []TaskRunSidecarSpec `json:"sidecarSpecs,omitempty"`
 	// Compute resources to use for this TaskRun
 	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// PodEvictionRetries is the number of times the Pod of this TaskRun is
+	// recreated when it is evicted, preempted or lost along with its node,
+	// rather than failed by one of its steps. Defaults to the
+	// "default-pod-eviction-retries" value of the config-defaults ConfigMap.
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	PodEvictionRetries *int `json:"podEvictionRetries,omitempty"`
+	// Timeouts holds the timeouts of the different phases of the TaskRun execution.
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	Timeouts *TaskRunTimeouts `json:"timeouts,omitempty"`
+}
+
+// TaskRunTimeouts allows specifying timeouts for the different phases of a TaskRun execution
+type TaskRunTimeouts struct {
+	// Pending sets the maximum allowed duration for the Pod of the TaskRun to be
+	// scheduled, to pull its images and to start running. Defaults to the
+	// "default-pending-timeout-minutes" value of the config-defaults ConfigMap.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	Pending *metav1.Duration `json:"pending,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -150,6 +171,18 @@ const (
 	TaskRunReasonResolvingTaskRef = "ResolvingTaskRef"
 	// TaskRunReasonImagePullFailed is the reason set when the step of a task fails due to image not being pulled
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonUnschedulable is the reason set when the Pod of a TaskRun could not be
+	// scheduled before its pending timeout elapsed
+	TaskRunReasonUnschedulable TaskRunReason = "TaskRunUnschedulable"
+	// TaskRunReasonPendingTimedOut is the reason set when the Pod of a TaskRun did not start
+	// running before its pending timeout elapsed, for a reason other than scheduling or pulling images
+	TaskRunReasonPendingTimedOut TaskRunReason = "TaskRunPendingTimeout"
+	// TaskRunReasonPodEvicted is the reason recorded in the retries status when the Pod of a TaskRun
+	// was evicted, preempted or lost along with its node, and has been recreated
+	TaskRunReasonPodEvicted TaskRunReason = "TaskRunPodEvicted"
+	// TaskRunReasonEvicted is the reason set when the Pod of a TaskRun was evicted, preempted or
+	// lost along with its node, and the TaskRun has no pod eviction retries left
+	TaskRunReasonEvicted TaskRunReason = "TaskRunEvicted"
 )
 
 func (t TaskRunReason) String() string {
@@ -234,6 +267,12 @@ type TaskRunStatusFields struct {
 
 	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
 	Provenance *Provenance `json:"provenance,omitempty"`
+
+	// EventDeliveries is the state of the delivery of the cloud events about the
+	// lifecycle of the TaskRun to each of their sinks.
+	// +optional
+	// +listType=atomic
+	EventDeliveries []EventDelivery `json:"eventDeliveries,omitempty"`
 }
 
 // TaskRunStepSpec is used to override the values of a Step in the corresponding Task.
@@ -299,6 +338,43 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	Container             string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+
+	// Timing breaks down the time spent by the step before and while running its command.
+	// +optional
+	Timing *StepTiming `json:"timing,omitempty"`
+
+	// Logs is the URI the logs of the step are archived at, once the log archiver
+	// reported it archived them, when the logs of the steps are archived.
+	// +optional
+	Logs string `json:"logs,omitempty"`
+
+	// LogsError is the error which prevented archiving the logs of the step, when
+	// the logs of the steps are archived.
+	// +optional
+	LogsError string `json:"logsError,omitempty"`
+}
+
+// StepTiming reports how long a step spent starting its container, waiting for the
+// previous steps to complete, and running its command.
+type StepTiming struct {
+	// Startup is the time between the start of the previous container of the Pod, or the
+	// initialization of the Pod for its first container, and the start of the step container.
+	// It includes the time spent pulling the image of the step, reported in ImagePull, and
+	// the time spent creating the container.
+	// +optional
+	Startup *metav1.Duration `json:"startup,omitempty"`
+	// ImagePull is the time the kubelet spent pulling the image of the step, as reported by
+	// the Pulled event of its container, which is zero if the image was already present on
+	// the node. It is not reported if the events of the Pod expired before it was recorded.
+	// +optional
+	ImagePull *metav1.Duration `json:"imagePull,omitempty"`
+	// Waiting is the time between the start of the step container and the start of its
+	// command, spent waiting for the previous steps to complete.
+	// +optional
+	Waiting *metav1.Duration `json:"waiting,omitempty"`
+	// Execution is the time spent running the command of the step.
+	// +optional
+	Execution *metav1.Duration `json:"execution,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -407,6 +483,25 @@ func (tr *TaskRun) GetTimeout(ctx context.Context) time.Duration {
 	return tr.Spec.Timeout.Duration
 }
 
+// GetPendingTimeout returns the maximum duration the Pod of the TaskRun may stay pending,
+// or the default if not specified
+func (tr *TaskRun) GetPendingTimeout(ctx context.Context) time.Duration {
+	if tr.Spec.Timeouts == nil || tr.Spec.Timeouts.Pending == nil {
+		defaultPendingTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultPendingTimeoutMinutes)
+		return defaultPendingTimeout * time.Minute
+	}
+	return tr.Spec.Timeouts.Pending.Duration
+}
+
+// GetPodEvictionRetries returns the number of times the Pod of the TaskRun may be
+// recreated after being evicted, or the default if not specified
+func (tr *TaskRun) GetPodEvictionRetries(ctx context.Context) int {
+	if tr.Spec.PodEvictionRetries == nil {
+		return config.FromContextOrDefaults(ctx).Defaults.DefaultPodEvictionRetries
+	}
+	return *tr.Spec.PodEvictionRetries
+}
+
 // GetNamespacedName returns a k8s namespaced name that identifies this TaskRun
 func (tr *TaskRun) GetNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Namespace: tr.Namespace, Name: tr.Name}
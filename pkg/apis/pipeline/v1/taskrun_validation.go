@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/webhook/resourcesemantics"
@@ -105,9 +106,36 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		}
 	}
 
+	if ts.PodEvictionRetries != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "podEvictionRetries", config.AlphaAPIFields).ViaField("podEvictionRetries"))
+		if *ts.PodEvictionRetries < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ts.PodEvictionRetries), "podEvictionRetries"))
+		}
+	}
+
+	if ts.Timeouts != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "timeouts", config.AlphaAPIFields).ViaField("timeouts"))
+		errs = errs.Also(validateTaskRunTimeouts(ts.Timeouts, ts.Timeout).ViaField("timeouts"))
+	}
+
 	return errs
 }
 
+// validateTaskRunTimeouts validates that the pending timeout is a valid duration
+// which does not exceed the timeout of the whole TaskRun.
+func validateTaskRunTimeouts(timeouts *TaskRunTimeouts, timeout *metav1.Duration) (errs *apis.FieldError) {
+	if timeouts.Pending == nil {
+		return nil
+	}
+	if timeouts.Pending.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", timeouts.Pending.Duration.String()), "pending")
+	}
+	if timeout != nil && timeout.Duration != config.NoTimeoutDuration && timeouts.Pending.Duration > timeout.Duration {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be <= the TaskRun timeout %s", timeouts.Pending.Duration.String(), timeout.Duration.String()), "pending")
+	}
+	return nil
+}
+
 // validateInlineParameters validates that any parameters called in the
 // Task spec are declared in the TaskRun.
 // This is crucial for propagated parameters because the parameters could
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Defaultable = (*ApprovalTask)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (at *ApprovalTask) SetDefaults(ctx context.Context) {
+	var old *ApprovalTask
+	if apis.IsInUpdate(ctx) {
+		old, _ = apis.GetBaseline(ctx).(*ApprovalTask)
+	}
+	for i := range at.Spec.Approvers {
+		approver := &at.Spec.Approvers[i]
+		if approver.Type == "" {
+			approver.Type = ApproverTypeUser
+		}
+		if approver.Input == "" {
+			approver.Input = ApproverInputPending
+		}
+		// Record who gave the input of the approver, so that the identities of the
+		// members of a group can be reported.
+		if old != nil && i < len(old.Spec.Approvers) && old.Spec.Approvers[i].Input != approver.Input {
+			if ui := apis.GetUserInfo(ctx); ui != nil {
+				approver.DecidedBy = ui.Username
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApprovalTask is a manual approval gate, created for each Run of a PipelineTask
+// referencing the ApprovalTask kind. The Run completes once enough approvers have
+// approved it, and fails as soon as one of them rejects it.
+//
+// +k8s:openapi-gen=true
+type ApprovalTask struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ApprovalTaskSpec `json:"spec,omitempty"`
+	// +optional
+	Status ApprovalTaskStatus `json:"status,omitempty"`
+}
+
+// ApprovalTaskSpec defines the approvers of an ApprovalTask and their decisions
+type ApprovalTaskSpec struct {
+	// Approvers is the list of users and groups allowed to approve or reject the ApprovalTask.
+	// Each approver records its decision by patching its input.
+	// +listType=atomic
+	Approvers []ApproverDetails `json:"approvers"`
+
+	// NumberOfApprovalsRequired is the number of approvers who need to approve the ApprovalTask.
+	NumberOfApprovalsRequired int `json:"numberOfApprovalsRequired"`
+
+	// Description is shown to the approvers.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// ApproverDetails holds an approver of an ApprovalTask and its decision
+type ApproverDetails struct {
+	// Name is the name of the user or group.
+	Name string `json:"name"`
+
+	// Type is either "User" or "Group". Any member of a group can give the input of the group.
+	// Defaults to "User".
+	// +optional
+	Type ApproverType `json:"type,omitempty"`
+
+	// Input is the decision of the approver: "pending", "approve" or "reject".
+	// Defaults to "pending".
+	// +optional
+	Input ApproverInput `json:"input,omitempty"`
+
+	// Message is an optional message given by the approver along with its input.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// DecidedBy is the name of the user who gave the input. It is recorded by the
+	// admission webhook when the input is changed.
+	// +optional
+	DecidedBy string `json:"decidedBy,omitempty"`
+}
+
+// ApproverType is the type of an approver of an ApprovalTask
+type ApproverType string
+
+const (
+	// ApproverTypeUser indicates that the approver is a user
+	ApproverTypeUser ApproverType = "User"
+	// ApproverTypeGroup indicates that the approver is a group
+	ApproverTypeGroup ApproverType = "Group"
+)
+
+// ApproverInput is the decision of an approver of an ApprovalTask
+type ApproverInput string
+
+const (
+	// ApproverInputPending indicates that the approver has not decided yet
+	ApproverInputPending ApproverInput = "pending"
+	// ApproverInputApprove indicates that the approver approved the ApprovalTask
+	ApproverInputApprove ApproverInput = "approve"
+	// ApproverInputReject indicates that the approver rejected the ApprovalTask
+	ApproverInputReject ApproverInput = "reject"
+)
+
+// ApprovalState is the overall state of an ApprovalTask
+type ApprovalState string
+
+const (
+	// ApprovalStatePending indicates that the ApprovalTask is waiting for approvals
+	ApprovalStatePending ApprovalState = "pending"
+	// ApprovalStateApproved indicates that enough approvers approved the ApprovalTask
+	ApprovalStateApproved ApprovalState = "approved"
+	// ApprovalStateRejected indicates that an approver rejected the ApprovalTask
+	ApprovalStateRejected ApprovalState = "rejected"
+)
+
+// ApprovalTaskStatus defines the observed state of an ApprovalTask
+type ApprovalTaskStatus struct {
+	// State is the overall state of the ApprovalTask: "pending", "approved" or "rejected".
+	// +optional
+	State ApprovalState `json:"state,omitempty"`
+
+	// ApprovedBy is the list of users who approved the ApprovalTask.
+	// +optional
+	// +listType=atomic
+	ApprovedBy []string `json:"approvedBy,omitempty"`
+
+	// RejectedBy is the user who rejected the ApprovalTask, if any.
+	// +optional
+	RejectedBy string `json:"rejectedBy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApprovalTaskList contains a list of ApprovalTask
+type ApprovalTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApprovalTask `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*ApprovalTask) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(pipeline.ApprovalTaskControllerName)
+}
+
+// Tally computes the state of the ApprovalTask from the inputs of its approvers.
+// A user who approved several entries, e.g. their own and the one of their group,
+// counts as a single approval.
+func (at *ApprovalTask) Tally() ApprovalTaskStatus {
+	status := ApprovalTaskStatus{State: ApprovalStatePending}
+	approvedBy := sets.NewString()
+	for _, approver := range at.Spec.Approvers {
+		switch approver.Input {
+		case ApproverInputReject:
+			status.State = ApprovalStateRejected
+			status.RejectedBy = approver.decider()
+			return status
+		case ApproverInputApprove:
+			if decider := approver.decider(); !approvedBy.Has(decider) {
+				approvedBy.Insert(decider)
+				status.ApprovedBy = append(status.ApprovedBy, decider)
+			}
+		}
+	}
+	if len(status.ApprovedBy) >= at.Spec.NumberOfApprovalsRequired {
+		status.State = ApprovalStateApproved
+	}
+	return status
+}
+
+// decider returns the name of the user who gave the input of the approver.
+func (ad ApproverDetails) decider() string {
+	if ad.DecidedBy != "" {
+		return ad.DecidedBy
+	}
+	return ad.Name
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestApprovalTask_Tally(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		approvers []v1alpha1.ApproverDetails
+		required  int
+		want      v1alpha1.ApprovalTaskStatus
+	}{{
+		name: "pending",
+		approvers: []v1alpha1.ApproverDetails{
+			{Name: "alice", Input: v1alpha1.ApproverInputApprove},
+			{Name: "bob", Input: v1alpha1.ApproverInputPending},
+		},
+		required: 2,
+		want:     v1alpha1.ApprovalTaskStatus{State: v1alpha1.ApprovalStatePending, ApprovedBy: []string{"alice"}},
+	}, {
+		name: "approved",
+		approvers: []v1alpha1.ApproverDetails{
+			{Name: "alice", Input: v1alpha1.ApproverInputApprove},
+			{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputApprove, DecidedBy: "carol"},
+		},
+		required: 2,
+		want:     v1alpha1.ApprovalTaskStatus{State: v1alpha1.ApprovalStateApproved, ApprovedBy: []string{"alice", "carol"}},
+	}, {
+		name: "same user approving for themselves and their group",
+		approvers: []v1alpha1.ApproverDetails{
+			{Name: "alice", Input: v1alpha1.ApproverInputApprove},
+			{Name: "admins", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputApprove, DecidedBy: "alice"},
+		},
+		required: 2,
+		want:     v1alpha1.ApprovalTaskStatus{State: v1alpha1.ApprovalStatePending, ApprovedBy: []string{"alice"}},
+	}, {
+		name: "rejected",
+		approvers: []v1alpha1.ApproverDetails{
+			{Name: "alice", Input: v1alpha1.ApproverInputApprove},
+			{Name: "bob", Input: v1alpha1.ApproverInputReject},
+		},
+		required: 1,
+		want:     v1alpha1.ApprovalTaskStatus{State: v1alpha1.ApprovalStateRejected, ApprovedBy: []string{"alice"}, RejectedBy: "bob"},
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			at := approvalTask(c.approvers...)
+			at.Spec.NumberOfApprovalsRequired = c.required
+			if d := cmp.Diff(c.want, at.Tally(), cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Unexpected status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+var _ apis.Validatable = (*ApprovalTask)(nil)
+var _ resourcesemantics.VerbLimited = (*ApprovalTask)(nil)
+
+// SupportedVerbs returns the operations that validation should be called for
+func (at *ApprovalTask) SupportedVerbs() []admissionregistrationv1.OperationType {
+	return []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+}
+
+// Validate implements apis.Validatable
+func (at *ApprovalTask) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(at.GetObjectMeta()).ViaField("metadata"); err != nil {
+		return err
+	}
+	errs := at.Spec.Validate(ctx).ViaField("spec")
+	if apis.IsInCreate(ctx) {
+		errs = errs.Also(at.validateCreate().ViaField("spec"))
+	}
+	if apis.IsInUpdate(ctx) {
+		if old, ok := apis.GetBaseline(ctx).(*ApprovalTask); ok {
+			errs = errs.Also(at.validateUpdate(ctx, old).ViaField("spec"))
+		}
+	}
+	return errs
+}
+
+// Validate ApprovalTask spec
+func (as *ApprovalTaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(as.Approvers) == 0 {
+		return apis.ErrMissingField("approvers")
+	}
+	if as.NumberOfApprovalsRequired < 1 || as.NumberOfApprovalsRequired > len(as.Approvers) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(as.NumberOfApprovalsRequired, 1, len(as.Approvers), "numberOfApprovalsRequired"))
+	}
+	seen := sets.NewString()
+	for i, approver := range as.Approvers {
+		if approver.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("approvers", i))
+		}
+		key := fmt.Sprintf("%s/%s", approver.Type, approver.Name)
+		if seen.Has(key) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("approver %q is listed more than once", approver.Name), "name").ViaFieldIndex("approvers", i))
+		}
+		seen.Insert(key)
+		switch approver.Type {
+		case "", ApproverTypeUser, ApproverTypeGroup:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(approver.Type, "type", fmt.Sprintf("approver type must be either %q or %q", ApproverTypeUser, ApproverTypeGroup)).ViaFieldIndex("approvers", i))
+		}
+		switch approver.Input {
+		case "", ApproverInputPending, ApproverInputApprove, ApproverInputReject:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(approver.Input, "input", fmt.Sprintf("approver input must be one of %q, %q or %q", ApproverInputPending, ApproverInputApprove, ApproverInputReject)).ViaFieldIndex("approvers", i))
+		}
+	}
+	return errs
+}
+
+// validateCreate checks that no approver has given its input yet, so that an ApprovalTask
+// can't be created already approved or rejected: the inputs are only given by updates,
+// which record who gave them.
+func (at *ApprovalTask) validateCreate() (errs *apis.FieldError) {
+	for i, approver := range at.Spec.Approvers {
+		if approver.Input != "" && approver.Input != ApproverInputPending {
+			errs = errs.Also(apis.ErrInvalidValue(approver.Input, "input", fmt.Sprintf("approver input must be %q when the ApprovalTask is created", ApproverInputPending)).ViaFieldIndex("approvers", i))
+		}
+		if approver.DecidedBy != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("decidedBy").ViaFieldIndex("approvers", i))
+		}
+	}
+	return errs
+}
+
+// validateUpdate checks that only the approvers themselves change their input, and
+// that the approvers and the number of approvals required are left unchanged.
+func (at *ApprovalTask) validateUpdate(ctx context.Context, old *ApprovalTask) (errs *apis.FieldError) {
+	if at.Spec.NumberOfApprovalsRequired != old.Spec.NumberOfApprovalsRequired {
+		errs = errs.Also(apis.ErrGeneric("numberOfApprovalsRequired cannot be changed", "numberOfApprovalsRequired"))
+	}
+	if len(at.Spec.Approvers) != len(old.Spec.Approvers) {
+		return errs.Also(apis.ErrGeneric("approvers cannot be added or removed", "approvers"))
+	}
+	ui := apis.GetUserInfo(ctx)
+	for i, approver := range at.Spec.Approvers {
+		before := old.Spec.Approvers[i]
+		if approver.Name != before.Name || approver.Type != before.Type {
+			errs = errs.Also(apis.ErrGeneric("approvers cannot be changed", "name").ViaFieldIndex("approvers", i))
+			continue
+		}
+		if approver == before {
+			continue
+		}
+		if old.Status.State == ApprovalStateApproved || old.Status.State == ApprovalStateRejected {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("the ApprovalTask is already %s", old.Status.State), "input").ViaFieldIndex("approvers", i))
+			continue
+		}
+		if ui == nil || !approver.isApprover(ui.Username, ui.Groups) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("only %s %q can change this input", approver.Type, approver.Name), "input").ViaFieldIndex("approvers", i))
+			continue
+		}
+		if approver.DecidedBy != before.DecidedBy && approver.DecidedBy != ui.Username {
+			errs = errs.Also(apis.ErrInvalidValue(approver.DecidedBy, "decidedBy", "decidedBy must be the user changing the input").ViaFieldIndex("approvers", i))
+		}
+		if approver.Input == ApproverInputApprove && at.decidedElsewhere(i, ui.Username) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("user %q already decided for another approver", ui.Username), "input").ViaFieldIndex("approvers", i))
+		}
+	}
+	return errs
+}
+
+// decidedElsewhere returns true if the user gave the input of another approver than the
+// one at index i, so that a single user can't approve for several approvers.
+func (at *ApprovalTask) decidedElsewhere(i int, username string) bool {
+	for j, other := range at.Spec.Approvers {
+		if j != i && (other.Input == ApproverInputApprove || other.Input == ApproverInputReject) && other.decider() == username {
+			return true
+		}
+	}
+	return false
+}
+
+// isApprover returns true if the user with the given name and groups can give the input of the approver.
+func (ad ApproverDetails) isApprover(username string, groups []string) bool {
+	if ad.Type == ApproverTypeGroup {
+		return sets.NewString(groups...).Has(ad.Name)
+	}
+	return username == ad.Name
+}
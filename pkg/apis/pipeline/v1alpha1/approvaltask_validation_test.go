@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func approvalTask(approvers ...v1alpha1.ApproverDetails) *v1alpha1.ApprovalTask {
+	return &v1alpha1.ApprovalTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "approve"},
+		Spec: v1alpha1.ApprovalTaskSpec{
+			Approvers:                 approvers,
+			NumberOfApprovalsRequired: 1,
+		},
+	}
+}
+
+func TestApprovalTask_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		at   *v1alpha1.ApprovalTask
+		want *apis.FieldError
+	}{{
+		name: "no approvers",
+		at:   approvalTask(),
+		want: apis.ErrMissingField("spec.approvers"),
+	}, {
+		name: "too many approvals required",
+		at: func() *v1alpha1.ApprovalTask {
+			at := approvalTask(v1alpha1.ApproverDetails{Name: "alice"})
+			at.Spec.NumberOfApprovalsRequired = 2
+			return at
+		}(),
+		want: apis.ErrOutOfBoundsValue(2, 1, 1, "spec.numberOfApprovalsRequired"),
+	}, {
+		name: "missing approver name",
+		at:   approvalTask(v1alpha1.ApproverDetails{Type: v1alpha1.ApproverTypeGroup}),
+		want: apis.ErrMissingField("spec.approvers[0].name"),
+	}, {
+		name: "duplicate approver",
+		at:   approvalTask(v1alpha1.ApproverDetails{Name: "alice"}, v1alpha1.ApproverDetails{Name: "alice"}),
+		want: apis.ErrGeneric(`approver "alice" is listed more than once`, "spec.approvers[1].name"),
+	}, {
+		name: "invalid approver type",
+		at:   approvalTask(v1alpha1.ApproverDetails{Name: "alice", Type: "Team"}),
+		want: apis.ErrInvalidValue("Team", "spec.approvers[0].type", `approver type must be either "User" or "Group"`),
+	}, {
+		name: "invalid approver input",
+		at:   approvalTask(v1alpha1.ApproverDetails{Name: "alice", Input: "yes"}),
+		want: apis.ErrInvalidValue("yes", "spec.approvers[0].input", `approver input must be one of "pending", "approve" or "reject"`),
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.at.Validate(context.Background())
+			if d := cmp.Diff(c.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApprovalTask_ValidateCreate(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		at   *v1alpha1.ApprovalTask
+		want *apis.FieldError
+	}{{
+		name: "pending inputs",
+		at: approvalTask(
+			v1alpha1.ApproverDetails{Name: "alice"},
+			v1alpha1.ApproverDetails{Name: "bob", Input: v1alpha1.ApproverInputPending},
+		),
+	}, {
+		name: "already approved",
+		at:   approvalTask(v1alpha1.ApproverDetails{Name: "alice", Input: v1alpha1.ApproverInputApprove}),
+		want: apis.ErrInvalidValue("approve", "spec.approvers[0].input", `approver input must be "pending" when the ApprovalTask is created`),
+	}, {
+		name: "already rejected",
+		at:   approvalTask(v1alpha1.ApproverDetails{Name: "alice", Input: v1alpha1.ApproverInputReject}),
+		want: apis.ErrInvalidValue("reject", "spec.approvers[0].input", `approver input must be "pending" when the ApprovalTask is created`),
+	}, {
+		name: "decided by",
+		at:   approvalTask(v1alpha1.ApproverDetails{Name: "alice", DecidedBy: "alice"}),
+		want: apis.ErrDisallowedFields("spec.approvers[0].decidedBy"),
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.at.Validate(apis.WithinCreate(context.Background()))
+			if c.want == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(c.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApprovalTask_ValidateUpdate(t *testing.T) {
+	old := approvalTask(
+		v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputPending},
+		v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputPending},
+	)
+	for _, c := range []struct {
+		name     string
+		userInfo *authenticationv1.UserInfo
+		update   func(*v1alpha1.ApprovalTask)
+		want     *apis.FieldError
+	}{{
+		name:     "user approves",
+		userInfo: &authenticationv1.UserInfo{Username: "alice"},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.Approvers[0].Input = v1alpha1.ApproverInputApprove
+		},
+	}, {
+		name:     "group member rejects",
+		userInfo: &authenticationv1.UserInfo{Username: "bob", Groups: []string{"release-managers"}},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.Approvers[1].Input = v1alpha1.ApproverInputReject
+			at.Spec.Approvers[1].Message = "not during the freeze"
+		},
+	}, {
+		name:     "another user approves",
+		userInfo: &authenticationv1.UserInfo{Username: "bob"},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.Approvers[0].Input = v1alpha1.ApproverInputApprove
+		},
+		want: apis.ErrGeneric(`only User "alice" can change this input`, "spec.approvers[0].input"),
+	}, {
+		name:     "non member approves for a group",
+		userInfo: &authenticationv1.UserInfo{Username: "alice", Groups: []string{"developers"}},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.Approvers[1].Input = v1alpha1.ApproverInputApprove
+		},
+		want: apis.ErrGeneric(`only Group "release-managers" can change this input`, "spec.approvers[1].input"),
+	}, {
+		name:     "group member approves for the group after approving for themselves",
+		userInfo: &authenticationv1.UserInfo{Username: "alice", Groups: []string{"release-managers"}},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.Approvers[0].Input = v1alpha1.ApproverInputApprove
+			at.Spec.Approvers[0].DecidedBy = "alice"
+			at.Spec.Approvers[1].Input = v1alpha1.ApproverInputApprove
+		},
+		want: apis.ErrGeneric(`user "alice" already decided for another approver`, "spec.approvers[0].input", "spec.approvers[1].input"),
+	}, {
+		name:     "approver replaced",
+		userInfo: &authenticationv1.UserInfo{Username: "alice"},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.Approvers[1].Name = "alice-friends"
+		},
+		want: apis.ErrGeneric("approvers cannot be changed", "spec.approvers[1].name"),
+	}, {
+		name:     "number of approvals required changed",
+		userInfo: &authenticationv1.UserInfo{Username: "alice"},
+		update: func(at *v1alpha1.ApprovalTask) {
+			at.Spec.NumberOfApprovalsRequired = 2
+		},
+		want: apis.ErrGeneric("numberOfApprovalsRequired cannot be changed", "spec.numberOfApprovalsRequired"),
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			at := old.DeepCopy()
+			c.update(at)
+			ctx := apis.WithUserInfo(apis.WithinUpdate(context.Background(), old), c.userInfo)
+			at.SetDefaults(ctx)
+			err := at.Validate(ctx)
+			if c.want == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(c.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApprovalTask_ValidateUpdateSecondApproval(t *testing.T) {
+	// alice already approved for herself, and can't approve again for her group
+	old := approvalTask(
+		v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputApprove, DecidedBy: "alice"},
+		v1alpha1.ApproverDetails{Name: "admins", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputPending},
+	)
+	old.Spec.NumberOfApprovalsRequired = 2
+	old.Status.State = v1alpha1.ApprovalStatePending
+	at := old.DeepCopy()
+	at.Spec.Approvers[1].Input = v1alpha1.ApproverInputApprove
+	ctx := apis.WithUserInfo(apis.WithinUpdate(context.Background(), old), &authenticationv1.UserInfo{Username: "alice", Groups: []string{"admins"}})
+	at.SetDefaults(ctx)
+
+	want := apis.ErrGeneric(`user "alice" already decided for another approver`, "spec.approvers[1].input")
+	if d := cmp.Diff(want.Error(), at.Validate(ctx).Error()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestApprovalTask_SetDefaults(t *testing.T) {
+	old := approvalTask(
+		v1alpha1.ApproverDetails{Name: "alice"},
+		v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup},
+	)
+	old.SetDefaults(context.Background())
+	want := approvalTask(
+		v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputPending},
+		v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputPending},
+	)
+	if d := cmp.Diff(want, old); d != "" {
+		t.Errorf("Unexpected defaults %s", diff.PrintWantGot(d))
+	}
+
+	at := old.DeepCopy()
+	at.Spec.Approvers[1].Input = v1alpha1.ApproverInputApprove
+	at.SetDefaults(apis.WithUserInfo(apis.WithinUpdate(context.Background(), old), &authenticationv1.UserInfo{Username: "bob"}))
+	want.Spec.Approvers[1].Input = v1alpha1.ApproverInputApprove
+	want.Spec.Approvers[1].DecidedBy = "bob"
+	if d := cmp.Diff(want, at); d != "" {
+		t.Errorf("Unexpected defaults on update %s", diff.PrintWantGot(d))
+	}
+}
@@ -31,6 +31,12 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 	sink.OnError = (v1.OnErrorType)(s.OnError)
 	sink.StdoutConfig = (*v1.StepOutputConfig)(s.StdoutConfig)
 	sink.StderrConfig = (*v1.StepOutputConfig)(s.StderrConfig)
+	sink.Hermetic = s.Hermetic
+	sink.Egress = s.Egress
+	if s.ScriptRef != nil {
+		sink.ScriptRef = &v1.ScriptRef{}
+		s.ScriptRef.convertTo(ctx, sink.ScriptRef)
+	}
 
 	// TODO(#4546): Handle deprecated fields
 	// Ports, LivenessProbe, ReadinessProbe, StartupProbe, Lifecycle, TerminationMessagePath
@@ -62,6 +68,27 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 	s.OnError = (OnErrorType)(source.OnError)
 	s.StdoutConfig = (*StepOutputConfig)(source.StdoutConfig)
 	s.StderrConfig = (*StepOutputConfig)(source.StderrConfig)
+	s.Hermetic = source.Hermetic
+	s.Egress = source.Egress
+	if source.ScriptRef != nil {
+		newScriptRef := ScriptRef{}
+		newScriptRef.convertFrom(ctx, *source.ScriptRef)
+		s.ScriptRef = &newScriptRef
+	}
+}
+
+func (s ScriptRef) convertTo(ctx context.Context, sink *v1.ScriptRef) {
+	sink.ConfigMap = s.ConfigMap
+	if s.Workspace != nil {
+		sink.Workspace = &v1.WorkspaceScriptSource{Name: s.Workspace.Name, Path: s.Workspace.Path}
+	}
+}
+
+func (s *ScriptRef) convertFrom(ctx context.Context, source v1.ScriptRef) {
+	s.ConfigMap = source.ConfigMap
+	if source.Workspace != nil {
+		s.Workspace = &WorkspaceScriptSource{Name: source.Workspace.Name, Path: source.Workspace.Path}
+	}
 }
 
 func (s StepTemplate) convertTo(ctx context.Context, sink *v1.StepTemplate) {
@@ -196,6 +196,50 @@ type Step struct {
 	// Stores configuration for the stderr stream of the step.
 	// +optional
 	StderrConfig *StepOutputConfig `json:"stderrConfig,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Hermetic runs the Step without network access, so that it cannot fetch
+	// anything which is not provided as an input of the Task.
+	// +optional
+	Hermetic bool `json:"hermetic,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Egress lists the destinations which the Step can still connect to when it runs
+	// hermetically, in addition to the ones listed by the Task.
+	// +optional
+	// +listType=atomic
+	Egress []string `json:"egress,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// ScriptRef references a script stored outside of the Task, which is run
+	// like an inline Script. The Step cannot have a Script or a Command if ScriptRef is set.
+	// +optional
+	ScriptRef *ScriptRef `json:"scriptRef,omitempty"`
+}
+
+// ScriptRef references the contents of an executable file to execute. Exactly
+// one of its fields must be set.
+type ScriptRef struct {
+	// ConfigMap selects a key of a ConfigMap, in the namespace of the TaskRun, holding the script.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	// Workspace selects a file, in a Workspace declared by the Task, holding the script.
+	// +optional
+	Workspace *WorkspaceScriptSource `json:"workspace,omitempty"`
+}
+
+// WorkspaceScriptSource selects a file holding a script in a Workspace.
+type WorkspaceScriptSource struct {
+	// Name is the name of the Workspace declared by the Task.
+	Name string `json:"name"`
+	// Path is the path of the script relative to the root of the Workspace.
+	Path string `json:"path"`
 }
 
 // OnErrorType defines a list of supported exiting behavior of a container on error
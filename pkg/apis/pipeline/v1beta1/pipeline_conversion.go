@@ -184,6 +184,19 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask) err
 	}
 
 	sink.Timeout = pt.Timeout
+	sink.OnError = v1.PipelineTaskOnErrorType(pt.OnError)
+	if pt.RetryPolicy != nil {
+		sink.RetryPolicy = &v1.RetryPolicy{}
+		pt.RetryPolicy.convertTo(ctx, sink.RetryPolicy)
+	}
+	if pt.Cache != nil {
+		sink.Cache = &v1.PipelineTaskCache{}
+		pt.Cache.convertTo(ctx, sink.Cache)
+	}
+	if pt.Loop != nil {
+		sink.Loop = &v1.PipelineTaskLoop{}
+		pt.Loop.convertTo(ctx, sink.Loop)
+	}
 	return nil
 }
 
@@ -230,6 +243,22 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask)
 	}
 
 	pt.Timeout = source.Timeout
+	pt.OnError = PipelineTaskOnErrorType(source.OnError)
+	if source.RetryPolicy != nil {
+		newRetryPolicy := RetryPolicy{}
+		newRetryPolicy.convertFrom(ctx, *source.RetryPolicy)
+		pt.RetryPolicy = &newRetryPolicy
+	}
+	if source.Cache != nil {
+		newCache := PipelineTaskCache{}
+		newCache.convertFrom(ctx, *source.Cache)
+		pt.Cache = &newCache
+	}
+	if source.Loop != nil {
+		newLoop := PipelineTaskLoop{}
+		newLoop.convertFrom(ctx, *source.Loop)
+		pt.Loop = &newLoop
+	}
 	return nil
 }
 
@@ -295,6 +324,54 @@ func (pr *PipelineResult) convertFrom(ctx context.Context, source v1.PipelineRes
 	pr.Value = newValue
 }
 
+func (rp RetryPolicy) convertTo(ctx context.Context, sink *v1.RetryPolicy) {
+	sink.Backoff = rp.Backoff
+	sink.MaxBackoff = rp.MaxBackoff
+	sink.RetryOn = rp.RetryOn
+	sink.NoRetryOn = rp.NoRetryOn
+}
+
+func (rp *RetryPolicy) convertFrom(ctx context.Context, source v1.RetryPolicy) {
+	rp.Backoff = source.Backoff
+	rp.MaxBackoff = source.MaxBackoff
+	rp.RetryOn = source.RetryOn
+	rp.NoRetryOn = source.NoRetryOn
+}
+
+func (c PipelineTaskCache) convertTo(ctx context.Context, sink *v1.PipelineTaskCache) {
+	sink.Inputs = c.Inputs
+	sink.Workspaces = c.Workspaces
+	sink.MaxAge = c.MaxAge
+}
+
+func (c *PipelineTaskCache) convertFrom(ctx context.Context, source v1.PipelineTaskCache) {
+	c.Inputs = source.Inputs
+	c.Workspaces = source.Workspaces
+	c.MaxAge = source.MaxAge
+}
+
+func (l PipelineTaskLoop) convertTo(ctx context.Context, sink *v1.PipelineTaskLoop) {
+	sink.Until = nil
+	for _, we := range l.Until {
+		new := v1.WhenExpression{}
+		we.convertTo(ctx, &new)
+		sink.Until = append(sink.Until, new)
+	}
+	sink.MaxIterations = l.MaxIterations
+	sink.Delay = l.Delay
+}
+
+func (l *PipelineTaskLoop) convertFrom(ctx context.Context, source v1.PipelineTaskLoop) {
+	l.Until = nil
+	for _, we := range source.Until {
+		new := WhenExpression{}
+		new.convertFrom(ctx, we)
+		l.Until = append(l.Until, new)
+	}
+	l.MaxIterations = source.MaxIterations
+	l.Delay = source.Delay
+}
+
 func (ptm PipelineTaskMetadata) convertTo(ctx context.Context, sink *v1.PipelineTaskMetadata) {
 	sink.Labels = ptm.Labels
 	sink.Annotations = ptm.Annotations
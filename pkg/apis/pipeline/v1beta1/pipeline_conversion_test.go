@@ -120,6 +120,26 @@ func TestPipelineConversion(t *testing.T) {
 						Workspace: "source",
 					}},
 					Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					OnError: v1beta1.PipelineTaskContinue,
+					RetryPolicy: &v1beta1.RetryPolicy{
+						Backoff:    &metav1.Duration{Duration: time.Second},
+						MaxBackoff: &metav1.Duration{Duration: time.Minute},
+						RetryOn:    []string{"TaskRunTimeout"},
+					},
+					Cache: &v1beta1.PipelineTaskCache{
+						Inputs:     []string{"$(params.foo-is-baz)"},
+						Workspaces: []string{"my-task-workspace"},
+						MaxAge:     &metav1.Duration{Duration: time.Hour},
+					},
+					Loop: &v1beta1.PipelineTaskLoop{
+						Until: v1beta1.WhenExpressions{{
+							Input:    "$(tasks.foo.results.status)",
+							Operator: selection.In,
+							Values:   []string{"healthy"},
+						}},
+						MaxIterations: 10,
+						Delay:         &metav1.Duration{Duration: time.Minute},
+					},
 				},
 				},
 				Params: []v1beta1.ParamSpec{{
@@ -88,6 +88,11 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		ptrs.convertTo(ctx, &new)
 		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
 	}
+	if prs.Concurrency != nil {
+		sink.Concurrency = &v1.PipelineRunConcurrency{}
+		prs.Concurrency.convertTo(ctx, sink.Concurrency)
+	}
+	sink.ResumeFrom = prs.ResumeFrom
 	return nil
 }
 
@@ -146,6 +151,12 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, trs)
 		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
 	}
+	if source.Concurrency != nil {
+		newConcurrency := &PipelineRunConcurrency{}
+		newConcurrency.convertFrom(ctx, *source.Concurrency)
+		prs.Concurrency = newConcurrency
+	}
+	prs.ResumeFrom = source.ResumeFrom
 	return nil
 }
 
@@ -161,6 +172,14 @@ func (tf *TimeoutFields) convertFrom(ctx context.Context, source v1.TimeoutField
 	tf.Finally = source.Finally
 }
 
+func (c PipelineRunConcurrency) convertTo(ctx context.Context, sink *v1.PipelineRunConcurrency) {
+	sink.Priority = c.Priority
+}
+
+func (c *PipelineRunConcurrency) convertFrom(ctx context.Context, source v1.PipelineRunConcurrency) {
+	c.Priority = source.Priority
+}
+
 func (ptrs PipelineTaskRunSpec) convertTo(ctx context.Context, sink *v1.PipelineTaskRunSpec) {
 	sink.PipelineTaskName = ptrs.PipelineTaskName
 	sink.ServiceAccountName = ptrs.TaskServiceAccountName
@@ -142,6 +142,10 @@ func TestPipelineRunConversion(t *testing.T) {
 						},
 					},
 				},
+				Concurrency: &v1beta1.PipelineRunConcurrency{
+					Priority: 10,
+				},
+				ResumeFrom: "pr-1",
 			},
 		},
 	}}
@@ -113,7 +113,7 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
-	errs = errs.Also(validateSpecStatus(ps.Status))
+	errs = errs.Also(validateSpecStatus(ctx, ps.Status))
 
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
@@ -129,6 +129,18 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(validateTaskRunSpec(ctx, trs).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
 
+	if ps.ResumeFrom != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "resumeFrom", config.AlphaAPIFields).ViaField("resumeFrom"))
+		// Resuming co-owns the TaskRuns and Runs of the resumed PipelineRun, which a DryRun must not change
+		if ps.Status == PipelineRunSpecStatusDryRun {
+			errs = errs.Also(apis.ErrGeneric("resumeFrom cannot be used with the DryRun status", "resumeFrom", "status"))
+		}
+	}
+
+	if ps.Concurrency != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "concurrency", config.AlphaAPIFields).ViaField("concurrency"))
+	}
+
 	return errs
 }
 
@@ -253,12 +265,14 @@ func appendPipelineTaskParams(paramSpecForValidation map[string]ParamSpec, param
 	return paramSpecForValidation
 }
 
-func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
+func validateSpecStatus(ctx context.Context, status PipelineRunSpecStatus) *apis.FieldError {
 	switch status {
 	case "":
 		return nil
 	case PipelineRunSpecStatusPending:
 		return nil
+	case PipelineRunSpecStatusDryRun:
+		return version.ValidateEnabledAPIFields(ctx, "DryRun status", config.AlphaAPIFields).ViaField("status")
 	case PipelineRunSpecStatusCancelled,
 		PipelineRunSpecStatusCancelledRunFinally,
 		PipelineRunSpecStatusStoppedRunFinally:
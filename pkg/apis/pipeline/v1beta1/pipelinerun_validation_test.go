@@ -1145,6 +1145,36 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "concurrency disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Concurrency: &v1beta1.PipelineRunConcurrency{Priority: 1},
+		},
+		wantErr: apis.ErrGeneric("concurrency requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "resumeFrom disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			ResumeFrom:  "foo-run-1",
+		},
+		wantErr: apis.ErrGeneric("resumeFrom requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "DryRun status disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Status:      v1beta1.PipelineRunSpecStatusDryRun,
+		},
+		wantErr: apis.ErrGeneric("DryRun status requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "resumeFrom with the DryRun status",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			ResumeFrom:  "foo-run-1",
+			Status:      v1beta1.PipelineRunSpecStatusDryRun,
+		},
+		wantErr:     apis.ErrGeneric("resumeFrom cannot be used with the DryRun status", "resumeFrom", "status"),
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -1216,6 +1246,27 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid concurrency priority",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "deploy"},
+			Concurrency: &v1beta1.PipelineRunConcurrency{Priority: 5},
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid resumeFrom",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "build"},
+			ResumeFrom:  "build-run-1",
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid DryRun status",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "build"},
+			Status:      v1beta1.PipelineRunSpecStatusDryRun,
+		},
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -13,15 +13,46 @@ limitations under the License.
 
 package v1beta1
 
+import "k8s.io/apimachinery/pkg/types"
+
 // Provenance contains some key authenticated metadata about how a software artifact was
 // built (what sources, what inputs/outputs, etc.). For now, it only contains the subfield
-// `ConfigSource` that identifies the source where a build config file came from.
+// `ConfigSource` that identifies the source where a build config file came from, and the
+// subfield `HermeticSteps` that identifies the steps which ran without network access, and
+// the subfield `ResumedFrom` that identifies the PipelineRun a PipelineRun was resumed from.
 // In future, it can be expanded as needed to include more metadata about the build.
 // This field aims to be used to carry minimum amount of the authenticated metadata in *Run status
 // so that Tekton Chains can pick it up and record in the provenance it generates.
 type Provenance struct {
 	// ConfigSource identifies the source where a resource came from.
 	ConfigSource *ConfigSource `json:"configSource,omitempty"`
+
+	// HermeticSteps lists the names of the steps of a TaskRun which ran without network access.
+	// +listType=atomic
+	HermeticSteps []string `json:"hermeticSteps,omitempty"`
+
+	// ResumedFrom identifies the PipelineRun a PipelineRun was resumed from.
+	ResumedFrom *ResumedFrom `json:"resumedFrom,omitempty"`
+}
+
+// ResumedFrom identifies the PipelineRun a PipelineRun was resumed from, and the
+// PipelineTasks whose outcome was reused rather than run again.
+type ResumedFrom struct {
+	// Name is the name of the original PipelineRun.
+	Name string `json:"name"`
+
+	// UID is the UID of the original PipelineRun.
+	UID types.UID `json:"uid,omitempty"`
+
+	// ReusedPipelineTasks lists the PipelineTasks whose successful TaskRuns and Runs
+	// were reused from the original PipelineRun.
+	// +listType=atomic
+	ReusedPipelineTasks []string `json:"reusedPipelineTasks,omitempty"`
+
+	// SkippedTasks lists the PipelineTasks which the original PipelineRun skipped because
+	// of their when expressions, and which are skipped again without being re-evaluated.
+	// +listType=atomic
+	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
 }
 
 // ConfigSource identifies the source where a resource came from.
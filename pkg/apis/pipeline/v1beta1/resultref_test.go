@@ -50,6 +50,7 @@ func TestNewResultReference(t *testing.T) {
 		want: []*v1beta1.ResultRef{{
 			PipelineTask: "sumTask",
 			Result:       "sumResult",
+			WholeArray:   true,
 		}},
 	}, {
 		name: "Test valid expression with single object result property",
@@ -644,6 +645,9 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 			}, {
 				Value: *v1beta1.NewStructuredValues("$(tasks.pt7.results.r7)", "$(tasks.pt8.results.r8)"),
 			}}},
+		Cache: &v1beta1.PipelineTaskCache{
+			Inputs: []string{"$(tasks.pt9.results.r9)"},
+		},
 	}
 	refs := v1beta1.PipelineTaskResultRefs(&pt)
 	expectedRefs := []*v1beta1.ResultRef{{
@@ -670,6 +674,9 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 	}, {
 		PipelineTask: "pt8",
 		Result:       "r8",
+	}, {
+		PipelineTask: "pt9",
+		Result:       "r9",
 	}}
 	if d := cmp.Diff(refs, expectedRefs, cmpopts.SortSlices(lessResultRef)); d != "" {
 		t.Errorf("%v", d)
@@ -86,6 +86,8 @@ func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec) error {
 		sink.Params = append(sink.Params, new)
 	}
 	sink.Description = ts.Description
+	sink.Hermetic = ts.Hermetic
+	sink.Egress = ts.Egress
 	return nil
 }
 
@@ -145,6 +147,8 @@ func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec) error
 		ts.Params = append(ts.Params, new)
 	}
 	ts.Description = source.Description
+	ts.Hermetic = source.Hermetic
+	ts.Egress = source.Egress
 	return nil
 }
 
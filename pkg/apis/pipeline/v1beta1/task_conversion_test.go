@@ -77,6 +77,8 @@ func TestTaskConversion(t *testing.T) {
 			},
 			Spec: v1beta1.TaskSpec{
 				Description: "test",
+				Hermetic:    true,
+				Egress:      []string{"proxy.golang.org:443"},
 				Steps: []v1beta1.Step{{
 					Name:            "step",
 					Image:           "foo",
@@ -96,6 +98,12 @@ func TestTaskConversion(t *testing.T) {
 					OnError:         v1beta1.Continue,
 					StdoutConfig:    &v1beta1.StepOutputConfig{Path: "/path"},
 					StderrConfig:    &v1beta1.StepOutputConfig{Path: "/another-path"},
+					Hermetic:        true,
+					Egress:          []string{"*.googleapis.com"},
+				}, {
+					Name:      "script-ref-step",
+					Image:     "foo",
+					ScriptRef: &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "workspace", Path: "build.sh"}},
 				}},
 				StepTemplate: &v1beta1.StepTemplate{
 					Image:           "foo",
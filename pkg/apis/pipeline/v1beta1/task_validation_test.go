@@ -1667,6 +1667,46 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name:            "hermetic step requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+			}},
+		},
+	}, {
+		name:            "hermetic task requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+			}},
+			Hermetic: true,
+		},
+	}, {
+		name:            "egress requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+				Egress:   []string{"proxy.golang.org"},
+			}},
+		},
+	}, {
+		name:            "script ref requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				ScriptRef: &v1beta1.ScriptRef{ConfigMap: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+					Key:                  "build.sh",
+				}},
+			}},
+		},
 	}}
 	versions := []string{"alpha", "stable"}
 	for _, tt := range tests {
@@ -1694,6 +1734,292 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 	}
 }
 
+func TestTaskSpecValidateHermetic(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          v1beta1.TaskSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "hermetic step with sidecar without ports",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:  "logger",
+				Image: "bar",
+			}},
+		},
+	}, {
+		name: "hermetic step with sidecar exposing ports",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:  "registry",
+				Image: "bar",
+				Ports: []corev1.ContainerPort{{ContainerPort: 5000}},
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: `sidecar "registry" exposes ports that hermetic steps cannot reach because they run without network access`,
+			Paths:   []string{"sidecars[0].ports"},
+		},
+	}, {
+		name: "hermetic task with sidecar exposing ports",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:  "registry",
+				Image: "bar",
+				Ports: []corev1.ContainerPort{{ContainerPort: 5000}},
+			}},
+			Hermetic: true,
+		},
+		expectedError: &apis.FieldError{
+			Message: `sidecar "registry" exposes ports that hermetic steps cannot reach because they run without network access`,
+			Paths:   []string{"sidecars[0].ports"},
+		},
+	}, {
+		name: "hermetic task and step with egress",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:  "foo",
+				Egress: []string{"storage.googleapis.com:443"},
+			}},
+			Hermetic: true,
+			Egress:   []string{"proxy.golang.org:443", "*.internal.example.com", "10.0.0.0/8"},
+		},
+	}, {
+		name: "task egress with a hermetic step",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+			}, {
+				Image: "bar",
+			}},
+			Egress: []string{"proxy.golang.org:443"},
+		},
+	}, {
+		name: "task egress without hermetic steps",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+			}},
+			Egress: []string{"proxy.golang.org:443"},
+		},
+		expectedError: &apis.FieldError{
+			Message: "egress can only be set for a Task with hermetic steps",
+			Paths:   []string{"egress"},
+		},
+	}, {
+		name: "step egress without hermetic",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+			}, {
+				Image:  "bar",
+				Egress: []string{"proxy.golang.org:443"},
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "egress can only be set for a hermetic step",
+			Paths:   []string{"steps[1].egress"},
+		},
+	}, {
+		name: "invalid egress entries",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:    "foo",
+				Hermetic: true,
+				Egress:   []string{"proxy.golang.org:0"},
+			}},
+			Hermetic: true,
+			Egress:   []string{"proxy.golang.org:https"},
+		},
+		expectedError: apis.ErrInvalidValue("proxy.golang.org:https", "egress[0]", `invalid port in egress entry "proxy.golang.org:https"`).Also(
+			apis.ErrInvalidValue("proxy.golang.org:0", "steps[0].egress[0]", `invalid port in egress entry "proxy.golang.org:0"`)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			tt.spec.SetDefaults(ctx)
+			err := tt.spec.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateScriptRef(t *testing.T) {
+	configMapRef := &v1beta1.ScriptRef{ConfigMap: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+		Key:                  "build.sh",
+	}}
+	tests := []struct {
+		name          string
+		spec          v1beta1.TaskSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "script from a configmap",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: configMapRef,
+			}},
+		},
+	}, {
+		name: "script from a workspace",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "ci/build.sh"}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+		},
+	}, {
+		name: "script from a workspace file whose name starts with dots",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "ci/..build.sh"}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+		},
+	}, {
+		name: "script ref with script",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				Script:    "echo hello",
+				ScriptRef: configMapRef,
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "scriptRef cannot be used with script or command",
+			Paths:   []string{"steps[0].scriptRef"},
+		},
+	}, {
+		name: "script ref without source",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: &v1beta1.ScriptRef{},
+			}},
+		},
+		expectedError: apis.ErrMissingOneOf("steps[0].scriptRef.configMap", "steps[0].scriptRef.workspace"),
+	}, {
+		name: "script ref with both sources",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				ScriptRef: &v1beta1.ScriptRef{
+					ConfigMap: configMapRef.ConfigMap,
+					Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "ci/build.sh"},
+				},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+		},
+		expectedError: apis.ErrMultipleOneOf("steps[0].scriptRef.configMap", "steps[0].scriptRef.workspace"),
+	}, {
+		name: "script ref to a configmap without key",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				ScriptRef: &v1beta1.ScriptRef{ConfigMap: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+				}},
+			}},
+		},
+		expectedError: apis.ErrMissingField("steps[0].scriptRef.configMap.key"),
+	}, {
+		name: "script ref to a path outside of the workspace",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "../build.sh"}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: ../build.sh",
+			Paths:   []string{"steps[0].scriptRef.workspace.path"},
+			Details: "path must be relative to the root of the workspace",
+		},
+	}, {
+		name: "script ref to the parent of the workspace",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "ci/../.."}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: ci/../..",
+			Paths:   []string{"steps[0].scriptRef.workspace.path"},
+			Details: "path must be relative to the root of the workspace",
+		},
+	}, {
+		name: "script ref to an undeclared workspace",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "ci/build.sh"}},
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: `undefined workspace "source"`,
+			Paths:   []string{"steps[0].scriptRef.workspace.name"},
+		},
+	}, {
+		name: "script ref with windows script",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image:     "foo",
+				ScriptRef: configMapRef,
+			}, {
+				Image:  "bar",
+				Script: "#!win powershell.exe -File\necho hello",
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "windows scripts cannot be used with scriptRef",
+			Paths:   []string{"steps[1].script"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			tt.spec.SetDefaults(ctx)
+			err := tt.spec.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestSubstitutedContext(t *testing.T) {
 	type fields struct {
 		Params              []v1beta1.ParamSpec
@@ -92,6 +92,10 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec) err
 		sink.SidecarSpecs = append(sink.SidecarSpecs, new)
 	}
 	sink.ComputeResources = trs.ComputeResources
+	sink.PodEvictionRetries = trs.PodEvictionRetries
+	if trs.Timeouts != nil {
+		sink.Timeouts = &v1.TaskRunTimeouts{Pending: trs.Timeouts.Pending}
+	}
 	return nil
 }
 
@@ -162,6 +166,10 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec)
 		trs.SidecarOverrides = append(trs.SidecarOverrides, new)
 	}
 	trs.ComputeResources = source.ComputeResources
+	trs.PodEvictionRetries = source.PodEvictionRetries
+	if source.Timeouts != nil {
+		trs.Timeouts = &TaskRunTimeouts{Pending: source.Timeouts.Pending}
+	}
 	return nil
 }
 
@@ -49,6 +49,7 @@ func TestTaskRunConversionBadType(t *testing.T) {
 }
 
 func TestTaskrunConversion(t *testing.T) {
+	podEvictionRetries := 2
 	tests := []struct {
 		name string
 		in   *v1beta1.TaskRun
@@ -168,6 +169,10 @@ func TestTaskrunConversion(t *testing.T) {
 						corev1.ResourceMemory: corev1resources.MustParse("1Gi"),
 					},
 				},
+				PodEvictionRetries: &podEvictionRetries,
+				Timeouts: &v1beta1.TaskRunTimeouts{
+					Pending: &metav1.Duration{Duration: 5 * time.Minute},
+				},
 			},
 		},
 	}}
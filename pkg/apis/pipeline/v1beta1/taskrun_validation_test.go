@@ -494,6 +494,8 @@ func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 }
 
 func TestTaskRunSpec_Invalidate(t *testing.T) {
+	podEvictionRetries := 1
+	negativePodEvictionRetries := -1
 	invalidStatusMessage := "status message without status"
 	tests := []struct {
 		name    string
@@ -747,6 +749,45 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "podEvictionRetries disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:            &v1beta1.TaskRef{Name: "foo"},
+			PodEvictionRetries: &podEvictionRetries,
+		},
+		wantErr: apis.ErrGeneric("podEvictionRetries requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "negative podEvictionRetries",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:            &v1beta1.TaskRef{Name: "foo"},
+			PodEvictionRetries: &negativePodEvictionRetries,
+		},
+		wantErr: apis.ErrInvalidValue("-1 should be >= 0", "podEvictionRetries"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "timeouts disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:  &v1beta1.TaskRef{Name: "foo"},
+			Timeouts: &v1beta1.TaskRunTimeouts{Pending: &metav1.Duration{Duration: time.Minute}},
+		},
+		wantErr: apis.ErrGeneric("timeouts requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "negative pending timeout",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:  &v1beta1.TaskRef{Name: "foo"},
+			Timeouts: &v1beta1.TaskRunTimeouts{Pending: &metav1.Duration{Duration: -time.Minute}},
+		},
+		wantErr: apis.ErrInvalidValue("-1m0s should be >= 0", "timeouts.pending"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "pending timeout exceeds timeout",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:  &v1beta1.TaskRef{Name: "foo"},
+			Timeout:  &metav1.Duration{Duration: time.Minute},
+			Timeouts: &v1beta1.TaskRunTimeouts{Pending: &metav1.Duration{Duration: time.Hour}},
+		},
+		wantErr: apis.ErrInvalidValue("1h0m0s should be <= the TaskRun timeout 1m0s", "timeouts.pending"),
+		wc:      config.EnableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -764,6 +805,7 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 }
 
 func TestTaskRunSpec_Validate(t *testing.T) {
+	podEvictionRetries := 1
 	tests := []struct {
 		name string
 		spec v1beta1.TaskRunSpec
@@ -845,6 +887,21 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid podEvictionRetries",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:            &v1beta1.TaskRef{Name: "task"},
+			PodEvictionRetries: &podEvictionRetries,
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid pending timeout",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:  &v1beta1.TaskRef{Name: "task"},
+			Timeout:  &metav1.Duration{Duration: time.Hour},
+			Timeouts: &v1beta1.TaskRunTimeouts{Pending: &metav1.Duration{Duration: 10 * time.Minute}},
+		},
+		wc: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -30,6 +30,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CachedTask) DeepCopyInto(out *CachedTask) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CachedTask.
+func (in *CachedTask) DeepCopy() *CachedTask {
+	if in == nil {
+		return nil
+	}
+	out := new(CachedTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChildStatusReference) DeepCopyInto(out *ChildStatusReference) {
 	*out = *in
@@ -319,6 +335,26 @@ func (in *EmbeddedTask) DeepCopy() *EmbeddedTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventDelivery) DeepCopyInto(out *EventDelivery) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventDelivery.
+func (in *EventDelivery) DeepCopy() *EventDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(EventDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternalTaskModifier) DeepCopyInto(out *InternalTaskModifier) {
 	*out = *in
@@ -648,6 +684,38 @@ func (in *PipelineRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConcurrency) DeepCopyInto(out *PipelineRunConcurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConcurrency.
+func (in *PipelineRunConcurrency) DeepCopy() *PipelineRunConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConcurrencyStatus) DeepCopyInto(out *PipelineRunConcurrencyStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConcurrencyStatus.
+func (in *PipelineRunConcurrencyStatus) DeepCopy() *PipelineRunConcurrencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConcurrencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
 	*out = *in
@@ -681,6 +749,36 @@ func (in *PipelineRunList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunPlan) DeepCopyInto(out *PipelineRunPlan) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]PlannedPipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = make([]PlannedPipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunPlan.
+func (in *PipelineRunPlan) DeepCopy() *PipelineRunPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunResult) DeepCopyInto(out *PipelineRunResult) {
 	*out = *in
@@ -782,6 +880,11 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(PipelineRunConcurrency)
+		**out = **in
+	}
 	return
 }
 
@@ -889,6 +992,28 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 		*out = new(Provenance)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(PipelineRunConcurrencyStatus)
+		**out = **in
+	}
+	if in.CachedTasks != nil {
+		in, out := &in.CachedTasks, &out.CachedTasks
+		*out = make([]CachedTask, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(PipelineRunPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventDeliveries != nil {
+		in, out := &in.EventDeliveries, &out.EventDeliveries
+		*out = make([]EventDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1004,6 +1129,21 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(PipelineTaskCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Loop != nil {
+		in, out := &in.Loop, &out.Loop
+		*out = new(PipelineTaskLoop)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RunAfter != nil {
 		in, out := &in.RunAfter, &out.RunAfter
 		*out = make([]string, len(*in))
@@ -1049,6 +1189,37 @@ func (in *PipelineTask) DeepCopy() *PipelineTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskCache) DeepCopyInto(out *PipelineTaskCache) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskCache.
+func (in *PipelineTaskCache) DeepCopy() *PipelineTaskCache {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
 	*out = *in
@@ -1092,6 +1263,34 @@ func (in PipelineTaskList) DeepCopy() PipelineTaskList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskLoop) DeepCopyInto(out *PipelineTaskLoop) {
+	*out = *in
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = make(WhenExpressions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskLoop.
+func (in *PipelineTaskLoop) DeepCopy() *PipelineTaskLoop {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskLoop)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskMetadata) DeepCopyInto(out *PipelineTaskMetadata) {
 	*out = *in
@@ -1259,6 +1458,69 @@ func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMatrixCombination) DeepCopyInto(out *PlannedMatrixCombination) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMatrixCombination.
+func (in *PlannedMatrixCombination) DeepCopy() *PlannedMatrixCombination {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMatrixCombination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedPipelineTask) DeepCopyInto(out *PlannedPipelineTask) {
+	*out = *in
+	if in.TaskRef != nil {
+		in, out := &in.TaskRef, &out.TaskRef
+		*out = new(TaskRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunAfter != nil {
+		in, out := &in.RunAfter, &out.RunAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatrixCombinations != nil {
+		in, out := &in.MatrixCombinations, &out.MatrixCombinations
+		*out = make([]PlannedMatrixCombination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedPipelineTask.
+func (in *PlannedPipelineTask) DeepCopy() *PlannedPipelineTask {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedPipelineTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PropertySpec) DeepCopyInto(out *PropertySpec) {
 	*out = *in
@@ -1283,6 +1545,16 @@ func (in *Provenance) DeepCopyInto(out *Provenance) {
 		*out = new(ConfigSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HermeticSteps != nil {
+		in, out := &in.HermeticSteps, &out.HermeticSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResumedFrom != nil {
+		in, out := &in.ResumedFrom, &out.ResumedFrom
+		*out = new(ResumedFrom)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1335,6 +1607,96 @@ func (in *ResultRef) DeepCopy() *ResultRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResumedFrom) DeepCopyInto(out *ResumedFrom) {
+	*out = *in
+	if in.ReusedPipelineTasks != nil {
+		in, out := &in.ReusedPipelineTasks, &out.ReusedPipelineTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedTasks != nil {
+		in, out := &in.SkippedTasks, &out.SkippedTasks
+		*out = make([]SkippedTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResumedFrom.
+func (in *ResumedFrom) DeepCopy() *ResumedFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ResumedFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NoRetryOn != nil {
+		in, out := &in.NoRetryOn, &out.NoRetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptRef) DeepCopyInto(out *ScriptRef) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workspace != nil {
+		in, out := &in.Workspace, &out.Workspace
+		*out = new(WorkspaceScriptSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptRef.
+func (in *ScriptRef) DeepCopy() *ScriptRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sidecar) DeepCopyInto(out *Sidecar) {
 	*out = *in
@@ -1553,6 +1915,16 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = new(StepOutputConfig)
 		**out = **in
 	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScriptRef != nil {
+		in, out := &in.ScriptRef, &out.ScriptRef
+		*out = new(ScriptRef)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1586,6 +1958,11 @@ func (in *StepOutputConfig) DeepCopy() *StepOutputConfig {
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
 	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	if in.Timing != nil {
+		in, out := &in.Timing, &out.Timing
+		*out = new(StepTiming)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1682,6 +2059,42 @@ func (in *StepTemplate) DeepCopy() *StepTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepTiming) DeepCopyInto(out *StepTiming) {
+	*out = *in
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ImagePull != nil {
+		in, out := &in.ImagePull, &out.ImagePull
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Waiting != nil {
+		in, out := &in.Waiting, &out.Waiting
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Execution != nil {
+		in, out := &in.Execution, &out.Execution
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepTiming.
+func (in *StepTiming) DeepCopy() *StepTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(StepTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Task) DeepCopyInto(out *Task) {
 	*out = *in
@@ -2112,6 +2525,16 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodEvictionRetries != nil {
+		in, out := &in.PodEvictionRetries, &out.PodEvictionRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(TaskRunTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2204,6 +2627,13 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		*out = new(Provenance)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EventDeliveries != nil {
+		in, out := &in.EventDeliveries, &out.EventDeliveries
+		*out = make([]EventDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2234,6 +2664,27 @@ func (in *TaskRunStepOverride) DeepCopy() *TaskRunStepOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunTimeouts) DeepCopyInto(out *TaskRunTimeouts) {
+	*out = *in
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunTimeouts.
+func (in *TaskRunTimeouts) DeepCopy() *TaskRunTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
@@ -2287,6 +2738,11 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2457,6 +2913,22 @@ func (in *WorkspacePipelineTaskBinding) DeepCopy() *WorkspacePipelineTaskBinding
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceScriptSource) DeepCopyInto(out *WorkspaceScriptSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceScriptSource.
+func (in *WorkspaceScriptSource) DeepCopy() *WorkspaceScriptSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceScriptSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceUsage) DeepCopyInto(out *WorkspaceUsage) {
 	*out = *in
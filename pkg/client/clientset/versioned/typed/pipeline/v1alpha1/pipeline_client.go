@@ -28,6 +28,7 @@ import (
 
 type TektonV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ApprovalTasksGetter
 	RunsGetter
 }
 
@@ -36,6 +37,10 @@ type TektonV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TektonV1alpha1Client) ApprovalTasks(namespace string) ApprovalTaskInterface {
+	return newApprovalTasks(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
 	return newRuns(c, namespace)
 }
@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ApprovalTasks returns a ApprovalTaskInformer.
+	ApprovalTasks() ApprovalTaskInformer
 	// Runs returns a RunInformer.
 	Runs() RunInformer
 }
@@ -39,6 +41,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ApprovalTasks returns a ApprovalTaskInformer.
+func (v *version) ApprovalTasks() ApprovalTaskInformer {
+	return &approvalTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Runs returns a RunInformer.
 func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// ApprovalTaskListerExpansion allows custom methods to be added to
+// ApprovalTaskLister.
+type ApprovalTaskListerExpansion interface{}
+
+// ApprovalTaskNamespaceListerExpansion allows custom methods to be added to
+// ApprovalTaskNamespaceLister.
+type ApprovalTaskNamespaceListerExpansion interface{}
+
 // RunListerExpansion allows custom methods to be added to
 // RunLister.
 type RunListerExpansion interface{}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package egress lets hermetic steps, which run without network access, connect to
+// the destinations of an allow-list through an HTTP proxy run by the entrypoint.
+package egress
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// rule is an entry of an allow-list, which matches either a host name or a network.
+type rule struct {
+	// host is a host name, or a suffix of host names starting with "." for wildcards.
+	host    string
+	network *net.IPNet
+	// port is the only port the rule allows, or "" for all ports.
+	port string
+}
+
+// AllowList is the list of destinations a hermetic step may connect to.
+type AllowList struct {
+	rules []rule
+}
+
+// ParseAllowList parses the entries of an allow-list. Each entry is a host name, a host
+// name starting with "*." to match its subdomains, an IP address or a CIDR, and host
+// names and IP addresses may end with a port, e.g. "proxy.golang.org:443".
+func ParseAllowList(entries []string) (*AllowList, error) {
+	a := &AllowList{}
+	for _, entry := range entries {
+		r, err := parseRule(entry)
+		if err != nil {
+			return nil, err
+		}
+		a.rules = append(a.rules, r)
+	}
+	return a, nil
+}
+
+// ValidateEntry returns an error if entry is not a valid entry of an allow-list.
+func ValidateEntry(entry string) error {
+	_, err := parseRule(entry)
+	return err
+}
+
+func parseRule(entry string) (rule, error) {
+	r := rule{}
+	host := entry
+	if h, port, err := net.SplitHostPort(entry); err == nil {
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return r, fmt.Errorf("invalid port in egress entry %q", entry)
+		}
+		host, r.port = h, port
+	}
+	if _, network, err := net.ParseCIDR(host); err == nil {
+		r.network = network
+		return r, nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		r.network = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+		return r, nil
+	}
+	name := strings.ToLower(host)
+	wildcard := strings.HasPrefix(name, "*.")
+	name = strings.TrimPrefix(name, "*.")
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return r, fmt.Errorf("egress entry %q is neither a host name, an IP address nor a CIDR: %s", entry, strings.Join(errs, ", "))
+	}
+	if wildcard {
+		name = "." + name
+	}
+	r.host = name
+	return r, nil
+}
+
+func (r rule) allowsPort(port string) bool {
+	return r.port == "" || r.port == port
+}
+
+// AllowsHost returns true if a rule allows the host name and port.
+func (a *AllowList) AllowsHost(host, port string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, r := range a.rules {
+		if r.host == "" || !r.allowsPort(port) {
+			continue
+		}
+		if host == r.host || (strings.HasPrefix(r.host, ".") && strings.HasSuffix(host, r.host)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIP returns true if a rule allows the IP address and port.
+func (a *AllowList) AllowsIP(ip net.IP, port string) bool {
+	for _, r := range a.rules {
+		if r.network != nil && r.allowsPort(port) && r.network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNetworks returns true if a rule matches a network rather than a host name.
+func (a *AllowList) hasNetworks() bool {
+	for _, r := range a.rules {
+		if r.network != nil {
+			return true
+		}
+	}
+	return false
+}
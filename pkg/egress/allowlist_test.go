@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egress_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/egress"
+)
+
+func TestValidateEntry(t *testing.T) {
+	for _, tc := range []struct {
+		entry   string
+		wantErr bool
+	}{
+		{entry: "proxy.golang.org"},
+		{entry: "proxy.golang.org:443"},
+		{entry: "*.googleapis.com"},
+		{entry: "10.0.0.1"},
+		{entry: "10.0.0.0/8"},
+		{entry: "10.0.0.0/8:443"},
+		{entry: "[fd00::1]:443"},
+		{entry: "fd00::/8"},
+		{entry: "", wantErr: true},
+		{entry: "*", wantErr: true},
+		{entry: "proxy.*.org", wantErr: true},
+		{entry: "https://proxy.golang.org", wantErr: true},
+		{entry: "proxy.golang.org:0", wantErr: true},
+		{entry: "proxy.golang.org:https", wantErr: true},
+		{entry: "10.0.0.0/33", wantErr: true},
+	} {
+		t.Run(tc.entry, func(t *testing.T) {
+			err := egress.ValidateEntry(tc.entry)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateEntry(%q) = %v, wantErr %t", tc.entry, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllowList(t *testing.T) {
+	a, err := egress.ParseAllowList([]string{"proxy.golang.org:443", "*.googleapis.com", "10.0.0.0/8", "192.168.1.1:80"})
+	if err != nil {
+		t.Fatalf("ParseAllowList() = %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		host string
+		port string
+		want bool
+	}{
+		{name: "host and port", host: "proxy.golang.org", port: "443", want: true},
+		{name: "host with another port", host: "proxy.golang.org", port: "80", want: false},
+		{name: "host in another case with a trailing dot", host: "Proxy.Golang.org.", port: "443", want: true},
+		{name: "subdomain of a host", host: "sum.proxy.golang.org", port: "443", want: false},
+		{name: "subdomain of a wildcard", host: "storage.googleapis.com", port: "443", want: true},
+		{name: "nested subdomain of a wildcard", host: "eu.storage.googleapis.com", port: "8443", want: true},
+		{name: "domain of a wildcard", host: "googleapis.com", port: "443", want: false},
+		{name: "suffix of a wildcard which is not a subdomain", host: "evilgoogleapis.com", port: "443", want: false},
+		{name: "other host", host: "example.com", port: "443", want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := a.AllowsHost(tc.host, tc.port); got != tc.want {
+				t.Errorf("AllowsHost(%q, %q) = %t, want %t", tc.host, tc.port, got, tc.want)
+			}
+		})
+	}
+	for _, tc := range []struct {
+		name string
+		ip   string
+		port string
+		want bool
+	}{
+		{name: "address in a network", ip: "10.1.2.3", port: "443", want: true},
+		{name: "address and port", ip: "192.168.1.1", port: "80", want: true},
+		{name: "address with another port", ip: "192.168.1.1", port: "443", want: false},
+		{name: "other address", ip: "192.168.1.2", port: "80", want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := a.AllowsIP(net.ParseIP(tc.ip), tc.port); got != tc.want {
+				t.Errorf("AllowsIP(%q, %q) = %t, want %t", tc.ip, tc.port, got, tc.want)
+			}
+		})
+	}
+}
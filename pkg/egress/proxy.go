@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"time"
+)
+
+// errDenied is returned when dialing a destination which is not in the allow-list.
+var errDenied = errors.New("egress is not allowed")
+
+// proxy is an HTTP proxy which only connects to the destinations of an allow-list.
+type proxy struct {
+	allowList *AllowList
+	dialer    *net.Dialer
+	forward   *httputil.ReverseProxy
+}
+
+// NewProxy returns an HTTP proxy which only connects to the destinations allowed by a.
+// It tunnels CONNECT requests, such as HTTPS requests, and forwards plain HTTP requests.
+func NewProxy(a *AllowList) http.Handler {
+	p := &proxy{
+		allowList: a,
+		dialer:    &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+	}
+	p.forward = &httputil.ReverseProxy{
+		// Proxy requests already have an absolute URL, which is forwarded as is
+		Director:     func(*http.Request) {},
+		Transport:    &http.Transport{DialContext: p.dialContext},
+		ErrorHandler: p.error,
+	}
+	return p
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "only proxy requests are supported", http.StatusBadRequest)
+		return
+	}
+	p.forward.ServeHTTP(w, r)
+}
+
+// tunnel connects the client of a CONNECT request to its destination.
+func (p *proxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	conn, err := p.dialContext(r.Context(), "tcp", r.Host)
+	if err != nil {
+		p.error(w, r, err)
+		return
+	}
+	defer conn.Close()
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "tunneling is not supported", http.StatusInternalServerError)
+		return
+	}
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		log.Printf("Error tunneling to %s: %v", r.Host, err)
+		return
+	}
+	defer client.Close()
+	if _, err := io.WriteString(client, "HTTP/1.1 200 Connection established\r\n\r\n"); err != nil {
+		return
+	}
+	// The client may have sent more than the CONNECT request, e.g. a TLS handshake
+	if n := buffered.Reader.Buffered(); n > 0 {
+		data, _ := buffered.Reader.Peek(n)
+		if _, err := conn.Write(data); err != nil {
+			return
+		}
+	}
+	pipe(client, conn)
+}
+
+func (p *proxy) error(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errDenied) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	log.Printf("Error connecting to %s: %v", r.Host, err)
+	http.Error(w, err.Error(), http.StatusBadGateway)
+}
+
+// dialContext connects to addr if the allow-list allows it. A host name which is not
+// allowed as such is resolved, and connected to through one of its allowed IP addresses.
+func (p *proxy) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if !p.allowList.AllowsIP(ip, port) {
+			return nil, fmt.Errorf("%w to %s", errDenied, addr)
+		}
+		return p.dialer.DialContext(ctx, network, addr)
+	}
+	if p.allowList.AllowsHost(host, port) {
+		return p.dialer.DialContext(ctx, network, addr)
+	}
+	if p.allowList.hasNetworks() {
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			if p.allowList.AllowsIP(ip, port) {
+				return p.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w to %s", errDenied, addr)
+}
+
+// Forward accepts the connections of l, and forwards each of them to the Unix socket
+// at socketPath, until l is closed.
+func Forward(l net.Listener, socketPath string) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			upstream, err := net.Dial("unix", socketPath)
+			if err != nil {
+				log.Printf("Error connecting to the egress proxy: %v", err)
+				return
+			}
+			defer upstream.Close()
+			pipe(conn, upstream)
+		}()
+	}
+}
+
+// halfCloser is a connection whose write side can be closed on its own.
+type halfCloser interface {
+	CloseWrite() error
+}
+
+// pipe copies data both ways between a and b until both of them are done writing.
+func pipe(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		if c, ok := dst.(halfCloser); ok {
+			_ = c.CloseWrite()
+		} else {
+			_ = dst.Close()
+		}
+		done <- struct{}{}
+	}
+	go cp(a, b)
+	go cp(b, a)
+	<-done
+	<-done
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egress_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/egress"
+)
+
+func TestProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello")
+	}))
+	defer backend.Close()
+	tlsBackend := httptest.NewTLSServer(backend.Config.Handler)
+	defer tlsBackend.Close()
+	_, backendPort, _ := net.SplitHostPort(backend.Listener.Addr().String())
+	_, tlsBackendPort, _ := net.SplitHostPort(tlsBackend.Listener.Addr().String())
+
+	for _, tc := range []struct {
+		name       string
+		allowList  []string
+		url        string
+		wantStatus int
+	}{{
+		name:       "http to an allowed address",
+		allowList:  []string{"127.0.0.1"},
+		url:        backend.URL,
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "http to an allowed host name",
+		allowList:  []string{"localhost:" + backendPort},
+		url:        "http://localhost:" + backendPort,
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "http to a host name resolving to an allowed network",
+		allowList:  []string{"127.0.0.0/8"},
+		url:        "http://localhost:" + backendPort,
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "http to an address on another port",
+		allowList:  []string{"127.0.0.1:" + tlsBackendPort},
+		url:        backend.URL,
+		wantStatus: http.StatusForbidden,
+	}, {
+		name:       "https to an allowed address",
+		allowList:  []string{"127.0.0.1:" + tlsBackendPort},
+		url:        tlsBackend.URL,
+		wantStatus: http.StatusOK,
+	}, {
+		name:      "https to an address which is not allowed",
+		allowList: []string{"10.0.0.0/8"},
+		url:       tlsBackend.URL,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := egress.ParseAllowList(tc.allowList)
+			if err != nil {
+				t.Fatalf("ParseAllowList() = %v", err)
+			}
+			proxy := httptest.NewServer(egress.NewProxy(a))
+			defer proxy.Close()
+			proxyURL, _ := url.Parse(proxy.URL)
+			transport := tlsBackend.Client().Transport.(*http.Transport).Clone()
+			transport.Proxy = http.ProxyURL(proxyURL)
+			client := &http.Client{Transport: transport}
+
+			resp, err := client.Get(tc.url)
+			if tc.wantStatus == 0 {
+				// A denied CONNECT request fails the request itself
+				if err == nil {
+					resp.Body.Close()
+					t.Fatalf("Expected the request to %s to fail, got status %d", tc.url, resp.StatusCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error getting %s: %v", tc.url, err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("Expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if tc.wantStatus == http.StatusOK {
+				if body, _ := io.ReadAll(resp.Body); string(body) != "hello" {
+					t.Errorf("Expected body %q, got %q", "hello", body)
+				}
+			}
+		})
+	}
+}
+
+func TestForward(t *testing.T) {
+	a, err := egress.ParseAllowList([]string{"127.0.0.1"})
+	if err != nil {
+		t.Fatalf("ParseAllowList() = %v", err)
+	}
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello")
+	}))
+	defer backend.Close()
+
+	socketPath := filepath.Join(t.TempDir(), "proxy.sock")
+	proxyListener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Error listening on %s: %v", socketPath, err)
+	}
+	proxy := &http.Server{Handler: egress.NewProxy(a)}
+	go proxy.Serve(proxyListener)              //nolint:errcheck
+	defer proxy.Shutdown(context.Background()) //nolint:errcheck
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error listening: %v", err)
+	}
+	defer l.Close()
+	go egress.Forward(l, socketPath) //nolint:errcheck
+
+	proxyURL, _ := url.Parse("http://" + l.Addr().String())
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	resp, err := client.Get(backend.URL)
+	if err != nil {
+		t.Fatalf("Error getting %s: %v", backend.URL, err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "hello" {
+		t.Errorf("Expected body %q, got %q", "hello", body)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricslabels
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.opencensus.io/tag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TagPrefix is the prefix of the tags holding the labels of runs
+	TagPrefix = "label_"
+	// OverflowValue replaces the values of the labels once the maximum number
+	// of combinations of values recorded for them is reached
+	OverflowValue = "other"
+
+	// missingValue marks the labels an object doesn't have in the combinations of values
+	missingValue = "\x01"
+)
+
+// Tagger copies the labels of PipelineRuns and TaskRuns onto the tags of their metrics,
+// as configured in the metrics config. The number of distinct combinations of the values
+// of the labels recorded is capped to protect the metrics backend from an unbounded
+// cardinality.
+type Tagger struct {
+	mutex        sync.Mutex
+	labels       []string
+	keys         []tag.Key
+	maxValues    int
+	combinations map[string]bool
+}
+
+// NewTagger returns a Tagger for the labels of the metrics config.
+func NewTagger(cfg *config.Metrics) *Tagger {
+	t := &Tagger{
+		maxValues:    cfg.LabelsMaxValues,
+		combinations: map[string]bool{},
+	}
+	if t.maxValues <= 0 {
+		t.maxValues = config.DefaultMetricsLabelsMaxValues
+	}
+	for _, label := range cfg.Labels {
+		t.labels = append(t.labels, label)
+		t.keys = append(t.keys, tag.MustNewKey(TagName(label)))
+	}
+	return t
+}
+
+// TagName returns the name of the tag holding a label, e.g. "label_tekton_dev_pipeline"
+// for the "tekton.dev/pipeline" label.
+func TagName(label string) string {
+	return TagPrefix + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, label)
+}
+
+// TagKeys returns the keys of the tags holding the labels, to be added to the views.
+func (t *Tagger) TagKeys() []tag.Key {
+	if t == nil {
+		return nil
+	}
+	return t.keys
+}
+
+// Mutators returns the mutators inserting the labels of an object in the tags of its
+// metrics, using the annotation with the same key if it's not a label of the object.
+// Labels the object doesn't have, and annotations which aren't valid tag values, are not
+// inserted. Once the maximum number of combinations of values is recorded, all the labels
+// of an object with a new combination are inserted with OverflowValue, so that the labels
+// split each series of a metric in at most that many series, plus the overflow one.
+func (t *Tagger) Mutators(o metav1.Object) []tag.Mutator {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// Tag values are printable ASCII characters, so marking the missing values, and separating
+	// the values of the labels, with control characters keeps the combinations unambiguous
+	values := make([]string, len(t.labels))
+	for i, label := range t.labels {
+		values[i] = missingValue
+		value, ok := o.GetLabels()[label]
+		if !ok {
+			value, ok = o.GetAnnotations()[label]
+		}
+		if !ok || !isValidTagValue(value) {
+			continue
+		}
+		values[i] = value
+	}
+	combination := strings.Join(values, "\x00")
+	overflow := false
+	if !t.combinations[combination] {
+		if len(t.combinations) >= t.maxValues {
+			overflow = true
+		} else {
+			t.combinations[combination] = true
+		}
+	}
+
+	var mutators []tag.Mutator
+	for i, value := range values {
+		switch {
+		case overflow:
+			mutators = append(mutators, tag.Insert(t.keys[i], OverflowValue))
+		case value != missingValue:
+			mutators = append(mutators, tag.Insert(t.keys[i], value))
+		}
+	}
+	return mutators
+}
+
+// isValidTagValue returns whether a value is a valid tag value, i.e. printable ASCII
+// characters of at most 255 characters.
+func isValidTagValue(value string) bool {
+	if len(value) > 255 {
+		return false
+	}
+	for _, r := range value {
+		if r < ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
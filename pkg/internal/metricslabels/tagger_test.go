@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricslabels_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/internal/metricslabels"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opencensus.io/tag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTagName(t *testing.T) {
+	for label, want := range map[string]string{
+		"team":                   "label_team",
+		"tekton.dev/pipeline":    "label_tekton_dev_pipeline",
+		"app.kubernetes.io/name": "label_app_kubernetes_io_name",
+	} {
+		if d := cmp.Diff(want, metricslabels.TagName(label)); d != "" {
+			t.Errorf("Wrong tag name for %q %s", label, diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestTaggerMutators(t *testing.T) {
+	tagger := metricslabels.NewTagger(&config.Metrics{
+		Labels:          []string{"team", "tekton.dev/pipeline", "example.com/owner"},
+		LabelsMaxValues: 2,
+	})
+	if d := cmp.Diff([]string{"label_team", "label_tekton_dev_pipeline", "label_example_com_owner"}, tagNames(tagger.TagKeys())); d != "" {
+		t.Errorf("Wrong tag keys %s", diff.PrintWantGot(d))
+	}
+
+	for _, tc := range []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		want        map[string]string
+	}{{
+		name:   "labels",
+		labels: map[string]string{"team": "a", "tekton.dev/pipeline": "build", "app": "web"},
+		want:   map[string]string{"label_team": "a", "label_tekton_dev_pipeline": "build"},
+	}, {
+		name:        "annotation",
+		labels:      map[string]string{"team": "b"},
+		annotations: map[string]string{"example.com/owner": "jdoe"},
+		want:        map[string]string{"label_team": "b", "label_example_com_owner": "jdoe"},
+	}, {
+		name:   "known combination after the cap is reached",
+		labels: map[string]string{"team": "a", "tekton.dev/pipeline": "build"},
+		want:   map[string]string{"label_team": "a", "label_tekton_dev_pipeline": "build"},
+	}, {
+		name:   "new value after the cap is reached",
+		labels: map[string]string{"team": "c"},
+		want: map[string]string{
+			"label_team":                metricslabels.OverflowValue,
+			"label_tekton_dev_pipeline": metricslabels.OverflowValue,
+			"label_example_com_owner":   metricslabels.OverflowValue,
+		},
+	}, {
+		name:   "new combination of known values after the cap is reached",
+		labels: map[string]string{"team": "b", "tekton.dev/pipeline": "build"},
+		want: map[string]string{
+			"label_team":                metricslabels.OverflowValue,
+			"label_tekton_dev_pipeline": metricslabels.OverflowValue,
+			"label_example_com_owner":   metricslabels.OverflowValue,
+		},
+	}, {
+		name:        "known combination with an invalid annotation value",
+		labels:      map[string]string{"team": "a", "tekton.dev/pipeline": "build"},
+		annotations: map[string]string{"example.com/owner": "multi\nline"},
+		want:        map[string]string{"label_team": "a", "label_tekton_dev_pipeline": "build"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			object := &metav1.ObjectMeta{Labels: tc.labels, Annotations: tc.annotations}
+			ctx, err := tag.New(context.Background(), tagger.Mutators(object)...)
+			if err != nil {
+				t.Fatalf("Unexpected error inserting the tags: %v", err)
+			}
+			got := map[string]string{}
+			for _, key := range tagger.TagKeys() {
+				if value, ok := tag.FromContext(ctx).Value(key); ok {
+					got[key.Name()] = value
+				}
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Wrong tags %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNilTagger(t *testing.T) {
+	var tagger *metricslabels.Tagger
+	if keys := tagger.TagKeys(); keys != nil {
+		t.Errorf("Expected no tag keys, got %v", keys)
+	}
+	if mutators := tagger.Mutators(&metav1.ObjectMeta{Labels: map[string]string{"team": "a"}}); mutators != nil {
+		t.Errorf("Expected no mutators, got %v", mutators)
+	}
+}
+
+func tagNames(keys []tag.Key) []string {
+	var names []string
+	for _, key := range keys {
+		names = append(names, key.Name())
+	}
+	return names
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logarchive archives the logs of the steps of a TaskRun, so they remain
+// available once the Pod of the TaskRun is deleted.
+package logarchive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/termination"
+)
+
+const (
+	// LogsDir is the directory the entrypoint copies the logs of the steps to, for
+	// them to be archived.
+	LogsDir = "/tekton/logs"
+	// PVCMountPath is the path the PersistentVolumeClaim logs are archived in is
+	// mounted at in the archiver container.
+	PVCMountPath = "/tekton/logs-archive"
+	// DefaultUploadTimeout is the time the upload of the logs of a step may take.
+	DefaultUploadTimeout = 5 * time.Minute
+	// maxErrorLength is the length errors are truncated to in the results of the
+	// archiver, which must fit in its termination message.
+	maxErrorLength = 256
+)
+
+// Backend stores the logs of steps.
+type Backend interface {
+	// Upload stores the size bytes read from content under the given key, and
+	// returns the URI of the stored logs.
+	Upload(ctx context.Context, key string, content io.Reader, size int64) (string, error)
+}
+
+// NewBackend returns the Backend storing logs at the destination URI, either
+// s3://<bucket>/<prefix> or pvc://<claim>/<prefix>.
+// The endpoint, region and credentials of S3-compatible object storages are read
+// from the standard AWS_ENDPOINT_URL, AWS_REGION, AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+func NewBackend(destination string) (Backend, error) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid logs archive %q: %w", destination, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("logs archive %q has no bucket or claim name", destination)
+	}
+	prefix := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case config.LogsArchiveSchemeS3:
+		return NewS3FromEnv(u.Host, prefix), nil
+	case config.LogsArchiveSchemePVC:
+		return &PVC{Claim: u.Host, Prefix: prefix, MountPath: PVCMountPath}, nil
+	default:
+		return nil, fmt.Errorf("unsupported logs archive %q", destination)
+	}
+}
+
+// Key returns the key the logs of the given step are stored under.
+func Key(step string) string {
+	return step + ".log"
+}
+
+// URI returns the URI the logs of the given step are archived at, for logs
+// archived at the destination URI.
+func URI(destination, step string) string {
+	return strings.TrimSuffix(destination, "/") + "/" + Key(step)
+}
+
+// LogPath returns the path the entrypoint copies the logs of the step with the given
+// index to.
+func LogPath(step int) string {
+	return filepath.Join(LogsDir, strconv.Itoa(step)+".log")
+}
+
+// Result reports whether the logs of a step were archived.
+type Result struct {
+	Step string `json:"step"`
+	// Error is the error which prevented archiving the logs of the step, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// WriteResults writes the results of the archiver to the termination message path,
+// for the controller to record the URIs of the logs which were archived.
+func WriteResults(path string, results []Result) error {
+	for i, r := range results {
+		if len(r.Error) > maxErrorLength {
+			results[i].Error = r.Error[:maxErrorLength-3] + "..."
+		}
+	}
+	b, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	if len(b) > termination.MaxContainerTerminationMessageLength {
+		return fmt.Errorf("the results of the logs archiver are above the max allowed size %d", termination.MaxContainerTerminationMessageLength)
+	}
+	return os.WriteFile(path, b, 0666)
+}
+
+// ParseResults parses the results of the archiver from its termination message.
+func ParseResults(message string) ([]Result, error) {
+	var results []Result
+	if err := json.Unmarshal([]byte(message), &results); err != nil {
+		return nil, fmt.Errorf("invalid results of the logs archiver %q: %w", message, err)
+	}
+	return results, nil
+}
+
+// Archiver uploads the logs of the steps of a Pod once each of them completes.
+type Archiver struct {
+	Backend Backend
+	// RunDir is the directory the entrypoint writes the post files of the steps to.
+	RunDir string
+	// LogsDir is the directory the entrypoint copies the logs of the steps to.
+	LogsDir string
+	// PollInterval is the interval at which the completion of a step is checked.
+	PollInterval time.Duration
+	// UploadTimeout is the time the upload of the logs of a step may take, which
+	// defaults to DefaultUploadTimeout.
+	UploadTimeout time.Duration
+}
+
+// Archive waits for the steps to complete, in order, and uploads the logs of each of
+// them as soon as it completes. The index of a step in steps is the index of its
+// run directory. The logs of the steps which didn't produce any, e.g. because they
+// were skipped, are archived empty so that all the steps have logs. A failure to
+// upload the logs of a step doesn't prevent archiving the logs of the next steps, and
+// the result of every step is returned, in order, once ctx is done.
+func (a *Archiver) Archive(ctx context.Context, steps []string) []Result {
+	results := make([]Result, 0, len(steps))
+	for i, step := range steps {
+		err := a.waitForStep(ctx, i)
+		if err == nil {
+			err = a.upload(ctx, i, step)
+		}
+		result := Result{Step: step}
+		if err != nil {
+			result.Error = fmt.Sprintf("error archiving the logs of step %q: %v", step, err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// waitForStep waits for the entrypoint of the step with the given index to write its
+// post file, which it does whether the step succeeded, failed or was skipped.
+func (a *Archiver) waitForStep(ctx context.Context, step int) error {
+	postFile := filepath.Join(a.RunDir, strconv.Itoa(step), "out")
+	for {
+		for _, path := range []string{postFile, postFile + ".err"} {
+			if _, err := os.Stat(path); err == nil {
+				return nil
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("error checking the completion of step %d: %w", step, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(a.PollInterval):
+		}
+	}
+}
+
+func (a *Archiver) upload(ctx context.Context, i int, step string) error {
+	timeout := a.UploadTimeout
+	if timeout == 0 {
+		timeout = DefaultUploadTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	f, err := os.Open(filepath.Join(a.LogsDir, strconv.Itoa(i)+".log"))
+	if errors.Is(err, os.ErrNotExist) {
+		_, err := a.Backend.Upload(ctx, Key(step), nil, 0)
+		return err
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	_, err = a.Backend.Upload(ctx, Key(step), f, info.Size())
+	return err
+}
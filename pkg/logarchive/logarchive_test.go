@@ -0,0 +1,287 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// objectStore is a stand-in for an S3-compatible object storage like MinIO, which
+// stores the objects PUT with a signed request.
+type objectStore struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func newObjectStore(t *testing.T) (*objectStore, *httptest.Server) {
+	t.Helper()
+	store := &objectStore{objects: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<Error><Code>AccessDenied</Code></Error>")
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		store.objects[r.URL.Path] = string(body)
+	}))
+	t.Cleanup(server.Close)
+	return store, server
+}
+
+func TestNewBackend(t *testing.T) {
+	for _, tc := range []struct {
+		destination string
+		want        logarchive.Backend
+	}{{
+		destination: "pvc://logs/ci/foo/pod",
+		want:        &logarchive.PVC{Claim: "logs", Prefix: "ci/foo/pod", MountPath: logarchive.PVCMountPath},
+	}, {
+		destination: "s3://tekton-logs/foo/pod",
+		want: &logarchive.S3{
+			Endpoint: "http://minio.minio.svc:9000",
+			Bucket:   "tekton-logs",
+			Prefix:   "foo/pod",
+			Region:   "eu-west-1",
+			Credentials: aws.Credentials{
+				AccessKeyID:     "minio",
+				SecretAccessKey: "minio123",
+			},
+			Client: http.DefaultClient,
+		},
+	}} {
+		t.Run(tc.destination, func(t *testing.T) {
+			t.Setenv("AWS_ENDPOINT_URL", "http://minio.minio.svc:9000")
+			t.Setenv("AWS_REGION", "eu-west-1")
+			t.Setenv("AWS_ACCESS_KEY_ID", "minio")
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "minio123")
+			got, err := logarchive.NewBackend(tc.destination)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got, cmp.Comparer(func(x, y *http.Client) bool { return x == y })); d != "" {
+				t.Errorf("Wrong backend %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewBackendError(t *testing.T) {
+	for _, destination := range []string{"gs://tekton-logs/foo", "s3:///foo", "pvc://%"} {
+		if _, err := logarchive.NewBackend(destination); err == nil {
+			t.Errorf("Expected an error for logs archive %q", destination)
+		}
+	}
+}
+
+func TestURI(t *testing.T) {
+	if d := cmp.Diff("s3://tekton-logs/foo/pod/build.log", logarchive.URI("s3://tekton-logs/foo/pod/", "build")); d != "" {
+		t.Errorf("Wrong URI %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestS3Upload(t *testing.T) {
+	store, server := newObjectStore(t)
+	backend := &logarchive.S3{
+		Endpoint:    server.URL,
+		Bucket:      "tekton-logs",
+		Prefix:      "foo/pod",
+		Region:      "us-east-1",
+		Credentials: aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"},
+	}
+
+	uri, err := backend.Upload(context.Background(), "build.log", strings.NewReader("hello world\n"), 12)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := cmp.Diff("s3://tekton-logs/foo/pod/build.log", uri); d != "" {
+		t.Errorf("Wrong URI %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(map[string]string{"/tekton-logs/foo/pod/build.log": "hello world\n"}, store.objects); d != "" {
+		t.Errorf("Wrong objects %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestS3UploadError(t *testing.T) {
+	_, server := newObjectStore(t)
+	backend := &logarchive.S3{
+		Endpoint:    server.URL,
+		Bucket:      "tekton-logs",
+		Region:      "us-east-1",
+		Credentials: aws.Credentials{AccessKeyID: "someone-else", SecretAccessKey: "secret"},
+	}
+
+	_, err := backend.Upload(context.Background(), "build.log", strings.NewReader("hello world\n"), 12)
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected an access denied error, got %v", err)
+	}
+}
+
+func TestPVCUpload(t *testing.T) {
+	mountPath := t.TempDir()
+	backend := &logarchive.PVC{Claim: "logs", Prefix: "foo/pod", MountPath: mountPath}
+
+	uri, err := backend.Upload(context.Background(), "build.log", strings.NewReader("hello world\n"), 12)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := cmp.Diff("pvc://logs/foo/pod/build.log", uri); d != "" {
+		t.Errorf("Wrong URI %s", diff.PrintWantGot(d))
+	}
+	got, err := os.ReadFile(filepath.Join(mountPath, "foo/pod/build.log"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := cmp.Diff("hello world\n", string(got)); d != "" {
+		t.Errorf("Wrong logs %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestArchive(t *testing.T) {
+	store, server := newObjectStore(t)
+	runDir, logsDir := t.TempDir(), t.TempDir()
+	archiver := &logarchive.Archiver{
+		Backend: &logarchive.S3{
+			Endpoint:    server.URL,
+			Bucket:      "tekton-logs",
+			Prefix:      "foo/pod",
+			Region:      "us-east-1",
+			Credentials: aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"},
+		},
+		RunDir:       runDir,
+		LogsDir:      logsDir,
+		PollInterval: time.Millisecond,
+	}
+
+	// The first step fails after writing its logs, and the second step is skipped
+	// without writing any, once the archiver started waiting for the steps.
+	done := make(chan []logarchive.Result)
+	go func() {
+		done <- archiver.Archive(context.Background(), []string{"build", "push"})
+	}()
+	writeFile(t, filepath.Join(logsDir, "0.log"), "compiling\nfailed\n")
+	writeFile(t, filepath.Join(runDir, "0", "out.err"), "")
+	writeFile(t, filepath.Join(runDir, "1", "out.err"), "")
+	if d := cmp.Diff([]logarchive.Result{{Step: "build"}, {Step: "push"}}, <-done); d != "" {
+		t.Errorf("Wrong results %s", diff.PrintWantGot(d))
+	}
+
+	want := map[string]string{
+		"/tekton-logs/foo/pod/build.log": "compiling\nfailed\n",
+		"/tekton-logs/foo/pod/push.log":  "",
+	}
+	if d := cmp.Diff(want, store.objects); d != "" {
+		t.Errorf("Wrong objects %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestArchiveCancelled(t *testing.T) {
+	archiver := &logarchive.Archiver{
+		Backend:      &logarchive.PVC{Claim: "logs", MountPath: t.TempDir()},
+		RunDir:       t.TempDir(),
+		LogsDir:      t.TempDir(),
+		PollInterval: time.Millisecond,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	results := archiver.Archive(ctx, []string{"build"})
+	if len(results) != 1 || !strings.Contains(results[0].Error, "context deadline exceeded") {
+		t.Errorf("Expected an error when the step never completes, got %v", results)
+	}
+}
+
+func TestArchiveUploadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	runDir := t.TempDir()
+	archiver := &logarchive.Archiver{
+		Backend:       &logarchive.S3{Endpoint: server.URL, Bucket: "tekton-logs", Region: "us-east-1"},
+		RunDir:        runDir,
+		LogsDir:       t.TempDir(),
+		PollInterval:  time.Millisecond,
+		UploadTimeout: 10 * time.Millisecond,
+	}
+	writeFile(t, filepath.Join(runDir, "0", "out"), "")
+
+	results := archiver.Archive(context.Background(), []string{"build"})
+	if len(results) != 1 || !strings.Contains(results[0].Error, "context deadline exceeded") {
+		t.Errorf("Expected the upload to time out, got %v", results)
+	}
+}
+
+func TestWriteAndParseResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	results := []logarchive.Result{{Step: "build"}, {Step: "push", Error: strings.Repeat("x", 300)}}
+	if err := logarchive.WriteResults(path, results); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	message, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := logarchive.ParseResults(string(message))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []logarchive.Result{{Step: "build"}, {Step: "push", Error: strings.Repeat("x", 253) + "..."}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Wrong results %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestParseResultsError(t *testing.T) {
+	if _, err := logarchive.ParseResults("Build successful"); err == nil {
+		t.Error("Expected an error parsing a message which isn't the results of the archiver")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
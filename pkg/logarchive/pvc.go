@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+// PVC stores logs in a PersistentVolumeClaim mounted in the archiver container.
+type PVC struct {
+	Claim  string
+	Prefix string
+	// MountPath is the path the claim is mounted at.
+	MountPath string
+}
+
+var _ Backend = (*PVC)(nil)
+
+// Upload copies the logs to a file of the claim.
+func (p *PVC) Upload(ctx context.Context, key string, content io.Reader, size int64) (string, error) {
+	dst := filepath.Join(p.MountPath, p.Prefix, key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", fmt.Errorf("error creating parent directory: %w", err)
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	if content != nil {
+		if _, err := io.CopyN(f, content, size); err != nil {
+			f.Close()
+			return "", fmt.Errorf("error copying the logs to %s: %w", dst, err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s://%s/%s", config.LogsArchiveSchemePVC, p.Claim, path.Join(p.Prefix, key)), nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	// unsignedPayload is the payload hash of the requests whose payload isn't part of
+	// their signature, which avoids reading the logs twice.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3 stores logs in the bucket of an S3-compatible object storage, e.g. AWS S3 or
+// MinIO, with path-style PUT requests signed with AWS Signature Version 4.
+type S3 struct {
+	// Endpoint is the URL of the object storage, e.g. https://s3.us-east-1.amazonaws.com.
+	Endpoint string
+	Bucket   string
+	Prefix   string
+	Region   string
+	// Credentials sign the requests, which are sent anonymously when they have no
+	// access key ID.
+	Credentials aws.Credentials
+	Client      *http.Client
+}
+
+var _ Backend = (*S3)(nil)
+
+// NewS3FromEnv returns the S3 backend storing logs in the given bucket, configured
+// from the standard AWS environment variables.
+func NewS3FromEnv(bucket, prefix string) *S3 {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = defaultS3Region
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	return &S3{
+		Endpoint: endpoint,
+		Bucket:   bucket,
+		Prefix:   prefix,
+		Region:   region,
+		Credentials: aws.Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+		Client: http.DefaultClient,
+	}
+}
+
+// Upload stores the logs as an object of the bucket.
+func (s *S3) Upload(ctx context.Context, key string, content io.Reader, size int64) (string, error) {
+	object := path.Join(s.Prefix, key)
+	u, err := url.Parse(strings.TrimSuffix(s.Endpoint, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", s.Endpoint, err)
+	}
+	u.Path = path.Join(u.Path, s.Bucket, object)
+
+	if size == 0 {
+		content = nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), content)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if s.Credentials.AccessKeyID != "" {
+		if err := v4.NewSigner().SignHTTP(ctx, s.Credentials, req, unsignedPayload, "s3", s.Region, time.Now()); err != nil {
+			return "", fmt.Errorf("error signing the request: %w", err)
+		}
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("unexpected status %s storing %s in bucket %q: %s", resp.Status, object, s.Bucket, strings.TrimSpace(string(body)))
+	}
+	return fmt.Sprintf("%s://%s/%s", config.LogsArchiveSchemeS3, s.Bucket, object), nil
+}
@@ -24,14 +24,18 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/metricslabels"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
@@ -39,10 +43,12 @@ import (
 )
 
 var (
-	pipelinerunTag = tag.MustNewKey("pipelinerun")
-	pipelineTag    = tag.MustNewKey("pipeline")
-	namespaceTag   = tag.MustNewKey("namespace")
-	statusTag      = tag.MustNewKey("status")
+	pipelinerunTag  = tag.MustNewKey("pipelinerun")
+	pipelineTag     = tag.MustNewKey("pipeline")
+	namespaceTag    = tag.MustNewKey("namespace")
+	statusTag       = tag.MustNewKey("status")
+	pipelineTaskTag = tag.MustNewKey("pipelinetask")
+	reasonTag       = tag.MustNewKey("reason")
 
 	prDuration = stats.Float64(
 		"pipelinerun_duration_seconds",
@@ -59,6 +65,27 @@ var (
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
 	runningPRsCountView *view.View
+
+	ptDuration = stats.Float64(
+		"pipelinerun_pipelinetask_duration_seconds",
+		"The execution time in seconds of the pipeline tasks of pipelineruns",
+		stats.UnitDimensionless)
+	ptDurationView *view.View
+
+	ptRetriesCount = stats.Float64("pipelinerun_pipelinetask_retries_count",
+		"number of retries of the pipeline tasks of pipelineruns",
+		stats.UnitDimensionless)
+	ptRetriesCountView *view.View
+
+	ptSkippedCount = stats.Float64("pipelinerun_pipelinetask_skipped_count",
+		"number of pipeline tasks skipped in pipelineruns",
+		stats.UnitDimensionless)
+	ptSkippedCountView *view.View
+
+	ptMatrixSize = stats.Float64("pipelinerun_pipelinetask_matrix_size",
+		"Number of taskruns or runs a matrixed pipeline task fanned out to",
+		stats.UnitDimensionless)
+	ptMatrixSizeView *view.View
 )
 
 const (
@@ -74,6 +101,8 @@ type Recorder struct {
 	insertTag func(pipeline,
 		pipelinerun string) []tag.Mutator
 
+	labelTagger *metricslabels.Tagger
+
 	ReportingPeriod time.Duration
 }
 
@@ -142,18 +171,21 @@ func viewRegister(cfg *config.Metrics) error {
 		}
 	}
 
+	r.labelTagger = metricslabels.NewTagger(cfg)
+	labelTag := r.labelTagger.TagKeys()
+
 	prDurationView = &view.View{
 		Description: prDuration.Description(),
 		Measure:     prDuration,
 		Aggregation: distribution,
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, prunTag...),
+		TagKeys:     append(append([]tag.Key{statusTag, namespaceTag}, prunTag...), labelTag...),
 	}
 
 	prCountView = &view.View{
 		Description: prCount.Description(),
 		Measure:     prCount,
 		Aggregation: view.Count(),
-		TagKeys:     []tag.Key{statusTag},
+		TagKeys:     append([]tag.Key{statusTag}, labelTag...),
 	}
 	runningPRsCountView = &view.View{
 		Description: runningPRsCount.Description(),
@@ -161,15 +193,46 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.LastValue(),
 	}
 
+	ptTag := []tag.Key{namespaceTag, pipelineTag, pipelineTaskTag}
+	ptDurationView = &view.View{
+		Description: ptDuration.Description(),
+		Measure:     ptDuration,
+		Aggregation: view.Distribution(10, 30, 60, 300, 900, 1800, 3600, 5400, 10800, 21600, 43200, 86400),
+		TagKeys:     append([]tag.Key{statusTag}, ptTag...),
+	}
+	ptRetriesCountView = &view.View{
+		Description: ptRetriesCount.Description(),
+		Measure:     ptRetriesCount,
+		Aggregation: view.Sum(),
+		TagKeys:     ptTag,
+	}
+	ptSkippedCountView = &view.View{
+		Description: ptSkippedCount.Description(),
+		Measure:     ptSkippedCount,
+		Aggregation: view.Count(),
+		TagKeys:     append([]tag.Key{reasonTag}, ptTag...),
+	}
+	ptMatrixSizeView = &view.View{
+		Description: ptMatrixSize.Description(),
+		Measure:     ptMatrixSize,
+		Aggregation: view.LastValue(),
+		TagKeys:     ptTag,
+	}
+
 	return view.Register(
 		prDurationView,
 		prCountView,
 		runningPRsCountView,
+		ptDurationView,
+		ptRetriesCountView,
+		ptSkippedCountView,
+		ptMatrixSizeView,
 	)
 }
 
 func viewUnregister() {
-	view.Unregister(prDurationView, prCountView, runningPRsCountView)
+	view.Unregister(prDurationView, prCountView, runningPRsCountView,
+		ptDurationView, ptRetriesCountView, ptSkippedCountView, ptMatrixSizeView)
 }
 
 // MetricsOnStore returns a function that checks if metrics are configured for a config.Store, and registers it if so
@@ -239,14 +302,11 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun, beforeCondition *ap
 		}
 	}
 
-	pipelineName := "anonymous"
-	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
-		pipelineName = pr.Spec.PipelineRef.Name
-	}
 	ctx, err := tag.New(
 		context.Background(),
-		append([]tag.Mutator{tag.Insert(namespaceTag, pr.Namespace),
-			tag.Insert(statusTag, status)}, r.insertTag(pipelineName, pr.Name)...)...)
+		append(append([]tag.Mutator{tag.Insert(namespaceTag, pr.Namespace),
+			tag.Insert(statusTag, status)}, r.insertTag(getPipelineName(pr), pr.Name)...),
+			r.labelTagger.Mutators(pr)...)...)
 	if err != nil {
 		return err
 	}
@@ -257,6 +317,119 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun, beforeCondition *ap
 	return nil
 }
 
+// pipelineTaskRuns aggregates the TaskRuns and Runs executing a PipelineTask
+type pipelineTaskRuns struct {
+	startTime      *metav1.Time
+	completionTime *metav1.Time
+	failed         bool
+	retries        int
+	count          int
+}
+
+func (p *pipelineTaskRuns) add(startTime, completionTime *metav1.Time, failed bool, retries int) {
+	if startTime != nil && (p.startTime == nil || startTime.Before(p.startTime)) {
+		p.startTime = startTime
+	}
+	if completionTime != nil && (p.completionTime == nil || p.completionTime.Before(completionTime)) {
+		p.completionTime = completionTime
+	}
+	p.failed = p.failed || failed
+	p.retries += retries
+	p.count++
+}
+
+// PipelineTaskMetrics logs the duration, the number of retries and the matrix fan-out
+// of each PipelineTask of a finished PipelineRun, as well as the PipelineTasks it skipped,
+// from the TaskRuns and Runs of the PipelineRun
+// returns an error if its failed to log the metrics
+func (r *Recorder) PipelineTaskMetrics(pr *v1beta1.PipelineRun, beforeCondition *apis.Condition, taskRuns []*v1beta1.TaskRun, runs []*v1alpha1.Run) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+
+	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
+	// To avoid recount
+	if !pr.IsDone() || equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	pipelineTasks := map[string]*pipelineTaskRuns{}
+	runsOf := func(pipelineTaskName string) *pipelineTaskRuns {
+		if _, ok := pipelineTasks[pipelineTaskName]; !ok {
+			pipelineTasks[pipelineTaskName] = &pipelineTaskRuns{}
+		}
+		return pipelineTasks[pipelineTaskName]
+	}
+	for _, tr := range taskRuns {
+		if name := tr.Labels[pipeline.PipelineTaskLabelKey]; name != "" {
+			runsOf(name).add(tr.Status.StartTime, tr.Status.CompletionTime, !tr.IsSuccessful(), len(tr.Status.RetriesStatus))
+		}
+	}
+	for _, run := range runs {
+		if name := run.Labels[pipeline.PipelineTaskLabelKey]; name != "" {
+			runsOf(name).add(run.Status.StartTime, run.Status.CompletionTime, !run.IsSuccessful(), len(run.Status.RetriesStatus))
+		}
+	}
+	matrixed := map[string]bool{}
+	if pr.Status.PipelineSpec != nil {
+		for _, pt := range append(pr.Status.PipelineSpec.Tasks, pr.Status.PipelineSpec.Finally...) {
+			matrixed[pt.Name] = pt.IsMatrixed()
+		}
+	}
+
+	pipelineName := getPipelineName(pr)
+	for name, ptRuns := range pipelineTasks {
+		ctx, err := tag.New(context.Background(),
+			tag.Insert(namespaceTag, pr.Namespace),
+			tag.Insert(pipelineTag, pipelineName),
+			tag.Insert(pipelineTaskTag, name))
+		if err != nil {
+			return err
+		}
+		if ptRuns.startTime != nil && ptRuns.completionTime != nil {
+			status := "success"
+			if ptRuns.failed {
+				status = "failed"
+			}
+			statusCtx, err := tag.New(ctx, tag.Insert(statusTag, status))
+			if err != nil {
+				return err
+			}
+			metrics.Record(statusCtx, ptDuration.M(float64(ptRuns.completionTime.Sub(ptRuns.startTime.Time)/time.Second)))
+		}
+		if ptRuns.retries > 0 {
+			metrics.Record(ctx, ptRetriesCount.M(float64(ptRuns.retries)))
+		}
+		if matrixed[name] {
+			metrics.Record(ctx, ptMatrixSize.M(float64(ptRuns.count)))
+		}
+	}
+	for _, skipped := range pr.Status.SkippedTasks {
+		ctx, err := tag.New(context.Background(),
+			tag.Insert(namespaceTag, pr.Namespace),
+			tag.Insert(pipelineTag, pipelineName),
+			tag.Insert(pipelineTaskTag, skipped.Name),
+			tag.Insert(reasonTag, string(skipped.Reason)))
+		if err != nil {
+			return err
+		}
+		metrics.Record(ctx, ptSkippedCount.M(1))
+	}
+
+	return nil
+}
+
+func getPipelineName(pr *v1beta1.PipelineRun) string {
+	pipelineName := "anonymous"
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		pipelineName = pr.Spec.PipelineRef.Name
+	}
+	return pipelineName
+}
+
 // RunningPipelineRuns logs the number of PipelineRuns running right now
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
@@ -19,19 +19,27 @@ package pipelinerunmetrics
 import (
 	"context"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun/fake"
+	"github.com/tektoncd/pipeline/pkg/internal/metricslabels"
 	"github.com/tektoncd/pipeline/pkg/names"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/metrics/metricstest" // Required to setup metrics env for testing
 	_ "knative.dev/pkg/metrics/testing"
@@ -64,6 +72,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.RunningPipelineRuns(nil); err == nil {
 		t.Error("Current PR count recording expected to return error but got nil")
 	}
+	if err := metrics.PipelineTaskMetrics(&v1beta1.PipelineRun{}, nil, nil, nil); err == nil {
+		t.Error("PipelineTaskMetrics recording expected to return error but got nil")
+	}
 }
 
 func TestMetricsOnStore(t *testing.T) {
@@ -334,6 +345,61 @@ func TestRecordPipelineRunDurationCount(t *testing.T) {
 	}
 }
 
+func TestRecordPipelineRunDurationCountWithLabels(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.Metrics.Labels = []string{"team"}
+	cfg.Metrics.LabelsMaxValues = 1
+	metrics, err := NewRecorder(config.ToContext(ctx, cfg))
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for _, team := range []string{"a", "b"} {
+		pr := &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-" + team, Namespace: "ns", Labels: map[string]string{"team": team}},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "pipeline-1"},
+			},
+			Status: v1beta1.PipelineRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+				},
+			},
+		}
+		if err := metrics.DurationAndCount(pr, nil); err != nil {
+			t.Errorf("DurationAndCount: %v", err)
+		}
+	}
+
+	// The second team is past the cap of values of the label
+	rows, err := view.RetrieveData("pipelinerun_count")
+	if err != nil {
+		t.Fatalf("RetrieveData: %v", err)
+	}
+	var got []string
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == "label_team" {
+				got = append(got, tag.Value)
+			}
+		}
+	}
+	sort.Strings(got)
+	if d := cmp.Diff([]string{"a", metricslabels.OverflowValue}, got); d != "" {
+		t.Errorf("Wrong label_team tags %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	unregisterMetrics()
 
@@ -377,8 +443,162 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 
 }
 
+func TestRecordPipelineTaskMetrics(t *testing.T) {
+	unregisterMetrics()
+
+	taskRun := func(name, pipelineTask string, duration time.Duration, status corev1.ConditionStatus, retries int) *v1beta1.TaskRun {
+		end := metav1.NewTime(startTime.Time.Add(duration))
+		return &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    map[string]string{pipeline.PipelineTaskLabelKey: pipelineTask},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: status,
+					}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &end,
+					RetriesStatus:  make([]v1beta1.TaskRunStatus, retries),
+				},
+			},
+		}
+	}
+	pipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline-1"},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+				}},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name: "build",
+						Matrix: &v1beta1.Matrix{
+							Params: []v1beta1.Param{{Name: "platform", Value: *v1beta1.NewStructuredValues("linux", "mac")}},
+						},
+					}, {
+						Name:    "test",
+						Retries: 2,
+					}, {
+						Name: "deploy",
+					}},
+					Finally: []v1beta1.PipelineTask{{
+						Name: "notify",
+					}},
+				},
+				SkippedTasks: []v1beta1.SkippedTask{{
+					Name:   "deploy",
+					Reason: v1beta1.ParentTasksSkip,
+				}},
+			},
+		},
+	}
+	taskRuns := []*v1beta1.TaskRun{
+		taskRun("pipelinerun-1-build-0", "build", time.Minute, corev1.ConditionTrue, 0),
+		taskRun("pipelinerun-1-build-1", "build", 2*time.Minute, corev1.ConditionTrue, 0),
+		taskRun("pipelinerun-1-test", "test", 30*time.Second, corev1.ConditionFalse, 2),
+	}
+	runs := []*v1alpha1.Run{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipelinerun-1-notify",
+			Namespace: "ns",
+			Labels:    map[string]string{pipeline.PipelineTaskLabelKey: "notify"},
+		},
+		Status: v1alpha1.RunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			RunStatusFields: v1alpha1.RunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+			},
+		},
+	}}
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	if err := metrics.PipelineTaskMetrics(pipelineRun, nil, taskRuns, runs); err != nil {
+		t.Errorf("PipelineTaskMetrics: %v", err)
+	}
+	tags := func(pipelineTask string, extra ...string) map[string]string {
+		tags := map[string]string{
+			"namespace":    "ns",
+			"pipeline":     "pipeline-1",
+			"pipelinetask": pipelineTask,
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			tags[extra[i]] = extra[i+1]
+		}
+		return tags
+	}
+	checkPipelineTaskDuration(t, tags("build", "status", "success"), 120)
+	checkPipelineTaskDuration(t, tags("test", "status", "failed"), 30)
+	checkPipelineTaskDuration(t, tags("notify", "status", "success"), 60)
+	metricstest.CheckSumData(t, "pipelinerun_pipelinetask_retries_count", tags("test"), 2)
+	metricstest.CheckCountData(t, "pipelinerun_pipelinetask_skipped_count", tags("deploy", "reason", string(v1beta1.ParentTasksSkip)), 1)
+	metricstest.CheckLastValueData(t, "pipelinerun_pipelinetask_matrix_size", tags("build"), 2)
+
+	// The metrics are not recorded again when the condition of the PipelineRun doesn't change
+	unregisterMetrics()
+	metrics, err = NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	if err := metrics.PipelineTaskMetrics(pipelineRun, pipelineRun.Status.GetCondition(apis.ConditionSucceeded), taskRuns, runs); err != nil {
+		t.Errorf("PipelineTaskMetrics: %v", err)
+	}
+	metricstest.CheckStatsNotReported(t, "pipelinerun_pipelinetask_duration_seconds", "pipelinerun_pipelinetask_retries_count",
+		"pipelinerun_pipelinetask_skipped_count", "pipelinerun_pipelinetask_matrix_size")
+}
+
+// checkPipelineTaskDuration checks the duration recorded for one of the pipeline tasks,
+// metricstest only supports metrics with a single row
+func checkPipelineTaskDuration(t *testing.T, wantTags map[string]string, wantDuration float64) {
+	t.Helper()
+	rows, err := view.RetrieveData("pipelinerun_pipelinetask_duration_seconds")
+	if err != nil {
+		t.Fatalf("RetrieveData: %v", err)
+	}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tag := range row.Tags {
+			tags[tag.Key.Name()] = tag.Value
+		}
+		if !reflect.DeepEqual(wantTags, tags) {
+			continue
+		}
+		data, ok := row.Data.(*view.DistributionData)
+		if !ok || data.Count != 1 || data.Min != wantDuration {
+			t.Errorf("Wrong duration for %v: %+v", wantTags, row.Data)
+		}
+		return
+	}
+	t.Errorf("No duration recorded for %v", wantTags)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count",
+		"pipelinerun_pipelinetask_duration_seconds", "pipelinerun_pipelinetask_retries_count",
+		"pipelinerun_pipelinetask_skipped_count", "pipelinerun_pipelinetask_matrix_size")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
@@ -247,9 +247,9 @@ func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.In
 	updated := false
 	if newPod.Status.Phase == corev1.PodRunning {
 		for _, s := range newPod.Status.ContainerStatuses {
-			// Stop any running container that isn't a step.
-			// An injected sidecar container might not have the
-			// "sidecar-" prefix, so we can't just look for that
+			// Stop any running container that isn't a step, including
+			// the log archiver. An injected sidecar container might not
+			// have the "sidecar-" prefix, so we can't just look for that
 			// prefix.
 			if !IsContainerStep(s.Name) && s.State.Running != nil {
 				for j, c := range newPod.Spec.Containers {
@@ -652,6 +652,17 @@ func TestStopSidecars(t *testing.T) {
 		Image: nopImage,
 	}
 
+	// The log archiver is stopped like a sidecar, and archives the logs of the last
+	// step before it exits.
+	logArchiverContainer := corev1.Container{
+		Name:  logArchiverContainerName,
+		Image: "entrypoint-image",
+	}
+	stoppedLogArchiverContainer := corev1.Container{
+		Name:  logArchiverContainer.Name,
+		Image: nopImage,
+	}
+
 	for _, c := range []struct {
 		desc           string
 		pod            corev1.Pod
@@ -681,6 +692,30 @@ func TestStopSidecars(t *testing.T) {
 			},
 		},
 		wantContainers: []corev1.Container{stepContainer, stoppedSidecarContainer, stoppedInjectedSidecar},
+	}, {
+		desc: "Running log archiver should be stopped",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{stepContainer, sidecarContainer, logArchiverContainer},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					// Step state doesn't matter.
+				}, {
+					Name:  sidecarContainer.Name,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}, {
+					Name: logArchiverContainer.Name,
+					// Log archiver is still archiving the logs of the last step.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}},
+			},
+		},
+		wantContainers: []corev1.Container{stepContainer, stoppedSidecarContainer, stoppedLogArchiverContainer},
 	}, {
 		desc: "Pending Pod should not be updated",
 		pod: corev1.Pod{
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// logArchiverContainerName is the name of the container archiving the logs of
+	// the steps. It is reported and stopped like a sidecar once the steps completed,
+	// and reports the logs it archived in its termination message.
+	logArchiverContainerName = "tekton-log-archiver"
+	logsVolumeName           = "tekton-internal-logs"
+	logsArchiveVolumeName    = "tekton-internal-logs-archive"
+	// logsArchiveErrorAnnotation is the annotation of a Pod whose steps don't archive
+	// their logs, holding the reason reported in the logsError of each step.
+	logsArchiveErrorAnnotation = "tekton.dev/logs-archive-error"
+)
+
+// checkLogsArchiveClaim returns an error if the logs archive of the defaults is a
+// PersistentVolumeClaim which can't be found in the namespace: the Pod mounting it
+// would never be scheduled.
+func checkLogsArchiveClaim(ctx context.Context, kubeclient kubernetes.Interface, defaults *config.Defaults, namespace string) error {
+	logsArchive, err := url.Parse(defaults.DefaultLogsArchive)
+	if err != nil || logsArchive.Scheme != config.LogsArchiveSchemePVC {
+		// An invalid logs archive is reported by archiveStepLogs
+		return nil
+	}
+	if _, err := kubeclient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, logsArchive.Host, metav1.GetOptions{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("the logs archive PersistentVolumeClaim %q does not exist in namespace %q", logsArchive.Host, namespace)
+		}
+		return fmt.Errorf("failed to get the logs archive PersistentVolumeClaim %q: %w", logsArchive.Host, err)
+	}
+	return nil
+}
+
+// archiveStepLogs makes the steps copy their logs to the logs volume, and returns
+// the container uploading them to the logs archive of the defaults once each step
+// completes, along with the volumes it needs. The logs of the steps are archived
+// under <logs archive>/<namespace>/<pod name>/<step name>.log.
+func archiveStepLogs(image string, defaults *config.Defaults, namespace, podName string, steps []corev1.Container) (corev1.Container, []corev1.Volume, error) {
+	logsArchive, err := url.Parse(defaults.DefaultLogsArchive)
+	if err != nil {
+		return corev1.Container{}, nil, fmt.Errorf("invalid logs archive %q: %w", defaults.DefaultLogsArchive, err)
+	}
+	destination := fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(defaults.DefaultLogsArchive, "/"), namespace, podName)
+
+	volumes := []corev1.Volume{{
+		Name:         logsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      logsVolumeName,
+		MountPath: logarchive.LogsDir,
+		ReadOnly:  true,
+	}}
+	command := []string{"/ko-app/entrypoint", "archive-logs", destination}
+	for i := range steps {
+		steps[i].Args = append([]string{"-log_path", logarchive.LogPath(i)}, steps[i].Args...)
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, corev1.VolumeMount{
+			Name:      logsVolumeName,
+			MountPath: logarchive.LogsDir,
+		})
+		volumeMounts = append(volumeMounts, runMount(i, true))
+		command = append(command, trimStepPrefix(steps[i].Name))
+	}
+
+	if logsArchive.Scheme == config.LogsArchiveSchemePVC {
+		volumes = append(volumes, corev1.Volume{
+			Name: logsArchiveVolumeName,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: logsArchive.Host,
+			}},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      logsArchiveVolumeName,
+			MountPath: logarchive.PVCMountPath,
+		})
+	}
+
+	archiver := corev1.Container{
+		Name:         logArchiverContainerName,
+		Image:        image,
+		WorkingDir:   "/",
+		Command:      command,
+		VolumeMounts: volumeMounts,
+	}
+	if defaults.DefaultLogsArchiveEndpoint != "" {
+		archiver.Env = []corev1.EnvVar{{Name: "AWS_ENDPOINT_URL", Value: defaults.DefaultLogsArchiveEndpoint}}
+	}
+	if defaults.DefaultLogsArchiveSecret != "" {
+		// The secret is read from the namespace of the TaskRun, so that each namespace
+		// provides its own credentials, and the logs are uploaded anonymously from the
+		// namespaces which don't.
+		optional := true
+		archiver.EnvFrom = []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: defaults.DefaultLogsArchiveSecret},
+				Optional:             &optional,
+			},
+		}}
+	}
+	return archiver, volumes, nil
+}
+
+// IsContainerLogArchiver returns true if the container name is the name of the
+// log archiver, which isn't reported as a sidecar.
+func IsContainerLogArchiver(name string) bool { return name == logArchiverContainerName }
+
+// IsLogArchiverRunning returns true if the Pod has a log archiver which didn't
+// terminate yet, and must be stopped along with the sidecars once the steps completed.
+func IsLogArchiverRunning(pod *corev1.Pod) bool {
+	for _, s := range pod.Status.ContainerStatuses {
+		if IsContainerLogArchiver(s.Name) && s.State.Terminated == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTaskRunStatusStepLogs records the URI the logs of the steps are archived at,
+// or the error archiving them, once the log archiver of the Pod terminated and
+// reported the logs it archived.
+func SetTaskRunStatusStepLogs(pod *corev1.Pod, trs *v1beta1.TaskRunStatus) {
+	if reason := pod.Annotations[logsArchiveErrorAnnotation]; reason != "" {
+		for i, s := range trs.Steps {
+			if s.Terminated != nil && s.LogsError == "" {
+				trs.Steps[i].LogsError = fmt.Sprintf("the logs of the step were not archived: %s", reason)
+			}
+		}
+		return
+	}
+	var destination string
+	for _, c := range pod.Spec.Containers {
+		if c.Name == logArchiverContainerName && len(c.Command) > 2 {
+			destination = c.Command[2]
+		}
+	}
+	if destination == "" {
+		return
+	}
+	var terminated *corev1.ContainerStateTerminated
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name != logArchiverContainerName {
+			continue
+		}
+		// The archiver is restarted with the nop image when it is stopped, in which
+		// case its results are in its last termination state.
+		terminated = s.State.Terminated
+		if s.LastTerminationState.Terminated != nil {
+			terminated = s.LastTerminationState.Terminated
+		}
+	}
+	if terminated == nil {
+		return
+	}
+
+	archived := map[string]string{}
+	results, err := logarchive.ParseResults(terminated.Message)
+	for _, r := range results {
+		archived[r.Step] = r.Error
+	}
+	for i, s := range trs.Steps {
+		if s.Terminated == nil || s.Logs != "" || s.LogsError != "" {
+			continue
+		}
+		switch archiveErr, ok := archived[s.Name]; {
+		case err != nil:
+			trs.Steps[i].LogsError = fmt.Sprintf("the logs archiver exited without reporting the logs it archived: %v", err)
+		case !ok:
+			trs.Steps[i].LogsError = "the logs archiver exited before archiving the logs of the step"
+		case archiveErr != "":
+			trs.Steps[i].LogsError = archiveErr
+		default:
+			trs.Steps[i].Logs = logarchive.URI(destination, s.Name)
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"math"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -42,6 +43,10 @@ const (
 	// TektonHermeticEnvVar is the env var we set in containers to indicate they should be run hermetically
 	TektonHermeticEnvVar = "TEKTON_HERMETIC"
 
+	// TektonHermeticEgressEnvVar is the env var listing the destinations that hermetic containers
+	// can still connect to, separated by commas
+	TektonHermeticEgressEnvVar = "TEKTON_HERMETIC_EGRESS"
+
 	// ExecutionModeAnnotation is an experimental optional annotation to set the execution mode on a TaskRun
 	ExecutionModeAnnotation = "experimental.tekton.dev/execution-mode"
 
@@ -156,10 +161,13 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		entrypointInitContainer(b.Images.EntrypointImage, steps),
 	}
 
-	// Convert any steps with Script to command+args.
+	// Convert any steps with Script or ScriptRef to command+args.
 	// If any are found, append an init container to initialize scripts.
 	if alphaAPIEnabled {
+		var scriptRefVolumes []corev1.Volume
 		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, b.Images.ShellImageWin, steps, sidecars, taskRun.Spec.Debug)
+		scriptsInit, scriptRefVolumes = convertScriptRefs(b.Images.ShellImage, scriptsInit, steps, stepContainers, taskSpec.Workspaces)
+		volumes = append(volumes, scriptRefVolumes...)
 	} else {
 		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, "", steps, sidecars, nil)
 	}
@@ -214,11 +222,17 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		}
 	}
 
-	// Add env var if hermetic execution was requested & if the alpha API is enabled
-	if taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic && alphaAPIEnabled {
-		for i, s := range stepContainers {
+	// Add env var if hermetic execution was requested for the TaskRun, the Task or the Step,
+	// & if the alpha API is enabled. Hermetic steps with an egress allow-list also get it
+	// in another env var.
+	hermeticTaskRun := taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic
+	for i, s := range stepContainers {
+		if alphaAPIEnabled && (hermeticTaskRun || taskSpec.Hermetic || steps[i].Hermetic) {
 			// Add it at the end so it overrides
 			env := append(s.Env, corev1.EnvVar{Name: TektonHermeticEnvVar, Value: "1"}) //nolint
+			if egress := append(append([]string{}, taskSpec.Egress...), steps[i].Egress...); len(egress) > 0 {
+				env = append(env, corev1.EnvVar{Name: TektonHermeticEgressEnvVar, Value: strings.Join(egress, ",")})
+			}
 			stepContainers[i].Env = env
 		}
 	}
@@ -267,6 +281,28 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		stepContainers[i].Name = names.SimpleNameGenerator.RestrictLength(StepName(s.Name, i))
 	}
 
+	podNameSuffix := "-pod"
+	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
+		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
+	}
+	podName := kmeta.ChildName(taskRun.Name, podNameSuffix)
+
+	// Archive the logs of the steps, if a logs archive is configured. The steps run
+	// without archiving their logs if the logs archive is a claim which can't be found,
+	// and report why in their logsError.
+	var logArchiverContainers []corev1.Container
+	var logsArchiveError error
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil && defaults.DefaultLogsArchive != "" {
+		if logsArchiveError = checkLogsArchiveClaim(ctx, b.KubeClient, defaults, taskRun.Namespace); logsArchiveError == nil {
+			logArchiver, logsVolumes, err := archiveStepLogs(b.Images.EntrypointImage, defaults, taskRun.Namespace, podName, stepContainers)
+			if err != nil {
+				return nil, err
+			}
+			logArchiverContainers = append(logArchiverContainers, logArchiver)
+			volumes = append(volumes, logsVolumes...)
+		}
+	}
+
 	// Add podTemplate Volumes to the explicitly declared use volumes
 	volumes = append(volumes, taskSpec.Volumes...)
 	volumes = append(volumes, podTemplate.Volumes...)
@@ -282,6 +318,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
 		mergedPodContainers = append(mergedPodContainers, sc)
 	}
+	mergedPodContainers = append(mergedPodContainers, logArchiverContainers...)
 
 	var dnsPolicy corev1.DNSPolicy
 	if podTemplate.DNSPolicy != nil {
@@ -295,6 +332,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 
 	podAnnotations := kmeta.CopyMap(taskRun.Annotations)
 	podAnnotations[ReleaseAnnotation] = changeset.Get()
+	if logsArchiveError != nil {
+		podAnnotations[logsArchiveErrorAnnotation] = logsArchiveError.Error()
+	}
 
 	if readyImmediately {
 		podAnnotations[readyAnnotation] = readyAnnotationValue
@@ -307,10 +347,6 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		activeDeadlineSeconds = MaxActiveDeadlineSeconds
 	}
 
-	podNameSuffix := "-pod"
-	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
-		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
-	}
 	newPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			// We execute the build's pod in the same namespace as where the build was
@@ -319,7 +355,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			// Generate a unique name based on the build's name.
 			// The name is univocally generated so that in case of
 			// stale informer cache, we never create duplicate Pods
-			Name: kmeta.ChildName(taskRun.Name, podNameSuffix),
+			Name: podName,
 			// If our parent TaskRun is deleted, then we should be as well.
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(taskRun, groupVersionKind),
@@ -1608,6 +1608,233 @@ _EOF_
 				}),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
+		}, {
+			desc:         "hermetic step",
+			featureFlags: map[string]string{"enable-api-fields": "alpha"},
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:    "fetch",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}, {
+					Name:     "build",
+					Image:    "image",
+					Command:  []string{"cmd"}, // avoid entrypoint lookup.
+					Hermetic: true,
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "fetch"}, {Name: "build"}})},
+				Containers: []corev1.Container{{
+					Name:    "step-fetch",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), runMount(1, true), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:    "step-build",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/run/0/out",
+						"-post_file",
+						"/tekton/run/1/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/1/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, true), runMount(1, false), {
+						Name:      "tekton-creds-init-home-1",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+					},
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), runVolume(1), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-1",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		}, {
+			desc:         "hermetic step with egress",
+			featureFlags: map[string]string{"enable-api-fields": "alpha"},
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:    "fetch",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}, {
+					Name:     "build",
+					Image:    "image",
+					Command:  []string{"cmd"}, // avoid entrypoint lookup.
+					Hermetic: true,
+					Egress:   []string{"storage.googleapis.com:443"},
+				}},
+				Egress: []string{"proxy.golang.org:443"},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "fetch"}, {Name: "build"}})},
+				Containers: []corev1.Container{{
+					Name:    "step-fetch",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), runMount(1, true), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:    "step-build",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/run/0/out",
+						"-post_file",
+						"/tekton/run/1/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/1/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, true), runMount(1, false), {
+						Name:      "tekton-creds-init-home-1",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+						{Name: "TEKTON_HERMETIC_EGRESS", Value: "proxy.golang.org:443,storage.googleapis.com:443"},
+					},
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), runVolume(1), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-1",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		}, {
+			desc: "hermetic step without alpha API fields",
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:    "fetch",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}, {
+					Name:     "build",
+					Image:    "image",
+					Command:  []string{"cmd"}, // avoid entrypoint lookup.
+					Hermetic: true,
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "fetch"}, {Name: "build"}})},
+				Containers: []corev1.Container{{
+					Name:    "step-fetch",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), runMount(1, true), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:    "step-build",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/run/0/out",
+						"-post_file",
+						"/tekton/run/1/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/1/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, true), runMount(1, false), {
+						Name:      "tekton-creds-init-home-1",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), runVolume(1), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-1",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
 		}, {
 			desc: "pod for a taskRun with retries",
 			ts: v1beta1.TaskSpec{
@@ -2289,6 +2516,176 @@ func verifyTaskLevelComputeResources(expectedComputeResources []ExpectedComputeR
 	return nil
 }
 
+func TestPodBuildWithLogsArchive(t *testing.T) {
+	trueB := true
+	for _, tc := range []struct {
+		desc             string
+		defaults         map[string]string
+		wantArchiver     corev1.Container
+		wantClaimVolumes []corev1.Volume
+	}{{
+		desc: "s3",
+		defaults: map[string]string{
+			"default-logs-archive":          "s3://tekton-logs/ci",
+			"default-logs-archive-endpoint": "http://minio.minio.svc:9000",
+			"default-logs-archive-secret":   "logs-archive-credentials",
+		},
+		wantArchiver: corev1.Container{
+			Name:       logArchiverContainerName,
+			Image:      images.EntrypointImage,
+			WorkingDir: "/",
+			Command:    []string{"/ko-app/entrypoint", "archive-logs", "s3://tekton-logs/ci/default/taskrun-name-pod", "build", "unnamed-1"},
+			Env:        []corev1.EnvVar{{Name: "AWS_ENDPOINT_URL", Value: "http://minio.minio.svc:9000"}},
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "logs-archive-credentials"},
+					Optional:             &trueB,
+				},
+			}},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: logsVolumeName, MountPath: "/tekton/logs", ReadOnly: true},
+				runMount(0, true),
+				runMount(1, true),
+			},
+		},
+	}, {
+		desc: "pvc",
+		defaults: map[string]string{
+			"default-logs-archive": "pvc://logs/ci",
+		},
+		wantArchiver: corev1.Container{
+			Name:       logArchiverContainerName,
+			Image:      images.EntrypointImage,
+			WorkingDir: "/",
+			Command:    []string{"/ko-app/entrypoint", "archive-logs", "pvc://logs/ci/default/taskrun-name-pod", "build", "unnamed-1"},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: logsVolumeName, MountPath: "/tekton/logs", ReadOnly: true},
+				runMount(0, true),
+				runMount(1, true),
+				{Name: logsArchiveVolumeName, MountPath: "/tekton/logs-archive"},
+			},
+		},
+		wantClaimVolumes: []corev1.Volume{{
+			Name: logsArchiveVolumeName,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "logs",
+			}},
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data:       tc.defaults,
+			})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+				&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "default"}},
+			)
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+			ts := v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+				Name:    "build",
+				Image:   "image",
+				Command: []string{"cmd"},
+			}, {
+				Image:   "image",
+				Command: []string{"cmd"},
+			}}}
+
+			got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			containers := got.Spec.Containers
+			if d := cmp.Diff(tc.wantArchiver, containers[len(containers)-1]); d != "" {
+				t.Errorf("Wrong log archiver container %s", diff.PrintWantGot(d))
+			}
+			for i, step := range containers[:len(containers)-1] {
+				wantArgs := []string{"-log_path", fmt.Sprintf("/tekton/logs/%d.log", i)}
+				if d := cmp.Diff(wantArgs, step.Args[:2]); d != "" {
+					t.Errorf("Wrong arguments of step %q %s", step.Name, diff.PrintWantGot(d))
+				}
+				wantMount := corev1.VolumeMount{Name: logsVolumeName, MountPath: "/tekton/logs"}
+				if !containsVolumeMount(step.VolumeMounts, wantMount) {
+					t.Errorf("Step %q doesn't mount the logs volume: %v", step.Name, step.VolumeMounts)
+				}
+			}
+			wantVolumes := append([]corev1.Volume{{
+				Name:         logsVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}}, tc.wantClaimVolumes...)
+			var gotVolumes []corev1.Volume
+			for _, v := range got.Spec.Volumes {
+				if v.Name == logsVolumeName || v.Name == logsArchiveVolumeName {
+					gotVolumes = append(gotVolumes, v)
+				}
+			}
+			if d := cmp.Diff(wantVolumes, gotVolumes); d != "" {
+				t.Errorf("Wrong logs volumes %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuildWithMissingLogsArchiveClaim(t *testing.T) {
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"default-logs-archive": "pvc://logs/ci"},
+	})
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+		// The claim exists in another namespace only
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "other"}},
+	)
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+	ts := v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+		Name:    "build",
+		Image:   "image",
+		Command: []string{"cmd"},
+	}}}
+
+	got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	for _, c := range got.Spec.Containers {
+		if c.Name == logArchiverContainerName {
+			t.Errorf("Expected no log archiver when the logs archive claim doesn't exist, got %v", c)
+		}
+	}
+	for _, v := range got.Spec.Volumes {
+		if v.Name == logsVolumeName || v.Name == logsArchiveVolumeName {
+			t.Errorf("Expected no logs volume when the logs archive claim doesn't exist, got %v", v)
+		}
+	}
+	want := `the logs archive PersistentVolumeClaim "logs" does not exist in namespace "default"`
+	if d := cmp.Diff(want, got.Annotations[logsArchiveErrorAnnotation]); d != "" {
+		t.Errorf("Wrong logs archive error %s", diff.PrintWantGot(d))
+	}
+}
+
+func containsVolumeMount(volumeMounts []corev1.VolumeMount, want corev1.VolumeMount) bool {
+	for _, vm := range volumeMounts {
+		if vm == want {
+			return true
+		}
+	}
+	return false
+}
+
 func TestMakeLabels(t *testing.T) {
 	taskRunName := "task-run-name"
 	want := map[string]string{
@@ -35,6 +35,8 @@ const (
 	scriptsDir             = "/tekton/scripts"
 	debugScriptsDir        = "/tekton/debug/scripts"
 	defaultScriptPreamble  = "#!/bin/sh\nset -e\n"
+	scriptRefVolumePrefix  = "tekton-internal-script-ref-"
+	scriptRefsDir          = "/tekton/script-refs"
 	debugInfoDir           = "/tekton/debug/info"
 )
 
@@ -231,6 +233,97 @@ cat > ${scriptfile} << '%s'
 	return containers
 }
 
+// convertScriptRefs converts any steps that specify a ScriptRef field into containers running
+// the referenced script.
+//
+// Referenced scripts are only known once the Pod runs, so instead of being written inline they
+// are copied by the place-scripts init container from the ConfigMap or Workspace holding them
+// into the scripts volume, where the default shebang is added if they don't start with one.
+// They are never passed through container args so unlike inline scripts they aren't encoded
+// and don't need to be decoded. It returns the place-scripts init container, creating it if
+// needed, and the volumes holding the referenced ConfigMap keys.
+func convertScriptRefs(shellImage string, placeScriptsInit *corev1.Container, steps []v1beta1.Step, stepContainers []corev1.Container, workspaces []v1beta1.WorkspaceDeclaration) (*corev1.Container, []corev1.Volume) {
+	var volumes []corev1.Volume
+	for i, s := range steps {
+		if s.ScriptRef == nil {
+			continue
+		}
+		if placeScriptsInit == nil {
+			placeScriptsInit = &corev1.Container{
+				Name:         "place-scripts",
+				Image:        shellImage,
+				Command:      []string{"sh"},
+				Args:         []string{"-c", ""},
+				VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount},
+			}
+		}
+
+		var source string
+		switch {
+		case s.ScriptRef.ConfigMap != nil:
+			volumeName := fmt.Sprintf("%s%d", scriptRefVolumePrefix, i)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: s.ScriptRef.ConfigMap.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: s.ScriptRef.ConfigMap.Key, Path: "script"}},
+				}},
+			})
+			mountPath := filepath.Join(scriptRefsDir, fmt.Sprintf("%d", i))
+			placeScriptsInit.VolumeMounts = append(placeScriptsInit.VolumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath, ReadOnly: true})
+			source = filepath.Join(mountPath, "script")
+		case s.ScriptRef.Workspace != nil:
+			for _, w := range workspaces {
+				if w.Name != s.ScriptRef.Workspace.Name {
+					continue
+				}
+				source = filepath.Join(w.GetMountPath(), s.ScriptRef.Workspace.Path)
+				addWorkspaceMount(placeScriptsInit, stepContainers, w.GetMountPath())
+			}
+		}
+
+		// Append to the place-scripts script to copy the script file
+		// into a known location in the scripts volume.
+		scriptFile := filepath.Join(scriptsDir, names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("script-ref-%d", i)))
+		preamble := strings.ReplaceAll(defaultScriptPreamble, "\n", `\n`)
+		// The path of the script is quoted, as it comes from the Task and may hold
+		// any character the shell would otherwise interpret.
+		placeScriptsInit.Args[1] += fmt.Sprintf(`scriptfile="%s"
+touch ${scriptfile} && chmod +x ${scriptfile}
+if ! grep -m 1 -v '^[[:space:]]*$' %s | grep -q '^[[:space:]]*#!'; then printf '%s' > ${scriptfile}; fi
+cat %s >> ${scriptfile} || exit 1
+`, scriptFile, shellQuote(source), preamble, shellQuote(source))
+
+		stepContainers[i].Command = []string{scriptFile}
+		stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, scriptsVolumeMount)
+	}
+	return placeScriptsInit, volumes
+}
+
+// addWorkspaceMount mounts the workspace mounted at mountPath in any of the containers
+// into the place-scripts init container, so that it can read the scripts it holds.
+func addWorkspaceMount(placeScriptsInit *corev1.Container, containers []corev1.Container, mountPath string) {
+	for _, vm := range placeScriptsInit.VolumeMounts {
+		if vm.MountPath == mountPath {
+			return
+		}
+	}
+	for _, c := range containers {
+		for _, vm := range c.VolumeMounts {
+			if vm.MountPath == mountPath {
+				vm.ReadOnly = true
+				placeScriptsInit.VolumeMounts = append(placeScriptsInit.VolumeMounts, vm)
+				return
+			}
+		}
+	}
+}
+
+// shellQuote quotes s as a single word which the shell doesn't expand.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
 // encodeScript encodes a script field into a format that avoids kubernetes' built-in processing of container args,
 // which can mangle dollar signs and unexpectedly replace variable references in the user's script.
 func encodeScript(script string) string {
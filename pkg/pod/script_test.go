@@ -651,3 +651,121 @@ sidecar-1
 	}
 
 }
+
+func TestConvertScriptRefs(t *testing.T) {
+	names.TestingSeed()
+
+	workspaceMount := corev1.VolumeMount{Name: "ws-9l9zj", MountPath: "/workspace/source"}
+	steps := []v1beta1.Step{{
+		Script: "#!/bin/sh\nscript-1",
+		Image:  "step-1",
+	}, {
+		Image: "step-2",
+		ScriptRef: &v1beta1.ScriptRef{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+			Key:                  "build.sh",
+		}},
+	}, {
+		Image:        "step-3",
+		ScriptRef:    &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: "ci/test.sh"}},
+		Args:         []string{"my", "args"},
+		VolumeMounts: []corev1.VolumeMount{workspaceMount},
+	}}
+	gotInit, gotSteps, _ := convertScripts(images.ShellImage, images.ShellImageWin, steps, nil, nil)
+	gotInit, gotVolumes := convertScriptRefs(images.ShellImage, gotInit, steps, gotSteps, []v1beta1.WorkspaceDeclaration{{Name: "source"}})
+
+	wantInit := &corev1.Container{
+		Name:    "place-scripts",
+		Image:   images.ShellImage,
+		Command: []string{"sh"},
+		Args: []string{"-c", `scriptfile="/tekton/scripts/script-0-9l9zj"
+touch ${scriptfile} && chmod +x ${scriptfile}
+cat > ${scriptfile} << '_EOF_'
+IyEvYmluL3NoCnNjcmlwdC0x
+_EOF_
+/tekton/bin/entrypoint decode-script "${scriptfile}"
+scriptfile="/tekton/scripts/script-ref-1-mz4c7"
+touch ${scriptfile} && chmod +x ${scriptfile}
+if ! grep -m 1 -v '^[[:space:]]*$' '/tekton/script-refs/1/script' | grep -q '^[[:space:]]*#!'; then printf '#!/bin/sh\nset -e\n' > ${scriptfile}; fi
+cat '/tekton/script-refs/1/script' >> ${scriptfile} || exit 1
+scriptfile="/tekton/scripts/script-ref-2-mssqb"
+touch ${scriptfile} && chmod +x ${scriptfile}
+if ! grep -m 1 -v '^[[:space:]]*$' '/workspace/source/ci/test.sh' | grep -q '^[[:space:]]*#!'; then printf '#!/bin/sh\nset -e\n' > ${scriptfile}; fi
+cat '/workspace/source/ci/test.sh' >> ${scriptfile} || exit 1
+`},
+		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount, {
+			Name:      "tekton-internal-script-ref-1",
+			MountPath: "/tekton/script-refs/1",
+			ReadOnly:  true,
+		}, {
+			Name:      "ws-9l9zj",
+			MountPath: "/workspace/source",
+			ReadOnly:  true,
+		}},
+	}
+	want := []corev1.Container{{
+		Image:        "step-1",
+		Command:      []string{"/tekton/scripts/script-0-9l9zj"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}, {
+		Image:        "step-2",
+		Command:      []string{"/tekton/scripts/script-ref-1-mz4c7"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}, {
+		Image:        "step-3",
+		Command:      []string{"/tekton/scripts/script-ref-2-mssqb"},
+		Args:         []string{"my", "args"},
+		VolumeMounts: []corev1.VolumeMount{workspaceMount, scriptsVolumeMount},
+	}}
+	wantVolumes := []corev1.Volume{{
+		Name: "tekton-internal-script-ref-1",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+			Items:                []corev1.KeyToPath{{Key: "build.sh", Path: "script"}},
+		}},
+	}}
+	if d := cmp.Diff(wantInit, gotInit); d != "" {
+		t.Errorf("Init Container Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(want, gotSteps); d != "" {
+		t.Errorf("Containers Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantVolumes, gotVolumes); d != "" {
+		t.Errorf("Volumes Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestConvertScriptRefs_QuotesPaths(t *testing.T) {
+	names.TestingSeed()
+
+	workspaceMount := corev1.VolumeMount{Name: "ws-9l9zj", MountPath: "/workspace/source"}
+	steps := []v1beta1.Step{{
+		Image:        "step-1",
+		ScriptRef:    &v1beta1.ScriptRef{Workspace: &v1beta1.WorkspaceScriptSource{Name: "source", Path: `ci/$(id) "it's" ` + "`ls`.sh"}},
+		VolumeMounts: []corev1.VolumeMount{workspaceMount},
+	}}
+	gotInit, gotSteps, _ := convertScripts(images.ShellImage, images.ShellImageWin, steps, nil, nil)
+	gotInit, _ = convertScriptRefs(images.ShellImage, gotInit, steps, gotSteps, []v1beta1.WorkspaceDeclaration{{Name: "source"}})
+
+	quoted := `'/workspace/source/ci/$(id) "it'"'"'s" ` + "`ls`.sh'"
+	want := `scriptfile="/tekton/scripts/script-ref-0-9l9zj"
+touch ${scriptfile} && chmod +x ${scriptfile}
+if ! grep -m 1 -v '^[[:space:]]*$' ` + quoted + ` | grep -q '^[[:space:]]*#!'; then printf '#!/bin/sh\nset -e\n' > ${scriptfile}; fi
+cat ` + quoted + ` >> ${scriptfile} || exit 1
+`
+	if d := cmp.Diff(want, gotInit.Args[1]); d != "" {
+		t.Errorf("Init Container script Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestConvertScriptRefs_NothingToConvert(t *testing.T) {
+	steps := []v1beta1.Step{{Image: "step-1"}}
+	gotInit, gotSteps, _ := convertScripts(images.ShellImage, images.ShellImageWin, steps, nil, nil)
+	gotInit, gotVolumes := convertScriptRefs(images.ShellImage, gotInit, steps, gotSteps, nil)
+	if gotInit != nil {
+		t.Errorf("Wanted nil init container, got %v", gotInit)
+	}
+	if len(gotVolumes) != 0 {
+		t.Errorf("Wanted 0 volumes, got %v", gotVolumes)
+	}
+}
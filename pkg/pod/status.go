@@ -19,6 +19,7 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -74,6 +75,26 @@ const (
 
 const oomKilled = "OOMKilled"
 
+// podDisruptionTarget is the type of the condition set on a Pod which is
+// about to be deleted because of a disruption, such as a preemption or an
+// eviction through the Eviction API.
+const podDisruptionTarget corev1.PodConditionType = "DisruptionTarget"
+
+// podEvictionReasons are the reasons the kubelet and the node lifecycle
+// controller set on the status of a Pod they terminated, regardless of the
+// steps it was running. DeadlineExceeded is set when the Pod outlived its
+// ActiveDeadlineSeconds before the TaskRun timed out, e.g. while its node
+// was unreachable.
+var podEvictionReasons = map[string]bool{
+	"DeadlineExceeded": true,
+	"Evicted":          true,
+	"Preempting":       true,
+	"NodeLost":         true,
+	"NodeShutdown":     true,
+	"Shutdown":         true,
+	"Terminated":       true,
+}
+
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
 // Terminated.
 func SidecarsReady(podStatus corev1.PodStatus) bool {
@@ -108,6 +129,7 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	}
 
 	sortPodContainerStatuses(pod.Status.ContainerStatuses, pod.Spec.Containers)
+	starts := containerStartTimes(pod)
 
 	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
 
@@ -132,22 +154,62 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	}
 
 	var merr *multierror.Error
-	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr); err != nil {
+	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr, starts); err != nil {
 		merr = multierror.Append(merr, err)
 	}
 
+	SetTaskRunStatusStepLogs(pod, trs)
+
 	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
 
+	setTaskRunStatusProvenance(pod, trs)
+
 	trs.TaskRunResults = removeDuplicateResults(trs.TaskRunResults)
 
 	return *trs, merr.ErrorOrNil()
 }
 
-func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun) *multierror.Error {
+// setTaskRunStatusProvenance records in the provenance of the TaskRun the steps that
+// were configured to run without network access.
+func setTaskRunStatusProvenance(pod *corev1.Pod, trs *v1beta1.TaskRunStatus) {
+	var hermeticSteps []string
+	for _, c := range pod.Spec.Containers {
+		if IsContainerStep(c.Name) && isContainerHermetic(c) {
+			hermeticSteps = append(hermeticSteps, trimStepPrefix(c.Name))
+		}
+	}
+	if len(hermeticSteps) == 0 {
+		return
+	}
+	if trs.Provenance == nil {
+		trs.Provenance = &v1beta1.Provenance{}
+	}
+	trs.Provenance.HermeticSteps = hermeticSteps
+}
+
+// isContainerHermetic returns true if the entrypoint drops the network access of the
+// container, which it does for all hermetic containers except PipelineResource steps.
+func isContainerHermetic(c corev1.Container) bool {
+	hermetic := false
+	for _, e := range c.Env {
+		switch e.Name {
+		case TektonHermeticEnvVar:
+			hermetic = e.Value == "1"
+		case "TEKTON_RESOURCE_NAME":
+			if e.Value != "" {
+				return false
+			}
+		}
+	}
+	return hermetic
+}
+
+func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun, starts map[string]containerStart) *multierror.Error {
 	trs := &tr.Status
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		var commandStartedAt *metav1.Time
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -180,6 +242,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				}
 				if time != nil {
 					s.State.Terminated.StartedAt = *time
+					commandStartedAt = time
 				}
 				if exitCode != nil {
 					s.State.Terminated.ExitCode = *exitCode
@@ -191,6 +254,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 			Name:           trimStepPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
+			Timing:         stepTiming(s.State, starts[s.Name], commandStartedAt),
 		})
 	}
 
@@ -272,6 +336,113 @@ func removeDuplicateResults(taskRunResult []v1beta1.TaskRunResult) []v1beta1.Tas
 	return uniq
 }
 
+// containerStart records when a container of a Pod started, and when the container
+// started before it, or the Pod was initialized for its first container.
+type containerStart struct {
+	previous metav1.Time
+	started  metav1.Time
+}
+
+// containerStartTimes returns the start times of the containers of a Pod, whose
+// statuses are sorted in the order the kubelet starts them, pulling their image first.
+// They're collected before the start time of the steps is replaced with the start time
+// of their command.
+func containerStartTimes(pod *corev1.Pod) map[string]containerStart {
+	var previous metav1.Time
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodInitialized && c.Status == corev1.ConditionTrue {
+			previous = c.LastTransitionTime
+		}
+	}
+	starts := map[string]containerStart{}
+	for _, s := range pod.Status.ContainerStatuses {
+		var started metav1.Time
+		switch {
+		case s.State.Running != nil:
+			started = s.State.Running.StartedAt
+		case s.State.Terminated != nil:
+			started = s.State.Terminated.StartedAt
+		}
+		starts[s.Name] = containerStart{previous: previous, started: started}
+		previous = started
+	}
+	return starts
+}
+
+// stepTiming returns the time a step spent starting its container, waiting for the previous
+// steps and running its command, as far as they're known given the state of its container
+// and the start time of its command reported by the entrypoint.
+func stepTiming(state corev1.ContainerState, start containerStart, commandStartedAt *metav1.Time) *v1beta1.StepTiming {
+	if start.started.IsZero() {
+		return nil
+	}
+	timing := &v1beta1.StepTiming{}
+	if !start.previous.IsZero() {
+		timing.Startup = durationBetween(start.previous, start.started)
+	}
+	if commandStartedAt != nil && state.Terminated != nil {
+		timing.Waiting = durationBetween(start.started, *commandStartedAt)
+		timing.Execution = durationBetween(*commandStartedAt, state.Terminated.FinishedAt)
+	}
+	if timing.Startup == nil && timing.Waiting == nil {
+		return nil
+	}
+	return timing
+}
+
+// durationBetween returns the duration between two times, which is zero rather than
+// negative when the times were recorded with different precisions.
+func durationBetween(from, to metav1.Time) *metav1.Duration {
+	d := to.Sub(from.Time)
+	if d < 0 {
+		d = 0
+	}
+	return &metav1.Duration{Duration: d}
+}
+
+// pulledImageDuration matches the time the kubelet reports in the message of the Pulled
+// event of a container, e.g. `Successfully pulled image "busybox" in 1.234s`.
+var pulledImageDuration = regexp.MustCompile(`^Successfully pulled image ".*" in ([0-9.]+[a-zµ]+)`)
+
+// StepImagePullTimes returns the time the kubelet spent pulling the image of the containers
+// of a Pod, by container name, from the Pulling and Pulled events it recorded for them. It
+// is zero for a container whose image was already present on the node.
+func StepImagePullTimes(pod *corev1.Pod, events []corev1.Event) map[string]*metav1.Duration {
+	pulling := map[string]metav1.Time{}
+	pulled := map[string]corev1.Event{}
+	for _, e := range events {
+		if e.InvolvedObject.Kind != "Pod" || e.InvolvedObject.Name != pod.Name {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(e.InvolvedObject.FieldPath, "spec.containers{"), "}")
+		if name == e.InvolvedObject.FieldPath {
+			continue
+		}
+		switch e.Reason {
+		case "Pulling":
+			pulling[name] = e.LastTimestamp
+		case "Pulled":
+			pulled[name] = e
+		}
+	}
+	pulls := map[string]*metav1.Duration{}
+	for name, e := range pulled {
+		if m := pulledImageDuration.FindStringSubmatch(e.Message); m != nil {
+			if d, err := time.ParseDuration(m[1]); err == nil {
+				pulls[name] = &metav1.Duration{Duration: d}
+				continue
+			}
+		}
+		switch start, ok := pulling[name]; {
+		case ok:
+			pulls[name] = durationBetween(start, e.LastTimestamp)
+		case strings.Contains(e.Message, "already present on machine"):
+			pulls[name] = &metav1.Duration{}
+		}
+	}
+	return pulls
+}
+
 func extractStartedAtTimeFromResults(results []v1beta1.PipelineResourceResult) (*metav1.Time, error) {
 	for _, result := range results {
 		if result.Key == "StartedAt" {
@@ -304,7 +475,11 @@ func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int3
 func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
-		markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
+		reason := v1beta1.TaskRunReasonFailed.String()
+		if evicted, _ := IsPodEvicted(pod); evicted {
+			reason = v1beta1.TaskRunReasonEvicted.String()
+		}
+		markStatusFailure(trs, reason, msg)
 	} else {
 		markStatusSuccess(trs)
 	}
@@ -323,10 +498,10 @@ func updateIncompleteTaskRunStatus(trs *v1beta1.TaskRunStatus, pod *corev1.Pod)
 			markStatusRunning(trs, ReasonExceededNodeResources, "TaskRun Pod exceeded available resources")
 		case isPodHitConfigError(pod):
 			markStatusFailure(trs, ReasonCreateContainerConfigError, "Failed to create pod due to config error")
-		case isPullImageError(pod):
-			markStatusRunning(trs, ReasonPullImageFailed, getWaitingMessage(pod))
+		case IsPullImageError(pod):
+			markStatusRunning(trs, ReasonPullImageFailed, GetWaitingMessage(pod))
 		default:
-			markStatusRunning(trs, ReasonPending, getWaitingMessage(pod))
+			markStatusRunning(trs, ReasonPending, GetWaitingMessage(pod))
 		}
 	}
 }
@@ -409,6 +584,35 @@ func IsPodExceedingNodeResources(pod *corev1.Pod) bool {
 	return false
 }
 
+// IsPodUnschedulable returns true if the Pod's status indicates that it could not
+// be scheduled on any node.
+func IsPodUnschedulable(pod *corev1.Pod) bool {
+	for _, podStatus := range pod.Status.Conditions {
+		if podStatus.Type == corev1.PodScheduled && podStatus.Status == corev1.ConditionFalse && podStatus.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPodEvicted returns true, along with a message explaining why, if the Pod
+// failed because it was evicted, preempted or lost along with its node rather
+// than because of one of its steps.
+func IsPodEvicted(pod *corev1.Pod) (bool, string) {
+	if pod.Status.Phase != corev1.PodFailed {
+		return false, ""
+	}
+	if podEvictionReasons[pod.Status.Reason] {
+		return true, fmt.Sprintf("pod %q was terminated with reason %q: %s", pod.Name, pod.Status.Reason, pod.Status.Message)
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == podDisruptionTarget && cond.Status == corev1.ConditionTrue {
+			return true, fmt.Sprintf("pod %q was terminated with reason %q: %s", pod.Name, cond.Reason, cond.Message)
+		}
+	}
+	return false, ""
+}
+
 // isPodHitConfigError returns true if the Pod's status undicates there are config error raised
 func isPodHitConfigError(pod *corev1.Pod) bool {
 	for _, containerStatus := range pod.Status.ContainerStatuses {
@@ -419,8 +623,8 @@ func isPodHitConfigError(pod *corev1.Pod) bool {
 	return false
 }
 
-// isPullImageError returns true if the Pod's status indicates there are any error when pulling image
-func isPullImageError(pod *corev1.Pod) bool {
+// IsPullImageError returns true if the Pod's status indicates there are any error when pulling image
+func IsPullImageError(pod *corev1.Pod) bool {
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		if containerStatus.State.Waiting != nil && isImageErrorReason(containerStatus.State.Waiting.Reason) {
 			return true
@@ -447,7 +651,8 @@ func isImageErrorReason(reason string) bool {
 	return false
 }
 
-func getWaitingMessage(pod *corev1.Pod) string {
+// GetWaitingMessage returns a message explaining why the Pod has not started running yet
+func GetWaitingMessage(pod *corev1.Pod) string {
 	// First, try to surface reason for pending/unknown about the actual build step.
 	for _, status := range pod.Status.ContainerStatuses {
 		wait := status.State.Waiting
@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -80,7 +81,7 @@ func TestSetTaskRunStatusBasedOnStepStatus(t *testing.T) {
 			}
 
 			logger, _ := logging.NewLogger("", "status")
-			merr := setTaskRunStatusBasedOnStepStatus(logger, c.ContainerStatuses, &tr)
+			merr := setTaskRunStatusBasedOnStepStatus(logger, c.ContainerStatuses, &tr, nil)
 			if merr != nil {
 				t.Errorf("setTaskRunStatusBasedOnStepStatus: %s", merr)
 			}
@@ -994,6 +995,74 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "hermetic steps are recorded in the provenance",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-git-source-repo",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_RESOURCE_NAME", Value: "repo"},
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+					},
+				}, {
+					Name: "step-build",
+					Env:  []corev1.EnvVar{{Name: "TEKTON_HERMETIC", Value: "1"}},
+				}, {
+					Name: "step-push",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-git-source-repo",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}, {
+					Name: "step-build",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}, {
+					Name: "step-push",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusRunning(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "git-source-repo",
+					ContainerName: "step-git-source-repo",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "build",
+					ContainerName: "step-build",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "push",
+					ContainerName: "step-push",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				Provenance: &v1beta1.Provenance{
+					HermeticSteps: []string{"build"},
+				},
+			},
+		},
 	}, {
 		desc: "when pod is pending because of pulling image then the error should bubble up to taskrun status",
 		pod: corev1.Pod{
@@ -1301,6 +1370,245 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 
 }
 
+func TestMakeTaskRunStatusStepTiming(t *testing.T) {
+	initialized := time.Date(2022, 1, 1, 1, 0, 0, 0, time.UTC)
+	at := func(seconds float64) metav1.Time {
+		return metav1.NewTime(initialized.Add(time.Duration(seconds * float64(time.Second))))
+	}
+	startedAtMessage := func(seconds float64) string {
+		return fmt.Sprintf(`[{"key":"StartedAt","value":%q,"type":3}]`, at(seconds).Format(timeFormat))
+	}
+	duration := func(seconds float64) *metav1.Duration {
+		return &metav1.Duration{Duration: time.Duration(seconds * float64(time.Second))}
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "step-one"}, {Name: "step-two"}, {Name: "step-three"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodInitialized,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: at(0),
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					StartedAt:  at(10),
+					FinishedAt: at(20),
+					Message:    startedAtMessage(10.5),
+				}},
+			}, {
+				Name: "step-two",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					StartedAt:  at(12),
+					FinishedAt: at(30),
+					Message:    startedAtMessage(20.25),
+				}},
+			}, {
+				Name:  "step-three",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: at(15)}},
+			}},
+		},
+	}
+	tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(logger, tr, pod)
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %s", err)
+	}
+
+	want := map[string]*v1beta1.StepTiming{
+		"one": {Startup: duration(10), Waiting: duration(0.5), Execution: duration(9.5)},
+		"two": {Startup: duration(2), Waiting: duration(8.25), Execution: duration(9.75)},
+		// The command of a running step hasn't reported its start time yet
+		"three": {Startup: duration(3)},
+	}
+	timings := map[string]*v1beta1.StepTiming{}
+	for _, step := range got.Steps {
+		timings[step.Name] = step.Timing
+	}
+	if d := cmp.Diff(want, timings); d != "" {
+		t.Errorf("Wrong step timings %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStepImagePullTimes(t *testing.T) {
+	at := func(seconds int) metav1.Time {
+		return metav1.NewTime(time.Date(2022, 1, 1, 1, 0, seconds, 0, time.UTC))
+	}
+	event := func(container, reason, message string, seconds int) corev1.Event {
+		return corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "pod", FieldPath: fmt.Sprintf("spec.containers{%s}", container)},
+			Reason:         reason,
+			Message:        message,
+			LastTimestamp:  at(seconds),
+		}
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"}}
+	events := []corev1.Event{
+		event("step-one", "Pulling", `Pulling image "busybox"`, 1),
+		event("step-one", "Pulled", `Successfully pulled image "busybox" in 2.5s (2.5s including waiting)`, 4),
+		event("step-two", "Pulled", `Container image "alpine" already present on machine`, 5),
+		// The duration is computed from the events when it isn't in the message
+		event("step-three", "Pulling", `Pulling image "golang"`, 5),
+		event("step-three", "Pulled", `Successfully pulled image "golang"`, 12),
+		// Images still being pulled, and events of other objects, are ignored
+		event("step-four", "Pulling", `Pulling image "node"`, 12),
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "other-pod", FieldPath: "spec.containers{step-five}"},
+			Reason:         "Pulled",
+			Message:        `Container image "alpine" already present on machine`,
+		},
+	}
+
+	want := map[string]*metav1.Duration{
+		"step-one":   {Duration: 2500 * time.Millisecond},
+		"step-two":   {},
+		"step-three": {Duration: 7 * time.Second},
+	}
+	if d := cmp.Diff(want, StepImagePullTimes(pod, events)); d != "" {
+		t.Errorf("Wrong image pull times %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMakeTaskRunStatusStepLogs(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		archiver corev1.ContainerStatus
+		want     map[string][]string
+	}{{
+		desc: "running archiver",
+		archiver: corev1.ContainerStatus{
+			Name:  logArchiverContainerName,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		},
+		// The logs aren't recorded before the archiver reports it archived them
+		want: map[string][]string{"one": {"", ""}, "two": {"", ""}},
+	}, {
+		desc: "terminated archiver",
+		archiver: corev1.ContainerStatus{
+			Name: logArchiverContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: `[{"step":"one"},{"step":"two","error":"error archiving the logs of step \"two\": AccessDenied"}]`,
+			}},
+		},
+		want: map[string][]string{
+			"one": {"s3://tekton-logs/foo/pod/one.log", ""},
+			"two": {"", `error archiving the logs of step "two": AccessDenied`},
+		},
+	}, {
+		desc: "archiver stopped with the nop image",
+		archiver: corev1.ContainerStatus{
+			Name: logArchiverContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: "Build successful",
+			}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: `[{"step":"one"}]`,
+			}},
+		},
+		want: map[string][]string{
+			"one": {"s3://tekton-logs/foo/pod/one.log", ""},
+			"two": {"", "the logs archiver exited before archiving the logs of the step"},
+		},
+	}, {
+		desc: "archiver killed before reporting",
+		archiver: corev1.ContainerStatus{
+			Name:  logArchiverContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}},
+		},
+		want: map[string][]string{
+			"one": {"", `the logs archiver exited without reporting the logs it archived: invalid results of the logs archiver "": unexpected end of JSON input`},
+			"two": {"", `the logs archiver exited without reporting the logs it archived: invalid results of the logs archiver "": unexpected end of JSON input`},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-one"}, {Name: "step-two"}, {
+						Name:    logArchiverContainerName,
+						Command: []string{"/ko-app/entrypoint", "archive-logs", "s3://tekton-logs/foo/pod", "one", "two"},
+					}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-one",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+					}, {
+						Name:  "step-two",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+					}, c.archiver},
+				},
+			}
+			tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(logger, tr, pod)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %s", err)
+			}
+
+			logs := map[string][]string{}
+			for _, step := range got.Steps {
+				logs[step.Name] = []string{step.Logs, step.LogsError}
+			}
+			if d := cmp.Diff(c.want, logs); d != "" {
+				t.Errorf("Wrong step logs %s", diff.PrintWantGot(d))
+			}
+			if len(got.Sidecars) != 0 {
+				t.Errorf("Expected the log archiver not to be reported as a sidecar, got %v", got.Sidecars)
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusStepLogsNotArchived(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod",
+			Namespace:   "foo",
+			Annotations: map[string]string{logsArchiveErrorAnnotation: `the logs archive PersistentVolumeClaim "logs" does not exist in namespace "foo"`},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "step-one"}, {Name: "step-two"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-one",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:  "step-two",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(logger, tr, pod)
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %s", err)
+	}
+
+	logs := map[string][]string{}
+	for _, step := range got.Steps {
+		logs[step.Name] = []string{step.Logs, step.LogsError}
+	}
+	// The error is reported once each step completes
+	want := map[string][]string{
+		"one": {"", `the logs of the step were not archived: the logs archive PersistentVolumeClaim "logs" does not exist in namespace "foo"`},
+		"two": {"", ""},
+	}
+	if d := cmp.Diff(want, logs); d != "" {
+		t.Errorf("Wrong step logs %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestMakeRunStatusJSONError(t *testing.T) {
 
 	pod := &corev1.Pod{
@@ -1503,6 +1811,125 @@ func TestSidecarsReady(t *testing.T) {
 	}
 }
 
+func TestIsPodUnschedulable(t *testing.T) {
+	for _, c := range []struct {
+		desc       string
+		conditions []corev1.PodCondition
+		want       bool
+	}{{
+		desc: "pod scheduled",
+		conditions: []corev1.PodCondition{{
+			Type:   corev1.PodScheduled,
+			Status: corev1.ConditionTrue,
+		}},
+	}, {
+		desc: "pod not scheduled yet",
+		conditions: []corev1.PodCondition{{
+			Type:   corev1.PodScheduled,
+			Status: corev1.ConditionFalse,
+		}},
+	}, {
+		desc: "pod unschedulable",
+		conditions: []corev1.PodCondition{{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  corev1.PodReasonUnschedulable,
+			Message: "0/1 nodes are available: 1 node(s) didn't match Pod's node affinity/selector.",
+		}},
+		want: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodPending,
+					Conditions: c.conditions,
+				},
+			}
+			if got := IsPodUnschedulable(pod); got != c.want {
+				t.Errorf("IsPodUnschedulable got %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsPodEvicted(t *testing.T) {
+	for _, c := range []struct {
+		desc        string
+		podStatus   corev1.PodStatus
+		want        bool
+		wantMessage string
+	}{{
+		desc: "running pod",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}, {
+		desc: "pod failed because of a step",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-foo",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+				},
+			}},
+		},
+	}, {
+		desc: "pod evicted by the kubelet",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		want:        true,
+		wantMessage: `pod "pod" was terminated with reason "Evicted": The node was low on resource: memory.`,
+	}, {
+		desc: "pod preempted by the scheduler",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			Conditions: []corev1.PodCondition{{
+				Type:    "DisruptionTarget",
+				Status:  corev1.ConditionTrue,
+				Reason:  "PreemptionByKubeScheduler",
+				Message: "Kube-scheduler: preempting to accommodate a higher priority pod",
+			}},
+		},
+		want:        true,
+		wantMessage: `pod "pod" was terminated with reason "PreemptionByKubeScheduler": Kube-scheduler: preempting to accommodate a higher priority pod`,
+	}, {
+		desc: "pod lost along with its node",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "NodeLost",
+			Message: "Node node-1 which was running pod pod is unresponsive",
+		},
+		want:        true,
+		wantMessage: `pod "pod" was terminated with reason "NodeLost": Node node-1 which was running pod pod is unresponsive`,
+	}, {
+		desc: "pod past its active deadline",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "DeadlineExceeded",
+			Message: "Pod was active on the node longer than the specified deadline",
+		},
+		want:        true,
+		wantMessage: `pod "pod" was terminated with reason "DeadlineExceeded": Pod was active on the node longer than the specified deadline`,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, gotMessage := IsPodEvicted(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Status:     c.podStatus,
+			})
+			if got != c.want {
+				t.Errorf("IsPodEvicted got %t, want %t", got, c.want)
+			}
+			if gotMessage != c.wantMessage {
+				t.Errorf("IsPodEvicted got message %q, want %q", gotMessage, c.wantMessage)
+			}
+		})
+	}
+}
+
 func TestMarkStatusRunning(t *testing.T) {
 	trs := v1beta1.TaskRunStatus{}
 	markStatusRunning(&trs, v1beta1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
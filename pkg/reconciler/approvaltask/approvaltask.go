@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvaltask
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	runreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/run"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const (
+	// ReasonAwaitingApproval indicates that the ApprovalTask is waiting for approvals
+	ReasonAwaitingApproval = "ApprovalTaskPending"
+	// ReasonApproved indicates that enough approvers approved the ApprovalTask
+	ReasonApproved = "ApprovalTaskApproved"
+	// ReasonRejected indicates that an approver rejected the ApprovalTask
+	ReasonRejected = "ApprovalTaskRejected"
+	// ReasonInvalidParams indicates that the params of the Run do not describe a valid ApprovalTask
+	ReasonInvalidParams = "ApprovalTaskInvalidParams"
+	// ReasonMismatch indicates that the ApprovalTask named after the Run was not created for
+	// it, or does not match its params
+	ReasonMismatch = "ApprovalTaskMismatch"
+
+	// ParamApprovers is the name of the array param listing the approvers. Groups are
+	// prefixed with "group:".
+	ParamApprovers = "approvers"
+	// ParamNumberOfApprovalsRequired is the name of the param holding the number of approvals
+	// required. Defaults to 1.
+	ParamNumberOfApprovalsRequired = "numberOfApprovalsRequired"
+	// ParamDescription is the name of the param holding the description shown to the approvers.
+	ParamDescription = "description"
+
+	// ResultApprovedBy is the name of the result holding the comma separated list of the users
+	// who approved the ApprovalTask.
+	ResultApprovedBy = "approvedBy"
+	// ResultRejectedBy is the name of the result holding the user who rejected the ApprovalTask.
+	ResultRejectedBy = "rejectedBy"
+
+	groupPrefix = "group:"
+)
+
+// Reconciler implements controller.Reconciler for the Runs referencing ApprovalTasks.
+type Reconciler struct {
+	PipelineClientSet clientset.Interface
+	Clock             clock.PassiveClock
+
+	approvalTaskLister listers.ApprovalTaskLister
+}
+
+// Check that our Reconciler implements runreconciler.Interface
+var _ runreconciler.Interface = (*Reconciler)(nil)
+
+// isApprovalTaskRun returns true if the object is a Run referencing the ApprovalTask kind.
+func isApprovalTaskRun(obj interface{}) bool {
+	run, ok := obj.(*v1alpha1.Run)
+	return ok && run.Spec.Ref != nil &&
+		run.Spec.Ref.APIVersion == v1alpha1.SchemeGroupVersion.String() &&
+		run.Spec.Ref.Kind == pipeline.ApprovalTaskControllerName
+}
+
+// ReconcileKind creates the ApprovalTask of a Run and updates the status of the Run
+// according to the inputs of the approvers.
+func (c *Reconciler) ReconcileKind(ctx context.Context, run *v1alpha1.Run) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+	if !isApprovalTaskRun(run) || run.IsDone() {
+		return nil
+	}
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields != config.AlphaAPIFields {
+		logger.Infof("Ignoring Run %s/%s: ApprovalTasks require \"enable-api-fields\" to be \"alpha\"", run.Namespace, run.Name)
+		return nil
+	}
+
+	if !run.HasStarted() {
+		run.Status.StartTime = &metav1.Time{Time: c.Clock.Now()}
+		run.Status.InitializeConditions()
+	}
+	if run.IsCancelled() {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonCancelled, "Run %q was cancelled. %s", run.Name, run.Spec.StatusMessage)
+		return nil
+	}
+	if run.HasTimedOut(c.Clock) {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonTimedOut, "Run %q was not approved within %s", run.Name, run.GetTimeout())
+		return nil
+	}
+
+	at, err := c.getOrCreateApprovalTask(ctx, run)
+	if err != nil {
+		if controller.IsPermanentError(err) {
+			run.Status.MarkRunFailed(ReasonInvalidParams, "Run %q does not describe a valid ApprovalTask: %v", run.Name, err)
+			return nil
+		}
+		return err
+	}
+	if err := checkApprovalTask(run, at); err != nil {
+		run.Status.MarkRunFailed(ReasonMismatch, "Run %q cannot use ApprovalTask %q: %v", run.Name, at.Name, err)
+		return nil
+	}
+
+	status := at.Tally()
+	if !equality.Semantic.DeepEqual(status, at.Status) {
+		at = at.DeepCopy()
+		at.Status = status
+		if _, err := c.PipelineClientSet.TektonV1alpha1().ApprovalTasks(at.Namespace).UpdateStatus(ctx, at, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update the status of ApprovalTask %s: %w", at.Name, err)
+		}
+	}
+
+	switch status.State {
+	case v1alpha1.ApprovalStateApproved:
+		run.Status.Results = []v1alpha1.RunResult{{Name: ResultApprovedBy, Value: strings.Join(status.ApprovedBy, ",")}}
+		run.Status.MarkRunSucceeded(ReasonApproved, "ApprovalTask %q was approved by %s", at.Name, strings.Join(status.ApprovedBy, ", "))
+	case v1alpha1.ApprovalStateRejected:
+		run.Status.Results = []v1alpha1.RunResult{
+			{Name: ResultApprovedBy, Value: strings.Join(status.ApprovedBy, ",")},
+			{Name: ResultRejectedBy, Value: status.RejectedBy},
+		}
+		run.Status.MarkRunFailed(ReasonRejected, "ApprovalTask %q was rejected by %s", at.Name, status.RejectedBy)
+	default:
+		run.Status.MarkRunRunning(ReasonAwaitingApproval, "ApprovalTask %q is waiting for %d more approval(s)",
+			at.Name, at.Spec.NumberOfApprovalsRequired-len(status.ApprovedBy))
+		if timeout := run.GetTimeout(); timeout != config.NoTimeoutDuration {
+			return controller.NewRequeueAfter(timeout - c.Clock.Since(run.Status.StartTime.Time))
+		}
+	}
+	return nil
+}
+
+// getOrCreateApprovalTask returns the ApprovalTask of the Run, creating it from the params
+// of the Run if it does not exist yet.
+func (c *Reconciler) getOrCreateApprovalTask(ctx context.Context, run *v1alpha1.Run) (*v1alpha1.ApprovalTask, error) {
+	at, err := c.approvalTaskLister.ApprovalTasks(run.Namespace).Get(run.Name)
+	if err == nil {
+		return at, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get ApprovalTask %s: %w", run.Name, err)
+	}
+
+	at, err = approvalTaskFromRun(run)
+	if err != nil {
+		return nil, controller.NewPermanentError(err)
+	}
+	at.SetDefaults(ctx)
+	if err := at.Spec.Validate(ctx); err != nil {
+		return nil, controller.NewPermanentError(err)
+	}
+	logging.FromContext(ctx).Infof("Creating ApprovalTask %s/%s", at.Namespace, at.Name)
+	return c.PipelineClientSet.TektonV1alpha1().ApprovalTasks(run.Namespace).Create(ctx, at, metav1.CreateOptions{})
+}
+
+// checkApprovalTask returns an error if the ApprovalTask named after the Run is not
+// controlled by it, e.g. because it was created ahead of the Run or recreated since, or
+// if its approvers or the number of approvals it requires differ from the params of the
+// Run, so that an ApprovalTask the Run did not create can't approve it.
+func checkApprovalTask(run *v1alpha1.Run, at *v1alpha1.ApprovalTask) error {
+	if !metav1.IsControlledBy(at, run) {
+		return fmt.Errorf("the ApprovalTask is not controlled by the Run")
+	}
+	want, err := approvalTaskFromRun(run)
+	if err != nil {
+		return err
+	}
+	if at.Spec.NumberOfApprovalsRequired != want.Spec.NumberOfApprovalsRequired {
+		return fmt.Errorf("the ApprovalTask requires %d approval(s) instead of %d", at.Spec.NumberOfApprovalsRequired, want.Spec.NumberOfApprovalsRequired)
+	}
+	if len(at.Spec.Approvers) != len(want.Spec.Approvers) {
+		return fmt.Errorf("the ApprovalTask has %d approver(s) instead of %d", len(at.Spec.Approvers), len(want.Spec.Approvers))
+	}
+	for i, approver := range at.Spec.Approvers {
+		if approver.Name != want.Spec.Approvers[i].Name || approver.Type != want.Spec.Approvers[i].Type {
+			return fmt.Errorf("approver %d of the ApprovalTask is %s %q instead of %s %q", i, approver.Type, approver.Name,
+				want.Spec.Approvers[i].Type, want.Spec.Approvers[i].Name)
+		}
+	}
+	return nil
+}
+
+// approvalTaskFromRun builds the ApprovalTask described by the params of the Run.
+func approvalTaskFromRun(run *v1alpha1.Run) (*v1alpha1.ApprovalTask, error) {
+	labels := make(map[string]string, len(run.Labels)+1)
+	for k, v := range run.Labels {
+		labels[k] = v
+	}
+	labels[pipeline.RunKey] = run.Name
+	at := &v1alpha1.ApprovalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            run.Name,
+			Namespace:       run.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(run)},
+		},
+		Spec: v1alpha1.ApprovalTaskSpec{NumberOfApprovalsRequired: 1},
+	}
+
+	approvers := run.Spec.GetParam(ParamApprovers)
+	if approvers == nil || approvers.Value.Type != v1beta1.ParamTypeArray {
+		return nil, fmt.Errorf("param %q of type array is required", ParamApprovers)
+	}
+	for _, name := range approvers.Value.ArrayVal {
+		approver := v1alpha1.ApproverDetails{Name: name, Type: v1alpha1.ApproverTypeUser}
+		if strings.HasPrefix(name, groupPrefix) {
+			approver.Name = strings.TrimPrefix(name, groupPrefix)
+			approver.Type = v1alpha1.ApproverTypeGroup
+		}
+		at.Spec.Approvers = append(at.Spec.Approvers, approver)
+	}
+	if p := run.Spec.GetParam(ParamNumberOfApprovalsRequired); p != nil {
+		n, err := strconv.Atoi(p.Value.StringVal)
+		if err != nil {
+			return nil, fmt.Errorf("param %q must be an integer: %w", ParamNumberOfApprovalsRequired, err)
+		}
+		at.Spec.NumberOfApprovalsRequired = n
+	}
+	if p := run.Spec.GetParam(ParamDescription); p != nil {
+		at.Spec.Description = p.Value.StringVal
+	}
+	return at, nil
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvaltask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	cminformer "knative.dev/pkg/configmap/informer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+var (
+	now       = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testClock = clock.NewFakePassiveClock(now)
+
+	alphaFeatureFlags = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": config.AlphaAPIFields},
+	}
+)
+
+func initializeApprovalTaskControllerAssets(t *testing.T, d test.Data) (test.Assets, func()) {
+	t.Helper()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	test.EnsureConfigurationConfigMapsExist(&d)
+	c, informers := test.SeedTestData(t, ctx, d)
+	configMapWatcher := cminformer.NewInformedWatcher(c.Kube, system.Namespace())
+	ctl := NewController(testClock)(ctx, configMapWatcher)
+	if err := configMapWatcher.Start(ctx.Done()); err != nil {
+		t.Fatalf("error starting configmap watcher: %v", err)
+	}
+
+	if la, ok := ctl.Reconciler.(pkgreconciler.LeaderAware); ok {
+		la.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {})
+	}
+
+	return test.Assets{
+		Logger:     logging.FromContext(ctx),
+		Controller: ctl,
+		Clients:    c,
+		Informers:  informers,
+		Ctx:        ctx,
+	}, cancel
+}
+
+func approvalRun(params ...v1beta1.Param) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deploy-approval",
+			Namespace: "foo",
+			UID:       "deploy-approval-uid",
+			Labels:    map[string]string{pipeline.PipelineRunLabelKey: "deploy"},
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1beta1.TaskRef{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       pipeline.ApprovalTaskControllerName,
+			},
+			Params:  params,
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+func startedAt(run *v1alpha1.Run, start time.Time) *v1alpha1.Run {
+	run.Status.StartTime = &metav1.Time{Time: start}
+	run.Status.InitializeConditions()
+	return run
+}
+
+// decided returns the ApprovalTask created for the Run, requiring 2 approvals, with the
+// given approvers.
+func decided(approvers ...v1alpha1.ApproverDetails) *v1alpha1.ApprovalTask {
+	return &v1alpha1.ApprovalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "deploy-approval",
+			Namespace:       "foo",
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(approvalRun())},
+		},
+		Spec: v1alpha1.ApprovalTaskSpec{
+			Approvers:                 approvers,
+			NumberOfApprovalsRequired: 2,
+		},
+	}
+}
+
+var (
+	approversParam = v1beta1.Param{
+		Name:  ParamApprovers,
+		Value: *v1beta1.NewStructuredValues("alice", "group:release-managers"),
+	}
+	twoApprovalsParam = v1beta1.Param{
+		Name:  ParamNumberOfApprovalsRequired,
+		Value: *v1beta1.NewStructuredValues("2"),
+	}
+	approvedByAlice = v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputApprove, DecidedBy: "alice"}
+	approvedByBob   = v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputApprove, DecidedBy: "bob"}
+)
+
+func TestReconcile_CreatesApprovalTask(t *testing.T) {
+	run := approvalRun(approversParam,
+		v1beta1.Param{Name: ParamNumberOfApprovalsRequired, Value: *v1beta1.NewStructuredValues("2")},
+		v1beta1.Param{Name: ParamDescription, Value: *v1beta1.NewStructuredValues("Deploy to production?")},
+	)
+	testAssets, cancel := initializeApprovalTaskControllerAssets(t, test.Data{
+		Runs:       []*v1alpha1.Run{run},
+		ConfigMaps: []*corev1.ConfigMap{alphaFeatureFlags},
+	})
+	defer cancel()
+	clients := testAssets.Clients
+
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/deploy-approval")
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay != time.Hour {
+		t.Fatalf("Expected the Run to be requeued after its timeout, got %v", err)
+	}
+
+	at, err := clients.Pipeline.TektonV1alpha1().ApprovalTasks("foo").Get(testAssets.Ctx, "deploy-approval", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the ApprovalTask: %v", err)
+	}
+	wantSpec := v1alpha1.ApprovalTaskSpec{
+		Approvers: []v1alpha1.ApproverDetails{
+			{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputPending},
+			{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputPending},
+		},
+		NumberOfApprovalsRequired: 2,
+		Description:               "Deploy to production?",
+	}
+	if d := cmp.Diff(wantSpec, at.Spec); d != "" {
+		t.Errorf("Unexpected ApprovalTask spec %s", diff.PrintWantGot(d))
+	}
+	wantLabels := map[string]string{pipeline.PipelineRunLabelKey: "deploy", pipeline.RunKey: "deploy-approval"}
+	if d := cmp.Diff(wantLabels, at.Labels); d != "" {
+		t.Errorf("Unexpected ApprovalTask labels %s", diff.PrintWantGot(d))
+	}
+	if len(at.OwnerReferences) != 1 || at.OwnerReferences[0].Kind != "Run" {
+		t.Errorf("Expected the ApprovalTask to be owned by the Run, got %v", at.OwnerReferences)
+	}
+
+	reconciledRun, err := clients.Pipeline.TektonV1alpha1().Runs("foo").Get(testAssets.Ctx, "deploy-approval", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the Run: %v", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != ReasonAwaitingApproval {
+		t.Errorf("Expected the Run to be awaiting approval, got %v", condition)
+	}
+}
+
+func TestReconcile_Decisions(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		run         *v1alpha1.Run
+		at          *v1alpha1.ApprovalTask
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantResults []v1alpha1.RunResult
+		wantState   v1alpha1.ApprovalState
+	}{{
+		name: "approved",
+		run:  startedAt(approvalRun(approversParam, twoApprovalsParam), now),
+		at: decided(
+			v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputApprove},
+			v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputApprove, DecidedBy: "bob"},
+		),
+		wantStatus:  corev1.ConditionTrue,
+		wantReason:  ReasonApproved,
+		wantResults: []v1alpha1.RunResult{{Name: ResultApprovedBy, Value: "alice,bob"}},
+		wantState:   v1alpha1.ApprovalStateApproved,
+	}, {
+		name: "rejected",
+		run:  startedAt(approvalRun(approversParam, twoApprovalsParam), now),
+		at: decided(
+			v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputApprove},
+			v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputReject, DecidedBy: "bob"},
+		),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonRejected,
+		wantResults: []v1alpha1.RunResult{
+			{Name: ResultApprovedBy, Value: "alice"},
+			{Name: ResultRejectedBy, Value: "bob"},
+		},
+		wantState: v1alpha1.ApprovalStateRejected,
+	}, {
+		name: "timed out",
+		run:  startedAt(approvalRun(approversParam, twoApprovalsParam), now.Add(-2*time.Hour)),
+		at: decided(
+			v1alpha1.ApproverDetails{Name: "alice", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputApprove},
+			v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputPending},
+		),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.RunReasonTimedOut,
+	}, {
+		name:       "invalid params",
+		run:        approvalRun(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonInvalidParams,
+	}, {
+		name: "approval task not controlled by the run",
+		run:  startedAt(approvalRun(approversParam, twoApprovalsParam), now),
+		at: func() *v1alpha1.ApprovalTask {
+			at := decided(approvedByAlice, approvedByBob)
+			at.OwnerReferences = nil
+			return at
+		}(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonMismatch,
+	}, {
+		name: "approval task requiring fewer approvals",
+		run:  startedAt(approvalRun(approversParam, twoApprovalsParam), now),
+		at: func() *v1alpha1.ApprovalTask {
+			at := decided(approvedByAlice, v1alpha1.ApproverDetails{Name: "release-managers", Type: v1alpha1.ApproverTypeGroup, Input: v1alpha1.ApproverInputPending})
+			at.Spec.NumberOfApprovalsRequired = 1
+			return at
+		}(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonMismatch,
+	}, {
+		name:       "approval task with other approvers",
+		run:        startedAt(approvalRun(approversParam, twoApprovalsParam), now),
+		at:         decided(approvedByAlice, v1alpha1.ApproverDetails{Name: "mallory", Type: v1alpha1.ApproverTypeUser, Input: v1alpha1.ApproverInputApprove, DecidedBy: "mallory"}),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: ReasonMismatch,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				Runs:       []*v1alpha1.Run{tc.run},
+				ConfigMaps: []*corev1.ConfigMap{alphaFeatureFlags},
+			}
+			if tc.at != nil {
+				d.ApprovalTasks = []*v1alpha1.ApprovalTask{tc.at}
+			}
+			testAssets, cancel := initializeApprovalTaskControllerAssets(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/deploy-approval"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			reconciledRun, err := clients.Pipeline.TektonV1alpha1().Runs("foo").Get(testAssets.Ctx, "deploy-approval", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get the Run: %v", err)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("Expected condition %s with reason %s, got %v", tc.wantStatus, tc.wantReason, condition)
+			}
+			if d := cmp.Diff(tc.wantResults, reconciledRun.Status.Results, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Unexpected results %s", diff.PrintWantGot(d))
+			}
+
+			if tc.wantState != "" {
+				at, err := clients.Pipeline.TektonV1alpha1().ApprovalTasks("foo").Get(testAssets.Ctx, "deploy-approval", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Failed to get the ApprovalTask: %v", err)
+				}
+				if at.Status.State != tc.wantState {
+					t.Errorf("Expected ApprovalTask state %s, got %s", tc.wantState, at.Status.State)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcile_AlphaDisabled(t *testing.T) {
+	testAssets, cancel := initializeApprovalTaskControllerAssets(t, test.Data{
+		Runs: []*v1alpha1.Run{approvalRun(approversParam)},
+	})
+	defer cancel()
+	clients := testAssets.Clients
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, "foo/deploy-approval"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ats, err := clients.Pipeline.TektonV1alpha1().ApprovalTasks("foo").List(testAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list ApprovalTasks: %v", err)
+	}
+	if len(ats.Items) != 0 {
+		t.Errorf("Expected no ApprovalTask to be created, got %d", len(ats.Items))
+	}
+}
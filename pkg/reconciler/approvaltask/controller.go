@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvaltask
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	approvaltaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/approvaltask"
+	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
+	runreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/run"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewController instantiates a new controller.Impl from knative.dev/pkg/controller
+func NewController(clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		runInformer := runinformer.Get(ctx)
+		approvalTaskInformer := approvaltaskinformer.Get(ctx)
+
+		configStore := config.NewStore(logger.Named("config-store"))
+		configStore.WatchConfigs(cmw)
+
+		c := &Reconciler{
+			PipelineClientSet:  pipelineclient.Get(ctx),
+			Clock:              clock,
+			approvalTaskLister: approvalTaskInformer.Lister(),
+		}
+		impl := runreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         pipeline.ApprovalTaskControllerName,
+				ConfigStore:       configStore,
+				PromoteFilterFunc: isApprovalTaskRun,
+			}
+		})
+
+		runInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: isApprovalTaskRun,
+			Handler:    controller.HandleAll(impl.Enqueue),
+		})
+		approvalTaskInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1alpha1.Run{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
+
+		return impl
+	}
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package approvaltask provides the built-in manual approval gate. It reconciles
+the Runs referencing the ApprovalTask kind: for each of them it creates an
+ApprovalTask listing the approvers given as parameters, and completes the Run
+once enough approvers have approved it, or fails it as soon as one of them
+rejects it or the Run times out.
+*/
+package approvaltask
@@ -29,10 +29,16 @@ import (
 // Struct to unmarshal the event data
 type eventData struct {
 	Run *v1alpha1.Run `json:"run,omitempty"`
+	// Subject identifies the Run in CDEvents, by its name and namespace
+	Subject *struct {
+		ID     string `json:"id"`
+		Source string `json:"source"`
+	} `json:"subject,omitempty"`
 }
 
-// ContainsOrAddCloudEvent checks if the event exists in the cache
-func ContainsOrAddCloudEvent(cacheClient *lru.Cache, event *cloudevents.Event) (bool, error) {
+// ContainsOrAddCloudEvent checks if the event exists in the cache of the events
+// sent to the given target
+func ContainsOrAddCloudEvent(cacheClient *lru.Cache, event *cloudevents.Event, target string) (bool, error) {
 	if cacheClient == nil {
 		return false, errors.New("cache client is nil")
 	}
@@ -40,7 +46,7 @@ func ContainsOrAddCloudEvent(cacheClient *lru.Cache, event *cloudevents.Event) (
 	if err != nil {
 		return false, err
 	}
-	isPresent, _ := cacheClient.ContainsOrAdd(eventKey, nil)
+	isPresent, _ := cacheClient.ContainsOrAdd(target+"|"+eventKey, nil)
 	return isPresent, nil
 }
 
@@ -56,11 +62,16 @@ func EventKey(event *cloudevents.Event) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if data.Run == nil {
+	switch {
+	case data.Run != nil:
+		resourceName = data.Run.Name
+		resourceNamespace = data.Run.Namespace
+	case data.Subject != nil && data.Subject.ID != "":
+		resourceName = data.Subject.ID
+		resourceNamespace = data.Subject.Source
+	default:
 		return "", fmt.Errorf("Invalid Run data in %v", event)
 	}
-	resourceName = data.Run.Name
-	resourceNamespace = data.Run.Namespace
 	eventType := event.Type()
 	return fmt.Sprintf("%s/run/%s/%s", eventType, resourceNamespace, resourceName), nil
 }
@@ -36,8 +36,15 @@ func strptr(s string) *string { return &s }
 
 func getEventData(run interface{}) map[string]interface{} {
 	cloudEventData := map[string]interface{}{}
-	if v, ok := run.(*v1alpha1.Run); ok {
+	switch v := run.(type) {
+	case *v1alpha1.Run:
 		cloudEventData["run"] = v
+	case *metav1.ObjectMeta:
+		cloudEventData["subject"] = map[string]interface{}{
+			"id":     v.Name,
+			"source": v.Namespace,
+			"type":   "taskRun",
+		}
 	}
 	return cloudEventData
 }
@@ -87,6 +94,12 @@ func TestEventsKey(t *testing.T) {
 		run:       getRunByMeta("myrun", "mynamespace"),
 		wantKey:   "my.test.run.event/run/mynamespace/myrun",
 		wantErr:   false,
+	}, {
+		name:      "run cdevent",
+		eventtype: "dev.cdevents.taskrun.started.0.1.0",
+		run:       &metav1.ObjectMeta{Name: "myrun", Namespace: "mynamespace"},
+		wantKey:   "dev.cdevents.taskrun.started.0.1.0/run/mynamespace/myrun",
+		wantErr:   false,
 	}, {
 		name:      "run event missing data",
 		eventtype: "my.test.run.event",
@@ -120,7 +133,9 @@ func TestAddCheckEvent(t *testing.T) {
 		name        string
 		firstEvent  *event.Event
 		secondEvent *event.Event
-		wantFound   bool
+		// secondTarget is the target of the second event, the first one is sent to "http://sink"
+		secondTarget string
+		wantFound    bool
 	}{{
 		name:        "identical events",
 		firstEvent:  baseEvent,
@@ -141,13 +156,23 @@ func TestAddCheckEvent(t *testing.T) {
 		firstEvent:  baseEvent,
 		secondEvent: getEventToTest("some.other.event.type", run),
 		wantFound:   false,
+	}, {
+		name:         "different target",
+		firstEvent:   baseEvent,
+		secondEvent:  baseEvent,
+		secondTarget: "http://other-sink",
+		wantFound:    false,
 	}}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			testCache, _ := lru.New(10)
-			_, _ = ContainsOrAddCloudEvent(testCache, tc.firstEvent)
-			found, _ := ContainsOrAddCloudEvent(testCache, tc.secondEvent)
+			secondTarget := "http://sink"
+			if tc.secondTarget != "" {
+				secondTarget = tc.secondTarget
+			}
+			_, _ = ContainsOrAddCloudEvent(testCache, tc.firstEvent, "http://sink")
+			found, _ := ContainsOrAddCloudEvent(testCache, tc.secondEvent, secondTarget)
 			if d := cmp.Diff(tc.wantFound, found); d != "" {
 				t.Errorf("Cache check failure %s", diff.PrintWantGot(d))
 			}
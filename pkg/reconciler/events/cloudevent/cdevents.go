@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"knative.dev/pkg/apis"
+)
+
+// CDEventType holds the types of CDEvents sent by Tekton
+type CDEventType string
+
+const (
+	// CDEventsSpecVersion is the version of the CDEvents specification the events conform to
+	CDEventsSpecVersion = "0.1.0"
+	// cdEventTypePrefix is the prefix of the types of CDEvents
+	cdEventTypePrefix = "dev.cdevents."
+
+	// PipelineRunQueuedCDEventV1 is sent for PipelineRuns which are pending or waiting
+	// to be scheduled
+	PipelineRunQueuedCDEventV1 CDEventType = "dev.cdevents.pipelinerun.queued.0.1.0"
+	// PipelineRunStartedCDEventV1 is sent for PipelineRuns with "ConditionSucceeded" "Unknown"
+	// the first time they are picked up by the reconciler
+	PipelineRunStartedCDEventV1 CDEventType = "dev.cdevents.pipelinerun.started.0.1.0"
+	// PipelineRunFinishedCDEventV1 is sent for PipelineRuns with "ConditionSucceeded" "True"
+	// or "False"
+	PipelineRunFinishedCDEventV1 CDEventType = "dev.cdevents.pipelinerun.finished.0.1.0"
+	// TaskRunStartedCDEventV1 is sent for TaskRuns with "ConditionSucceeded" "Unknown"
+	// the first time they are picked up by the reconciler, and for Runs once they are created
+	TaskRunStartedCDEventV1 CDEventType = "dev.cdevents.taskrun.started.0.1.0"
+	// TaskRunFinishedCDEventV1 is sent for TaskRuns and Runs with "ConditionSucceeded" "True"
+	// or "False"
+	TaskRunFinishedCDEventV1 CDEventType = "dev.cdevents.taskrun.finished.0.1.0"
+
+	// CDEventSubjectTypePipelineRun is the type of the subject of PipelineRun CDEvents
+	CDEventSubjectTypePipelineRun = "pipelineRun"
+	// CDEventSubjectTypeTaskRun is the type of the subject of TaskRun and Run CDEvents
+	CDEventSubjectTypeTaskRun = "taskRun"
+
+	// CDEventOutcomeSuccess is the outcome of runs which succeeded
+	CDEventOutcomeSuccess = "success"
+	// CDEventOutcomeFailure is the outcome of runs which failed
+	CDEventOutcomeFailure = "failure"
+)
+
+func (t CDEventType) String() string {
+	return string(t)
+}
+
+// CDEventData type is used to marshal and unmarshal the payload of a CDEvent
+type CDEventData struct {
+	Context CDEventContext `json:"context"`
+	Subject CDEventSubject `json:"subject"`
+}
+
+// CDEventContext holds the context of a CDEvent, which mirrors the attributes of the
+// cloud event carrying it
+type CDEventContext struct {
+	Version   string    `json:"version"`
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CDEventSubject identifies the run a CDEvent is about. Its ID is the name of the run
+// and its source is the namespace of the run.
+type CDEventSubject struct {
+	ID      string                `json:"id"`
+	Source  string                `json:"source"`
+	Type    string                `json:"type"`
+	Content CDEventSubjectContent `json:"content"`
+}
+
+// CDEventSubjectContent holds the fields of the subject of PipelineRun and TaskRun CDEvents
+type CDEventSubjectContent struct {
+	PipelineName string                   `json:"pipelineName,omitempty"`
+	TaskName     string                   `json:"taskName,omitempty"`
+	URL          string                   `json:"url,omitempty"`
+	PipelineRun  *CDEventSubjectReference `json:"pipelineRun,omitempty"`
+	Outcome      string                   `json:"outcome,omitempty"`
+	Errors       string                   `json:"errors,omitempty"`
+}
+
+// CDEventSubjectReference references the subject of another CDEvent
+type CDEventSubjectReference struct {
+	ID string `json:"id"`
+}
+
+// cdEventForObjectWithCondition creates a new CDEvent for a objectWithCondition, or returns
+// an error if not possible. It returns no event if the status of the object doesn't match
+// any CDEvent type, e.g. for a TaskRun which is still running.
+func cdEventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Event, error) {
+	eventType, err := getCDEventType(runObject)
+	if err != nil {
+		return nil, err
+	}
+	if eventType == nil {
+		return nil, nil
+	}
+	return newCDEvent(runObject, *eventType)
+}
+
+// newCDEvent creates a new CDEvent of the given type for a objectWithCondition.
+func newCDEvent(runObject objectWithCondition, eventType CDEventType) (*cloudevents.Event, error) {
+	meta := runObject.GetObjectMeta()
+	source := sourceForObjectWithCondition(runObject)
+	c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
+	timestamp := time.Now()
+	if c != nil && !c.LastTransitionTime.Inner.IsZero() {
+		timestamp = c.LastTransitionTime.Inner.Time
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetSubject(meta.GetName())
+	event.SetSource(source)
+	event.SetType(eventType.String())
+	event.SetTime(timestamp)
+
+	subject := CDEventSubject{
+		ID:     meta.GetName(),
+		Source: meta.GetNamespace(),
+		Content: CDEventSubjectContent{
+			URL: source,
+		},
+	}
+	switch v := runObject.(type) {
+	case *v1beta1.PipelineRun:
+		subject.Type = CDEventSubjectTypePipelineRun
+		subject.Content.PipelineName = meta.GetLabels()[pipeline.PipelineLabelKey]
+		if subject.Content.PipelineName == "" && v.Spec.PipelineRef != nil {
+			subject.Content.PipelineName = v.Spec.PipelineRef.Name
+		}
+	case *v1beta1.TaskRun:
+		subject.Type = CDEventSubjectTypeTaskRun
+		subject.Content.TaskName = meta.GetLabels()[pipeline.TaskLabelKey]
+		if subject.Content.TaskName == "" && v.Spec.TaskRef != nil {
+			subject.Content.TaskName = v.Spec.TaskRef.Name
+		}
+	case *v1alpha1.Run:
+		subject.Type = CDEventSubjectTypeTaskRun
+		if v.Spec.Ref != nil {
+			subject.Content.TaskName = v.Spec.Ref.Name
+		}
+	}
+	if subject.Type == CDEventSubjectTypeTaskRun {
+		if pipelineRun := meta.GetLabels()[pipeline.PipelineRunLabelKey]; pipelineRun != "" {
+			subject.Content.PipelineRun = &CDEventSubjectReference{ID: pipelineRun}
+		}
+	}
+	if c != nil {
+		switch {
+		case c.IsTrue():
+			subject.Content.Outcome = CDEventOutcomeSuccess
+		case c.IsFalse():
+			subject.Content.Outcome = CDEventOutcomeFailure
+			subject.Content.Errors = c.Message
+		}
+	}
+
+	data := CDEventData{
+		Context: CDEventContext{
+			Version:   CDEventsSpecVersion,
+			ID:        event.ID(),
+			Source:    source,
+			Type:      eventType.String(),
+			Timestamp: timestamp,
+		},
+		Subject: subject,
+	}
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// getCDEventType returns the CDEvent type matching the status of a objectWithCondition,
+// or nil if there is none.
+func getCDEventType(runObject objectWithCondition) (*CDEventType, error) {
+	var eventType CDEventType
+	c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
+	if c == nil {
+		// As for Tekton events, a Run without condition is considered as started
+		if _, ok := runObject.(*v1alpha1.Run); ok {
+			eventType = TaskRunStartedCDEventV1
+			return &eventType, nil
+		}
+		return nil, fmt.Errorf("no condition for ConditionSucceeded in %T", runObject)
+	}
+	switch {
+	case c.IsUnknown():
+		switch runObject.(type) {
+		case *v1beta1.TaskRun:
+			if c.Reason != v1beta1.TaskRunReasonStarted.String() {
+				return nil, nil
+			}
+			eventType = TaskRunStartedCDEventV1
+		case *v1beta1.PipelineRun:
+			switch c.Reason {
+			case v1beta1.PipelineRunReasonStarted.String():
+				eventType = PipelineRunStartedCDEventV1
+			case v1beta1.PipelineRunReasonPending.String():
+				eventType = PipelineRunQueuedCDEventV1
+			default:
+				return nil, nil
+			}
+		case *v1alpha1.Run:
+			// Run controllers may set any reason, events for Runs are deduplicated
+			eventType = TaskRunStartedCDEventV1
+		}
+	case c.IsTrue(), c.IsFalse():
+		switch runObject.(type) {
+		case *v1beta1.PipelineRun:
+			eventType = PipelineRunFinishedCDEventV1
+		default:
+			eventType = TaskRunFinishedCDEventV1
+		}
+	default:
+		return nil, fmt.Errorf("unknown condition for in %T.Status %s", runObject, c.Status)
+	}
+	return &eventType, nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCDEventForObjectWithCondition(t *testing.T) {
+	failedTaskRun := getTaskRunByCondition(corev1.ConditionFalse, "Failed")
+	failedTaskRun.Labels = map[string]string{
+		pipeline.TaskLabelKey:        "build",
+		pipeline.PipelineRunLabelKey: pipelineRunName,
+	}
+	failedTaskRun.Status.Conditions[0].Message = "step build failed"
+	pendingPipelineRun := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonPending.String())
+	pendingPipelineRun.Spec.PipelineRef = &v1beta1.PipelineRef{Name: "release"}
+	run := createRunWithCondition(corev1.ConditionTrue, "yay")
+	run.Spec.Ref = &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: "example"}
+
+	for _, tc := range []struct {
+		desc          string
+		object        objectWithCondition
+		wantEventType CDEventType
+		wantSubject   CDEventSubject
+	}{{
+		desc:          "started taskrun",
+		object:        getTaskRunByCondition(corev1.ConditionUnknown, v1beta1.TaskRunReasonStarted.String()),
+		wantEventType: TaskRunStartedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:      taskRunName,
+			Source:  "marshmallow",
+			Type:    CDEventSubjectTypeTaskRun,
+			Content: CDEventSubjectContent{URL: defaultEventSourceURI},
+		},
+	}, {
+		desc:          "failed taskrun of a pipelinerun",
+		object:        failedTaskRun,
+		wantEventType: TaskRunFinishedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:     taskRunName,
+			Source: "marshmallow",
+			Type:   CDEventSubjectTypeTaskRun,
+			Content: CDEventSubjectContent{
+				TaskName:    "build",
+				URL:         defaultEventSourceURI,
+				PipelineRun: &CDEventSubjectReference{ID: pipelineRunName},
+				Outcome:     CDEventOutcomeFailure,
+				Errors:      "step build failed",
+			},
+		},
+	}, {
+		desc:          "pending pipelinerun",
+		object:        pendingPipelineRun,
+		wantEventType: PipelineRunQueuedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:     pipelineRunName,
+			Source: "marshmallow",
+			Type:   CDEventSubjectTypePipelineRun,
+			Content: CDEventSubjectContent{
+				PipelineName: "release",
+				URL:          defaultEventSourceURI,
+			},
+		},
+	}, {
+		desc:          "started pipelinerun",
+		object:        getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonStarted.String()),
+		wantEventType: PipelineRunStartedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:      pipelineRunName,
+			Source:  "marshmallow",
+			Type:    CDEventSubjectTypePipelineRun,
+			Content: CDEventSubjectContent{URL: defaultEventSourceURI},
+		},
+	}, {
+		desc:          "successful pipelinerun",
+		object:        getPipelineRunByCondition(corev1.ConditionTrue, v1beta1.PipelineRunReasonSuccessful.String()),
+		wantEventType: PipelineRunFinishedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:     pipelineRunName,
+			Source: "marshmallow",
+			Type:   CDEventSubjectTypePipelineRun,
+			Content: CDEventSubjectContent{
+				URL:     defaultEventSourceURI,
+				Outcome: CDEventOutcomeSuccess,
+			},
+		},
+	}, {
+		desc:          "run without condition",
+		object:        createRunWithCondition("", ""),
+		wantEventType: TaskRunStartedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:      runName,
+			Source:  "marshmallow",
+			Type:    CDEventSubjectTypeTaskRun,
+			Content: CDEventSubjectContent{URL: defaultEventSourceURI},
+		},
+	}, {
+		desc:          "successful run",
+		object:        run,
+		wantEventType: TaskRunFinishedCDEventV1,
+		wantSubject: CDEventSubject{
+			ID:     runName,
+			Source: "marshmallow",
+			Type:   CDEventSubjectTypeTaskRun,
+			Content: CDEventSubjectContent{
+				TaskName: "example",
+				URL:      defaultEventSourceURI,
+				Outcome:  CDEventOutcomeSuccess,
+			},
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := cdEventForObjectWithCondition(tc.object)
+			if err != nil {
+				t.Fatalf("I did not expect an error but I got %s", err)
+			}
+			if got == nil {
+				t.Fatalf("Expected an event but got none")
+			}
+			if d := cmp.Diff(tc.wantEventType.String(), got.Type()); d != "" {
+				t.Errorf("Wrong Event Type %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSubject.ID, got.Subject()); d != "" {
+				t.Errorf("Wrong Event Subject %s", diff.PrintWantGot(d))
+			}
+			gotData := CDEventData{}
+			if err := got.DataAs(&gotData); err != nil {
+				t.Fatalf("Unexpected error from DataAs; %s", err)
+			}
+			wantContext := CDEventContext{
+				Version: CDEventsSpecVersion,
+				ID:      got.ID(),
+				Source:  defaultEventSourceURI,
+				Type:    tc.wantEventType.String(),
+			}
+			if d := cmp.Diff(wantContext, gotData.Context, cmpopts.IgnoreFields(CDEventContext{}, "Timestamp")); d != "" {
+				t.Errorf("Wrong CDEvent context %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSubject, gotData.Subject); d != "" {
+				t.Errorf("Wrong CDEvent subject %s", diff.PrintWantGot(d))
+			}
+			if err := got.Validate(); err != nil {
+				t.Errorf("Expected event to be valid; %s", err)
+			}
+		})
+	}
+}
+
+func TestCDEventForObjectWithConditionNoEvent(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		object objectWithCondition
+	}{{
+		desc:   "running taskrun",
+		object: getTaskRunByCondition(corev1.ConditionUnknown, v1beta1.TaskRunReasonRunning.String()),
+	}, {
+		desc:   "running pipelinerun",
+		object: getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String()),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := cdEventForObjectWithCondition(tc.object)
+			if err != nil {
+				t.Fatalf("I did not expect an error but I got %s", err)
+			}
+			if got != nil {
+				t.Errorf("Expected no event but got %v", got)
+			}
+		})
+	}
+}
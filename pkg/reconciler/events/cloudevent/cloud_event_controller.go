@@ -19,21 +19,22 @@ package cloudevent
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cache"
 	"go.uber.org/zap"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/clock"
-	controller "knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 )
 
@@ -117,8 +118,8 @@ func SendCloudEvents(tr *v1beta1.TaskRun, ceclient CEClient, logger *zap.Sugared
 	return merr.ErrorOrNil()
 }
 
-// SendCloudEventWithRetries sends a cloud event for the specified resource.
-// It does not block and it perform retries with backoff using the cloudevents
+// SendCloudEventWithRetries sends a cloud event for the specified resource in
+// each of the formats configured in the defaults. It does not block and it perform retries with backoff using the cloudevents
 // sdk-go capabilities.
 // It accepts a runtime.Object to avoid making objectWithCondition public since
 // it's only used within the events/cloudevents packages.
@@ -135,39 +136,123 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if ceClient == nil {
 		return errors.New("No cloud events client found in the context")
 	}
-	event, err := eventForObjectWithCondition(o)
+	events, err := eventsForObjectWithCondition(ctx, o)
 	if err != nil {
 		return err
 	}
+	if types, ok := ctx.Value(eventTypesKey{}).([]string); ok {
+		var matching []*cloudevents.Event
+		for _, event := range events {
+			if matchesEventTypes(types, event.Type()) {
+				matching = append(matching, event)
+			}
+		}
+		events = matching
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	var target string
+	if t := cecontext.TargetFrom(ctx); t != nil {
+		target = t.String()
+	}
 	// Events for Runs require a cache of events that have been sent
 	cacheClient := cache.Get(ctx)
 	_, isRun := object.(*v1alpha1.Run)
 
+	// The deliveries of the events of TaskRuns and PipelineRuns are recorded in their
+	// status as pending until they complete. The events whose delivery is already in
+	// flight aren't sent again.
+	tracker := GetDeliveryTracker(ctx)
+	deliveries := eventDeliveries(object)
+	keys := map[*cloudevents.Event]string{}
+	if tracker != nil && deliveries != nil && target != "" {
+		var starting []*cloudevents.Event
+		for _, event := range events {
+			key := deliveryKey(o, event.Type(), target)
+			if !tracker.start(key) {
+				logger.Debugf("The cloudevent of type %q to %s is already being sent", event.Type(), target)
+				continue
+			}
+			starting = append(starting, event)
+			keys[event] = key
+			setEventDelivery(deliveries, v1beta1.EventDelivery{
+				Type:  event.Type(),
+				Sink:  target,
+				State: v1beta1.EventDeliveryStatePending,
+			})
+		}
+		events = starting
+		if len(events) == 0 {
+			return nil
+		}
+	}
+
 	wasIn := make(chan error)
 	go func() {
 		wasIn <- nil
-		logger.Debugf("Sending cloudevent of type %q", event.Type())
-		// In case of Run event, check cache if cloudevent is already sent
-		if isRun {
-			cloudEventSent, err := cache.ContainsOrAddCloudEvent(cacheClient, event)
-			if err != nil {
-				logger.Errorf("error while checking cache: %s", err)
+		for _, event := range events {
+			// In case of Run event, check cache if cloudevent is already sent
+			if isRun {
+				cloudEventSent, err := cache.ContainsOrAddCloudEvent(cacheClient, event, target)
+				if err != nil {
+					logger.Errorf("error while checking cache: %s", err)
+				}
+				if cloudEventSent {
+					logger.Infof("cloudevent %v already sent", event)
+					continue
+				}
 			}
-			if cloudEventSent {
-				logger.Infof("cloudevent %v already sent", event)
-				return
+			delivery := deliverCloudEvent(ctx, ceClient, event, object, target)
+			if key, ok := keys[event]; ok {
+				tracker.complete(key, o, delivery)
 			}
 		}
-		if result := ceClient.Send(cloudevents.ContextWithRetriesExponentialBackoff(ctx, 10*time.Millisecond, 10), *event); !cloudevents.IsACK(result) {
-			logger.Warnf("Failed to send cloudevent: %s", result.Error())
-			recorder := controller.GetEventRecorder(ctx)
-			if recorder == nil {
-				logger.Warnf("No recorder in context, cannot emit error event")
-				return
-			}
-			recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", result.Error())
-		}
 	}()
 
 	return <-wasIn
 }
+
+type eventTypesKey struct{}
+
+// WithEventTypes returns a context in which SendCloudEventWithRetries only sends the
+// events whose type matches one of the given types. A type ending with "*" matches
+// all the event types starting with the same prefix.
+func WithEventTypes(ctx context.Context, types []string) context.Context {
+	return context.WithValue(ctx, eventTypesKey{}, types)
+}
+
+func matchesEventTypes(types []string, eventType string) bool {
+	for _, t := range types {
+		if strings.HasSuffix(t, "*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*")) || t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// eventsForObjectWithCondition creates the events for a objectWithCondition in each of
+// the configured formats. Formats without an event matching the status of the object
+// are skipped.
+func eventsForObjectWithCondition(ctx context.Context, o objectWithCondition) ([]*cloudevents.Event, error) {
+	var events []*cloudevents.Event
+	for _, format := range config.FromContextOrDefaults(ctx).Defaults.CloudEventsFormats() {
+		var (
+			event *cloudevents.Event
+			err   error
+		)
+		switch format {
+		case config.CloudEventsFormatCDEvents:
+			event, err = cdEventForObjectWithCondition(o)
+		default:
+			event, err = eventForObjectWithCondition(o)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
@@ -622,6 +623,56 @@ func TestSendCloudEventWithRetries(t *testing.T) {
 	}
 }
 
+func TestSendCloudEventWithRetriesFormats(t *testing.T) {
+	pipelineRun := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonStarted.String())
+	runningPipelineRun := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+
+	tests := []struct {
+		name        string
+		format      string
+		object      objectWithCondition
+		wantCEvents []string
+	}{{
+		name:        "default format",
+		format:      "",
+		object:      pipelineRun,
+		wantCEvents: []string{"(?s)type: dev.tekton.event.pipelinerun.started.v1"},
+	}, {
+		name:        "cdevents format",
+		format:      "cdevents",
+		object:      pipelineRun,
+		wantCEvents: []string{`(?s)type: dev.cdevents.pipelinerun.started.0.1.0.*"subject": {\s*"id": "fakepipelinerunname",\s*"source": "marshmallow",\s*"type": "pipelineRun"`},
+	}, {
+		name:   "both formats",
+		format: "tektonv1,cdevents",
+		object: pipelineRun,
+		wantCEvents: []string{
+			"(?s)type: dev.tekton.event.pipelinerun.started.v1",
+			"(?s)type: dev.cdevents.pipelinerun.started.0.1.0",
+		},
+	}, {
+		name:        "both formats without matching cdevent",
+		format:      "tektonv1,cdevents",
+		object:      runningPipelineRun,
+		wantCEvents: []string{"(?s)type: dev.tekton.event.pipelinerun.running.v1"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := setupFakeContext(t, FakeClientBehaviour{SendSuccessfully: true}, true)
+			cfg := config.FromContextOrDefaults(ctx)
+			cfg.Defaults.DefaultCloudEventsFormat = tc.format
+			ctx = config.ToContext(ctx, cfg)
+			if err := SendCloudEventWithRetries(ctx, tc.object); err != nil {
+				t.Fatalf("Unexpected error sending cloud events: %v", err)
+			}
+			ceClient := Get(ctx).(FakeClient)
+			if err := eventstest.CheckEventsUnordered(t, ceClient.Events, tc.name, tc.wantCEvents); err != nil {
+				t.Fatalf(err.Error())
+			}
+		})
+	}
+}
+
 func TestSendCloudEventWithRetriesInvalid(t *testing.T) {
 
 	tests := []struct {
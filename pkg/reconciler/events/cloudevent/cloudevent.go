@@ -106,9 +106,33 @@ func newTektonCloudEventData(runObject objectWithCondition) TektonCloudEventData
 // eventForObjectWithCondition creates a new event based for a objectWithCondition,
 // or return an error if not possible.
 func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Event, error) {
+	eventType, err := getEventType(runObject)
+	if err != nil {
+		return nil, err
+	}
+	if eventType == nil {
+		return nil, errors.New("No matching event type found")
+	}
+	return newEvent(runObject, *eventType)
+}
+
+// newEvent creates a new event of the given type for a objectWithCondition.
+func newEvent(runObject objectWithCondition, eventType TektonEventType) (*cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 	event.SetID(uuid.New().String())
 	event.SetSubject(runObject.GetObjectMeta().GetName())
+	event.SetSource(sourceForObjectWithCondition(runObject))
+	event.SetType(eventType.String())
+
+	if err := event.SetData(cloudevents.ApplicationJSON, newTektonCloudEventData(runObject)); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// sourceForObjectWithCondition returns the source of the events of a objectWithCondition,
+// which is the path of the object in the API server.
+func sourceForObjectWithCondition(runObject objectWithCondition) string {
 	// TODO: SelfLink is deprecated https://github.com/tektoncd/pipeline/issues/2676
 	source := runObject.GetObjectMeta().GetSelfLink()
 	if source == "" {
@@ -120,20 +144,7 @@ func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Ev
 			gvk.Kind,
 			runObject.GetObjectMeta().GetName())
 	}
-	event.SetSource(source)
-	eventType, err := getEventType(runObject)
-	if err != nil {
-		return nil, err
-	}
-	if eventType == nil {
-		return nil, errors.New("No matching event type found")
-	}
-	event.SetType(eventType.String())
-
-	if err := event.SetData(cloudevents.ApplicationJSON, newTektonCloudEventData(runObject)); err != nil {
-		return nil, err
-	}
-	return &event, nil
+	return source
 }
 
 // eventForTaskRun will create a new event based on a TaskRun,
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
@@ -49,9 +50,14 @@ func newFakeClient(behaviour *FakeClientBehaviour) cloudevents.Client {
 
 var _ cloudevents.Client = (*FakeClient)(nil)
 
-// Send fakes the Send method from cloudevents.Client
+// Send fakes the Send method from cloudevents.Client. The target of the event, if any,
+// is appended to the events recorded by the client.
 func (c FakeClient) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
 	if c.behaviour.SendSuccessfully {
+		if target := cecontext.TargetFrom(ctx); target != nil {
+			c.Events <- fmt.Sprintf("%sTarget: %s\n", event.String(), target)
+			return nil
+		}
 		c.Events <- fmt.Sprintf("%s", event.String())
 		return nil
 	}
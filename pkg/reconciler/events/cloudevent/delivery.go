@@ -0,0 +1,322 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// deliveryResultsSize is the number of delivery results kept until they are
+	// recorded in the status of their run
+	deliveryResultsSize = 4096
+
+	// FailedSinkExtension is the extension of the cloud events sent to the dead-letter
+	// sink holding the sink they could not be delivered to
+	FailedSinkExtension = "tektonfailedsink"
+	// FailedErrorExtension is the extension of the cloud events sent to the dead-letter
+	// sink holding the error of the last attempt to deliver them to their sink
+	FailedErrorExtension = "tektonfailederror"
+)
+
+var (
+	deliveryStateTag = tag.MustNewKey("state")
+	eventTypeTag     = tag.MustNewKey("type")
+
+	deliveryCount = stats.Int64("cloudevent_delivery_count",
+		"number of cloud events delivered, dead-lettered or failed",
+		stats.UnitDimensionless)
+
+	deliveryCountView = &view.View{
+		Description: deliveryCount.Description(),
+		Measure:     deliveryCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{deliveryStateTag, eventTypeTag},
+	}
+
+	registerViews sync.Once
+)
+
+func init() {
+	injection.Default.RegisterClient(withDeliveryTracker)
+	injection.Fake.RegisterClient(withDeliveryTracker)
+}
+
+// DeliveryTracker tracks the delivery of the cloud events of TaskRuns and PipelineRuns,
+// so that the state of each delivery is recorded in the status of its run once it
+// completes.
+type DeliveryTracker struct {
+	mutex    sync.Mutex
+	inFlight map[string]bool
+	results  *lru.Cache
+	enqueue  map[string]func(types.NamespacedName)
+}
+
+// deliveryTrackerKey is used to associate the DeliveryTracker inside the context.Context
+type deliveryTrackerKey struct{}
+
+func withDeliveryTracker(ctx context.Context, _ *rest.Config) context.Context {
+	logger := logging.FromContext(ctx)
+	registerViews.Do(func() {
+		if err := view.Register(deliveryCountView); err != nil {
+			logger.Errorf("Failed to register the cloud events delivery view: %v", err)
+		}
+	})
+	results, err := lru.New(deliveryResultsSize)
+	if err != nil {
+		logger.Errorf("Unable to create the cloud events delivery tracker: %v", err)
+		return ctx
+	}
+	return context.WithValue(ctx, deliveryTrackerKey{}, &DeliveryTracker{
+		inFlight: map[string]bool{},
+		results:  results,
+		enqueue:  map[string]func(types.NamespacedName){},
+	})
+}
+
+// GetDeliveryTracker extracts the DeliveryTracker from the context, or returns nil if
+// there is none.
+func GetDeliveryTracker(ctx context.Context) *DeliveryTracker {
+	t, _ := ctx.Value(deliveryTrackerKey{}).(*DeliveryTracker)
+	return t
+}
+
+// RegisterEnqueue registers the function enqueuing the runs of the given kind, which is
+// called once the delivery of one of their cloud events completes.
+func (t *DeliveryTracker) RegisterEnqueue(kind string, enqueue func(types.NamespacedName)) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.enqueue[kind] = enqueue
+}
+
+// start marks the delivery as in flight, unless it already is, and forgets the result
+// of its previous completion.
+func (t *DeliveryTracker) start(key string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.inFlight[key] {
+		return false
+	}
+	t.results.Remove(key)
+	t.inFlight[key] = true
+	return true
+}
+
+// complete records the result of a delivery and enqueues its run, so that the result is
+// recorded in its status.
+func (t *DeliveryTracker) complete(key string, o objectWithCondition, delivery v1beta1.EventDelivery) {
+	t.mutex.Lock()
+	t.results.Add(key, delivery)
+	delete(t.inFlight, key)
+	enqueue := t.enqueue[runKind(o)]
+	t.mutex.Unlock()
+	if enqueue != nil {
+		enqueue(types.NamespacedName{Namespace: o.GetObjectMeta().GetNamespace(), Name: o.GetObjectMeta().GetName()})
+	}
+}
+
+// result returns the result of a completed delivery.
+func (t *DeliveryTracker) result(key string) (v1beta1.EventDelivery, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if result, ok := t.results.Get(key); ok {
+		return result.(v1beta1.EventDelivery), true
+	}
+	return v1beta1.EventDelivery{}, false
+}
+
+func deliveryKey(o objectWithCondition, eventType, sink string) string {
+	meta := o.GetObjectMeta()
+	return fmt.Sprintf("%s/%s/%s/%s|%s|%s", runKind(o), meta.GetNamespace(), meta.GetName(), meta.GetUID(), eventType, sink)
+}
+
+func runKind(o objectWithCondition) string {
+	switch o.(type) {
+	case *v1beta1.TaskRun:
+		return pipeline.TaskRunControllerName
+	case *v1beta1.PipelineRun:
+		return pipeline.PipelineRunControllerName
+	default:
+		return pipeline.RunControllerName
+	}
+}
+
+// eventDeliveries returns the state of the deliveries in the status of TaskRuns and
+// PipelineRuns, or nil for other objects.
+func eventDeliveries(object runtime.Object) *[]v1beta1.EventDelivery {
+	switch o := object.(type) {
+	case *v1beta1.TaskRun:
+		return &o.Status.EventDeliveries
+	case *v1beta1.PipelineRun:
+		return &o.Status.EventDeliveries
+	default:
+		return nil
+	}
+}
+
+// setEventDelivery sets the state of the delivery of an event to a sink.
+func setEventDelivery(deliveries *[]v1beta1.EventDelivery, delivery v1beta1.EventDelivery) {
+	for i, d := range *deliveries {
+		if d.Type == delivery.Type && d.Sink == delivery.Sink {
+			(*deliveries)[i] = delivery
+			return
+		}
+	}
+	*deliveries = append(*deliveries, delivery)
+}
+
+// RecordEventDeliveries records in the status of a TaskRun or PipelineRun the state of
+// the deliveries of its cloud events which completed since it was last reconciled. The
+// deliveries which are pending but neither in flight nor completed were interrupted, e.g.
+// by a restart of the controller: their event is created again, with its type and the
+// current state of the run, and sent again. Every run is reconciled when the controller
+// starts, so the interrupted deliveries are resumed right after a restart.
+func RecordEventDeliveries(ctx context.Context, object runtime.Object) {
+	tracker := GetDeliveryTracker(ctx)
+	deliveries := eventDeliveries(object)
+	o, ok := object.(objectWithCondition)
+	if tracker == nil || deliveries == nil || !ok {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	for i, d := range *deliveries {
+		if d.State != v1beta1.EventDeliveryStatePending {
+			continue
+		}
+		key := deliveryKey(o, d.Type, d.Sink)
+		if result, ok := tracker.result(key); ok {
+			(*deliveries)[i] = result
+			continue
+		}
+		ceClient := Get(ctx)
+		if ceClient == nil || !tracker.start(key) {
+			continue
+		}
+		event, err := eventForType(o, d.Type)
+		if err != nil {
+			logger.Warnf("Failed to create the cloudevent of type %q to send again: %v", d.Type, err)
+			tracker.complete(key, o, v1beta1.EventDelivery{
+				Type:           d.Type,
+				Sink:           d.Sink,
+				State:          v1beta1.EventDeliveryStateFailed,
+				LastError:      err.Error(),
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			})
+			continue
+		}
+		logger.Infof("Sending again cloudevent of type %q to %s", d.Type, d.Sink)
+		go func(key, sink string, event *cloudevents.Event) {
+			delivery := deliverCloudEvent(cloudevents.ContextWithTarget(ctx, sink), ceClient, event, object, sink)
+			tracker.complete(key, o, delivery)
+		}(key, d.Sink, event)
+	}
+}
+
+// eventForType creates a new event of the given type, in the Tekton or CDEvents format,
+// for a objectWithCondition.
+func eventForType(o objectWithCondition, eventType string) (*cloudevents.Event, error) {
+	if strings.HasPrefix(eventType, cdEventTypePrefix) {
+		return newCDEvent(o, CDEventType(eventType))
+	}
+	return newEvent(o, TektonEventType(eventType))
+}
+
+// deliverCloudEvent sends a cloud event to its sink, retrying with an exponential backoff
+// bounded by the configured number of retries. Events which could not be delivered are
+// sent to the dead-letter sink, if any.
+func deliverCloudEvent(ctx context.Context, ceClient CEClient, event *cloudevents.Event, object runtime.Object, sink string) v1beta1.EventDelivery {
+	logger := logging.FromContext(ctx)
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	delivery := v1beta1.EventDelivery{Type: event.Type(), Sink: sink}
+
+	logger.Debugf("Sending cloudevent of type %q", event.Type())
+	result := ceClient.Send(cloudevents.ContextWithRetriesExponentialBackoff(ctx, defaults.DefaultCloudEventsRetryBackoff, defaults.DefaultCloudEventsMaxRetries), *event)
+	delivery.Attempts = 1
+	var retries *cehttp.RetriesResult
+	if errors.As(result, &retries) {
+		delivery.Attempts = retries.Retries + 1
+	}
+	delivery.CompletionTime = &metav1.Time{Time: time.Now()}
+	if cloudevents.IsACK(result) {
+		delivery.State = v1beta1.EventDeliveryStateDelivered
+		recordDelivery(ctx, delivery)
+		return delivery
+	}
+
+	logger.Warnf("Failed to send cloudevent: %s", result.Error())
+	delivery.State = v1beta1.EventDeliveryStateFailed
+	delivery.LastError = result.Error()
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", result.Error())
+	} else {
+		logger.Warnf("No recorder in context, cannot emit error event")
+	}
+
+	if deadLetterSink := defaults.DefaultCloudEventsDeadLetterSink; deadLetterSink != "" {
+		deadLetter := event.Clone()
+		deadLetter.SetExtension(FailedSinkExtension, sink)
+		deadLetter.SetExtension(FailedErrorExtension, result.Error())
+		deadLetterCtx := cloudevents.ContextWithTarget(ctx, deadLetterSink)
+		deadLetterCtx = cloudevents.ContextWithRetriesExponentialBackoff(deadLetterCtx, defaults.DefaultCloudEventsRetryBackoff, defaults.DefaultCloudEventsMaxRetries)
+		if result := ceClient.Send(deadLetterCtx, deadLetter); cloudevents.IsACK(result) {
+			delivery.State = v1beta1.EventDeliveryStateDeadLettered
+		} else {
+			logger.Warnf("Failed to send cloudevent to the dead-letter sink %s: %s", deadLetterSink, result.Error())
+		}
+	}
+	recordDelivery(ctx, delivery)
+	return delivery
+}
+
+// recordDelivery records the delivery of a cloud event in the metrics.
+func recordDelivery(ctx context.Context, delivery v1beta1.EventDelivery) {
+	ctx, err := tag.New(ctx,
+		tag.Insert(deliveryStateTag, strings.ToLower(string(delivery.State))),
+		tag.Insert(eventTypeTag, delivery.Type))
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to record the delivery of a cloudevent: %v", err)
+		return
+	}
+	metrics.Record(ctx, deliveryCount.M(1))
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opencensus.io/stats/view"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+// testSink is a local HTTP sink which fails the first requests it receives
+type testSink struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	failures int
+	events   []cloudevents.Event
+}
+
+func newTestSink(t *testing.T, failures int) *testSink {
+	t.Helper()
+	s := &testSink{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.failures != 0 {
+			s.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		event, err := cloudevents.NewEventFromHTTPRequest(r)
+		if err != nil {
+			t.Errorf("Unexpected error reading the cloud event: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.events = append(s.events, *event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testSink) received() []cloudevents.Event {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]cloudevents.Event{}, s.events...)
+}
+
+func setupDeliveryContext(t *testing.T, maxRetries int, deadLetterSink string) context.Context {
+	t.Helper()
+	ctx := setupFakeContext(t, FakeClientBehaviour{}, false)
+	ceClient, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		t.Fatalf("Unexpected error creating the cloud events client: %v", err)
+	}
+	ctx = context.WithValue(ctx, ceKey{}, ceClient)
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.Defaults.DefaultCloudEventsMaxRetries = maxRetries
+	cfg.Defaults.DefaultCloudEventsRetryBackoff = time.Millisecond
+	cfg.Defaults.DefaultCloudEventsDeadLetterSink = deadLetterSink
+	return config.ToContext(ctx, cfg)
+}
+
+// resetDeliveryMetrics clears the data recorded in the delivery metrics by other tests
+func resetDeliveryMetrics(t *testing.T) {
+	t.Helper()
+	view.Unregister(deliveryCountView)
+	if err := view.Register(deliveryCountView); err != nil {
+		t.Fatalf("Unexpected error registering the delivery view: %v", err)
+	}
+}
+
+func TestDeliverCloudEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		sinkFailures       int
+		maxRetries         int
+		deadLetterFailures int
+		withDeadLetterSink bool
+		wantState          v1beta1.EventDeliveryState
+		wantAttempts       int
+		wantError          bool
+		wantDeadLetters    int
+	}{{
+		name:         "delivered",
+		maxRetries:   3,
+		wantState:    v1beta1.EventDeliveryStateDelivered,
+		wantAttempts: 1,
+	}, {
+		name:         "delivered after retries",
+		sinkFailures: 2,
+		maxRetries:   3,
+		wantState:    v1beta1.EventDeliveryStateDelivered,
+		wantAttempts: 3,
+	}, {
+		name:         "failed",
+		sinkFailures: -1,
+		maxRetries:   2,
+		wantState:    v1beta1.EventDeliveryStateFailed,
+		wantAttempts: 3,
+		wantError:    true,
+	}, {
+		name:               "dead-lettered",
+		sinkFailures:       -1,
+		maxRetries:         1,
+		withDeadLetterSink: true,
+		wantState:          v1beta1.EventDeliveryStateDeadLettered,
+		wantAttempts:       2,
+		wantError:          true,
+		wantDeadLetters:    1,
+	}, {
+		name:               "failed with failing dead-letter sink",
+		sinkFailures:       -1,
+		maxRetries:         1,
+		withDeadLetterSink: true,
+		deadLetterFailures: -1,
+		wantState:          v1beta1.EventDeliveryStateFailed,
+		wantAttempts:       2,
+		wantError:          true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resetDeliveryMetrics(t)
+			sink := newTestSink(t, tc.sinkFailures)
+			deadLetterSink := newTestSink(t, tc.deadLetterFailures)
+			deadLetterURL := ""
+			if tc.withDeadLetterSink {
+				deadLetterURL = deadLetterSink.URL
+			}
+			ctx := setupDeliveryContext(t, tc.maxRetries, deadLetterURL)
+			taskRun := getTaskRunByCondition(corev1.ConditionTrue, "Succeeded")
+			event, err := eventForObjectWithCondition(taskRun)
+			if err != nil {
+				t.Fatalf("Unexpected error creating the cloud event: %v", err)
+			}
+
+			got := deliverCloudEvent(cloudevents.ContextWithTarget(ctx, sink.URL), Get(ctx), event, taskRun, sink.URL)
+			want := v1beta1.EventDelivery{
+				Type:     TaskRunSuccessfulEventV1.String(),
+				Sink:     sink.URL,
+				State:    tc.wantState,
+				Attempts: tc.wantAttempts,
+			}
+			if d := cmp.Diff(want, got, cmpopts.IgnoreFields(v1beta1.EventDelivery{}, "LastError", "CompletionTime")); d != "" {
+				t.Errorf("Wrong delivery %s", diff.PrintWantGot(d))
+			}
+			if tc.wantError != (got.LastError != "") {
+				t.Errorf("Expected an error to be recorded: %t, got %q", tc.wantError, got.LastError)
+			}
+			if got.CompletionTime == nil {
+				t.Error("Expected the completion time to be recorded")
+			}
+
+			deadLetters := deadLetterSink.received()
+			if len(deadLetters) != tc.wantDeadLetters {
+				t.Fatalf("Expected %d events in the dead-letter sink, got %d", tc.wantDeadLetters, len(deadLetters))
+			}
+			for _, deadLetter := range deadLetters {
+				if deadLetter.ID() != event.ID() {
+					t.Errorf("Expected the dead-letter event to be %q, got %q", event.ID(), deadLetter.ID())
+				}
+				if d := cmp.Diff(sink.URL, deadLetter.Extensions()[FailedSinkExtension]); d != "" {
+					t.Errorf("Wrong failed sink extension %s", diff.PrintWantGot(d))
+				}
+				if deadLetter.Extensions()[FailedErrorExtension] == nil {
+					t.Errorf("Expected the %s extension to be set", FailedErrorExtension)
+				}
+			}
+
+			metricstest.CheckCountData(t, "cloudevent_delivery_count", map[string]string{
+				"state": strings.ToLower(string(tc.wantState)),
+				"type":  TaskRunSuccessfulEventV1.String(),
+			}, 1)
+		})
+	}
+}
+
+func TestSendCloudEventWithRetriesRecordsDeliveries(t *testing.T) {
+	sink := newTestSink(t, 1)
+	ctx := setupDeliveryContext(t, 3, "")
+	enqueued := make(chan types.NamespacedName, 1)
+	GetDeliveryTracker(ctx).RegisterEnqueue(pipeline.TaskRunControllerName, func(key types.NamespacedName) {
+		enqueued <- key
+	})
+	taskRun := getTaskRunByCondition(corev1.ConditionTrue, "Succeeded")
+
+	if err := SendCloudEventWithRetries(cloudevents.ContextWithTarget(ctx, sink.URL), taskRun); err != nil {
+		t.Fatalf("Unexpected error sending cloud events: %v", err)
+	}
+	want := []v1beta1.EventDelivery{{
+		Type:  TaskRunSuccessfulEventV1.String(),
+		Sink:  sink.URL,
+		State: v1beta1.EventDeliveryStatePending,
+	}}
+	if d := cmp.Diff(want, taskRun.Status.EventDeliveries); d != "" {
+		t.Errorf("Wrong pending deliveries %s", diff.PrintWantGot(d))
+	}
+
+	select {
+	case key := <-enqueued:
+		if d := cmp.Diff(types.NamespacedName{Namespace: "marshmallow", Name: taskRunName}, key); d != "" {
+			t.Errorf("Wrong TaskRun enqueued %s", diff.PrintWantGot(d))
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("Timed out waiting for the TaskRun to be enqueued")
+	}
+
+	RecordEventDeliveries(ctx, taskRun)
+	want[0].State = v1beta1.EventDeliveryStateDelivered
+	want[0].Attempts = 2
+	if d := cmp.Diff(want, taskRun.Status.EventDeliveries, cmpopts.IgnoreFields(v1beta1.EventDelivery{}, "CompletionTime")); d != "" {
+		t.Errorf("Wrong recorded deliveries %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestRecordEventDeliveriesSendsInterruptedEventsAgain(t *testing.T) {
+	sink := newTestSink(t, 0)
+	ctx := setupDeliveryContext(t, 3, "")
+	enqueued := make(chan types.NamespacedName, 1)
+	GetDeliveryTracker(ctx).RegisterEnqueue(pipeline.TaskRunControllerName, func(key types.NamespacedName) {
+		enqueued <- key
+	})
+	delivered := v1beta1.EventDelivery{
+		Type:     TaskRunStartedEventV1.String(),
+		Sink:     sink.URL,
+		State:    v1beta1.EventDeliveryStateDelivered,
+		Attempts: 1,
+	}
+	inFlight := v1beta1.EventDelivery{
+		Type:  TaskRunRunningEventV1.String(),
+		Sink:  sink.URL,
+		State: v1beta1.EventDeliveryStatePending,
+	}
+	taskRun := getTaskRunByCondition(corev1.ConditionTrue, "Succeeded")
+	// The delivery of the successful event was interrupted, e.g. by a restart of the controller,
+	// while the running event is still being sent
+	taskRun.Status.EventDeliveries = []v1beta1.EventDelivery{delivered, inFlight, {
+		Type:  TaskRunSuccessfulEventV1.String(),
+		Sink:  sink.URL,
+		State: v1beta1.EventDeliveryStatePending,
+	}}
+	GetDeliveryTracker(ctx).start(deliveryKey(taskRun, inFlight.Type, inFlight.Sink))
+
+	RecordEventDeliveries(ctx, taskRun)
+	select {
+	case <-enqueued:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("Timed out waiting for the TaskRun to be enqueued")
+	}
+	received := sink.received()
+	if len(received) != 1 || received[0].Type() != TaskRunSuccessfulEventV1.String() {
+		t.Fatalf("Expected only the successful event to be sent again, got %v", received)
+	}
+
+	RecordEventDeliveries(ctx, taskRun)
+	want := []v1beta1.EventDelivery{delivered, inFlight, {
+		Type:     TaskRunSuccessfulEventV1.String(),
+		Sink:     sink.URL,
+		State:    v1beta1.EventDeliveryStateDelivered,
+		Attempts: 1,
+	}}
+	if d := cmp.Diff(want, taskRun.Status.EventDeliveries, cmpopts.IgnoreFields(v1beta1.EventDelivery{}, "CompletionTime")); d != "" {
+		t.Errorf("Wrong recorded deliveries %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestSendCloudEventWithRetriesSkipsEventsInFlight(t *testing.T) {
+	sink := newTestSink(t, 0)
+	ctx := setupDeliveryContext(t, 3, "")
+	taskRun := getTaskRunByCondition(corev1.ConditionTrue, "Succeeded")
+	// The successful event of the TaskRun is already being sent to the sink
+	GetDeliveryTracker(ctx).start(deliveryKey(taskRun, TaskRunSuccessfulEventV1.String(), sink.URL))
+
+	if err := SendCloudEventWithRetries(cloudevents.ContextWithTarget(ctx, sink.URL), taskRun); err != nil {
+		t.Fatalf("Unexpected error sending cloud events: %v", err)
+	}
+	if len(taskRun.Status.EventDeliveries) != 0 {
+		t.Errorf("Expected no delivery to be recorded, got %v", taskRun.Status.EventDeliveries)
+	}
+	// Give a duplicate delivery the time to reach the sink
+	time.Sleep(100 * time.Millisecond)
+	if received := sink.received(); len(received) != 0 {
+		t.Errorf("Expected the event in flight not to be sent again, got %v", received)
+	}
+}
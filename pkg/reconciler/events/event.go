@@ -18,12 +18,16 @@ package events
 
 import (
 	"context"
+	"strings"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
@@ -40,6 +44,14 @@ const (
 	EventReasonStarted = "Started"
 	// EventReasonError is the reason set for events related to TaskRuns / PipelineRuns reconcile errors
 	EventReasonError = "Error"
+
+	// CloudEventsSinkAnnotation is the annotation of a PipelineRun, TaskRun or Run holding
+	// the sink its cloud events are sent to, instead of the sinks of its namespace or the
+	// default sink
+	CloudEventsSinkAnnotation = pipeline.GroupName + "/cloudEventsSink"
+	// CloudEventsTypesAnnotation is the annotation of a PipelineRun, TaskRun or Run holding a
+	// comma-separated list of the types of the cloud events sent to the sink of the run
+	CloudEventsTypesAnnotation = pipeline.GroupName + "/cloudEventsTypes"
 )
 
 // Emit emits events for object
@@ -49,43 +61,82 @@ const (
 // Cloud events are always sent if enabled, i.e. if a sink is available
 func Emit(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	recorder := controller.GetEventRecorder(ctx)
-	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
-	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
-	}
 
 	sendKubernetesEvents(recorder, beforeCondition, afterCondition, object)
 
-	if sendCloudEvents {
-		// Only send events if the new condition represents a change
-		if !equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
-			err := cloudevent.SendCloudEventWithRetries(ctx, object)
-			if err != nil {
-				logger.Warnf("Failed to emit cloud events %v", err.Error())
-			}
-		}
+	// Record the deliveries of cloud events which completed since the last reconcile
+	cloudevent.RecordEventDeliveries(ctx, object)
+
+	// Only send events if the new condition represents a change
+	if !equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		EmitCloudEvents(ctx, object)
 	}
 }
 
-// EmitCloudEvents emits CloudEvents (only) for object
+// EmitCloudEvents emits CloudEvents (only) for object to each of its sinks
 func EmitCloudEvents(ctx context.Context, object runtime.Object) {
 	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
-	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
-	}
-
-	if sendCloudEvents {
-		err := cloudevent.SendCloudEventWithRetries(ctx, object)
+	for _, sink := range cloudEventsSinks(ctx, object) {
+		sinkCtx := cloudevents.ContextWithTarget(ctx, sink.URL)
+		if len(sink.Types) > 0 {
+			sinkCtx = cloudevent.WithEventTypes(sinkCtx, sink.Types)
+		}
+		err := cloudevent.SendCloudEventWithRetries(sinkCtx, object)
 		if err != nil {
 			logger.Warnf("Failed to emit cloud events %v", err.Error())
 		}
 	}
 }
 
+// cloudEventsSinks returns the sinks the cloud events of an object are sent to. The sink
+// in the annotations of the object takes precedence over the sinks configured for its
+// namespace, which take precedence over the default sink. The sink in the annotations is
+// ignored unless it is allowed by the defaults. The sinks of the namespace whose label
+// filters don't match the labels of the object are skipped.
+func cloudEventsSinks(ctx context.Context, object runtime.Object) []config.CloudEventsSink {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	objectMeta, err := meta.Accessor(object)
+	if err != nil {
+		if defaults.DefaultCloudEventsSink == "" {
+			return nil
+		}
+		return []config.CloudEventsSink{{URL: defaults.DefaultCloudEventsSink}}
+	}
+
+	sink := objectMeta.GetAnnotations()[CloudEventsSinkAnnotation]
+	if sink != "" {
+		if err := defaults.ValidateRunCloudEventsSink(sink); err != nil {
+			logging.FromContext(ctx).Warnf("Ignoring the %s annotation of %s/%s: %v", CloudEventsSinkAnnotation, objectMeta.GetNamespace(), objectMeta.GetName(), err)
+			sink = ""
+		}
+	}
+	if sink != "" {
+		runSink := config.CloudEventsSink{Namespace: objectMeta.GetNamespace(), URL: sink}
+		if types := objectMeta.GetAnnotations()[CloudEventsTypesAnnotation]; types != "" {
+			for _, t := range strings.Split(types, ",") {
+				runSink.Types = append(runSink.Types, strings.TrimSpace(t))
+			}
+		}
+		return []config.CloudEventsSink{runSink}
+	}
+
+	var sinks []config.CloudEventsSink
+	namespaceConfigured := false
+	for _, sink := range defaults.DefaultCloudEventsNamespaceSinks {
+		if sink.Namespace != objectMeta.GetNamespace() {
+			continue
+		}
+		namespaceConfigured = true
+		if labels.SelectorFromSet(sink.Labels).Matches(labels.Set(objectMeta.GetLabels())) {
+			sinks = append(sinks, sink)
+		}
+	}
+	if !namespaceConfigured && defaults.DefaultCloudEventsSink != "" {
+		sinks = append(sinks, config.CloudEventsSink{Namespace: objectMeta.GetNamespace(), URL: defaults.DefaultCloudEventsSink})
+	}
+	return sinks
+}
+
 func sendKubernetesEvents(c record.EventRecorder, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	// Events that are going to be sent
 	//
@@ -217,6 +217,11 @@ func TestEmit(t *testing.T) {
 		data:            map[string]string{"default-cloud-events-sink": "http://mysink"},
 		wantEvents:      []string{"Normal Started"},
 		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*test1`},
+	}, {
+		name:            "with sink of another namespace",
+		data:            map[string]string{"default-cloud-events-sink": "http://mysink", "default-cloud-events-namespace-sinks": "- namespace: other\n  sink: http://othersink"},
+		wantEvents:      []string{"Normal Started"},
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*test1.*Target: http://mysink`},
 	}}
 
 	for _, tc := range testcases {
@@ -235,7 +240,7 @@ func TestEmit(t *testing.T) {
 		ctx = config.ToContext(ctx, cfg)
 
 		recorder := controller.GetEventRecorder(ctx).(*record.FakeRecorder)
-		Emit(ctx, nil, after, object)
+		Emit(ctx, nil, after, object.DeepCopy())
 		if err := eventstest.CheckEventsOrdered(t, recorder.Events, tc.name, tc.wantEvents); err != nil {
 			t.Fatalf(err.Error())
 		}
@@ -300,3 +305,112 @@ func TestEmitCloudEvents(t *testing.T) {
 		}
 	}
 }
+
+func TestEmitCloudEventsSinks(t *testing.T) {
+	namespaceSinks := `
+- namespace: team-a
+  sink: http://team-a-sink
+- namespace: team-a
+  sink: http://team-a-failures
+  types:
+  - dev.tekton.event.pipelinerun.failed.v1
+- namespace: team-a
+  sink: http://team-a-web
+  types:
+  - dev.tekton.event.pipelinerun.*
+  labels:
+    app: web
+- namespace: team-b
+  sink: http://team-b-sink
+  labels:
+    app: web
+`
+	startedCondition := duckv1beta1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: v1beta1.PipelineRunReasonStarted.String(),
+		}},
+	}
+	pipelineRun := func(namespace string, labels, annotations map[string]string) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test1",
+				Namespace:   namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Status: v1beta1.PipelineRunStatus{Status: startedCondition},
+		}
+	}
+	testcases := []struct {
+		name            string
+		object          *v1beta1.PipelineRun
+		wantCloudEvents []string
+	}{{
+		name:            "default sink",
+		object:          pipelineRun("default", nil, nil),
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://default-sink`},
+	}, {
+		name:            "namespace sinks filtered by type",
+		object:          pipelineRun("team-a", nil, nil),
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://team-a-sink`},
+	}, {
+		name:   "namespace sinks filtered by type and label",
+		object: pipelineRun("team-a", map[string]string{"app": "web"}, nil),
+		wantCloudEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://team-a-sink`,
+			`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://team-a-web`,
+		},
+	}, {
+		name:            "namespace sinks without matching label",
+		object:          pipelineRun("team-b", map[string]string{"app": "db"}, nil),
+		wantCloudEvents: []string{},
+	}, {
+		name:            "run sink",
+		object:          pipelineRun("team-a", nil, map[string]string{CloudEventsSinkAnnotation: "http://run-sink"}),
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://run-sink`},
+	}, {
+		name:            "run sink with a sub-path of an allowed sink",
+		object:          pipelineRun("team-a", nil, map[string]string{CloudEventsSinkAnnotation: "http://run-sink/release"}),
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://run-sink/release`},
+	}, {
+		name:            "run sink not allowed",
+		object:          pipelineRun("default", nil, map[string]string{CloudEventsSinkAnnotation: "http://169.254.169.254/latest/meta-data"}),
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://default-sink`},
+	}, {
+		name:            "invalid run sink",
+		object:          pipelineRun("default", nil, map[string]string{CloudEventsSinkAnnotation: "file:///etc/passwd"}),
+		wantCloudEvents: []string{`(?s)dev.tekton.event.pipelinerun.started.v1.*Target: http://default-sink`},
+	}, {
+		name: "run sink filtered by type",
+		object: pipelineRun("team-a", nil, map[string]string{
+			CloudEventsSinkAnnotation:  "http://run-sink",
+			CloudEventsTypesAnnotation: "dev.tekton.event.pipelinerun.successful.v1, dev.tekton.event.pipelinerun.failed.v1",
+		}),
+		wantCloudEvents: []string{},
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ctx = cloudevent.WithClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true})
+			fakeClient := cloudevent.Get(ctx).(cloudevent.FakeClient)
+
+			defaults, err := config.NewDefaultsFromMap(map[string]string{
+				"default-cloud-events-sink":              "http://default-sink",
+				"default-cloud-events-namespace-sinks":   namespaceSinks,
+				"default-cloud-events-allowed-run-sinks": "http://run-sink",
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error parsing the defaults: %v", err)
+			}
+			ctx = config.ToContext(ctx, &config.Config{Defaults: defaults})
+
+			EmitCloudEvents(ctx, tc.object)
+			if err := eventstest.CheckEventsUnordered(t, fakeClient.Events, tc.name, tc.wantCloudEvents); err != nil {
+				t.Fatalf(err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// isCacheEnabled returns true if the TaskRun of rpt may be reused from, or stored in, the cache.
+func isCacheEnabled(ctx context.Context, rpt *resources.ResolvedPipelineTask) bool {
+	return config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields && rpt.IsCacheable()
+}
+
+// reuseCachedTaskRun looks for a successful TaskRun of rpt with the same cache key and, if one
+// is found, adds pr to its owners, sets it as the TaskRun of rpt and records it in the status of pr. It returns true
+// if a TaskRun was reused, in which case no TaskRun must be created for rpt.
+func (c *Reconciler) reuseCachedTaskRun(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) (bool, error) {
+	logger := logging.FromContext(ctx)
+
+	// The cache key must match the one set on the TaskRun createTaskRun would create.
+	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask)
+	if err := rpt.ValidateCacheWorkspaces(pr); err != nil {
+		logger.Errorf("Failed to compute the cache key of pipeline task %q of %q: %v", rpt.PipelineTask.Name, pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidCacheWorkspace, "PipelineRun %s/%s can't be Run; %s", pr.Namespace, pr.Name, err)
+		return false, controller.NewPermanentError(err)
+	}
+	cacheContext, err := c.cacheContext(ctx, rpt, pr)
+	if err != nil {
+		return false, err
+	}
+	spec := newTaskRunSpec(rpt, pr, nil)
+	key, err := resources.CacheKey(ctx, &spec, cacheContext)
+	if err != nil {
+		return false, err
+	}
+	taskRuns, err := c.taskRunLister.TaskRuns(pr.Namespace).List(k8slabels.SelectorFromSet(map[string]string{pipeline.CacheKeyLabelKey: key}))
+	if err != nil {
+		return false, fmt.Errorf("failed to list TaskRuns with cache key %s: %w", key, err)
+	}
+	var maxAge *time.Duration
+	if rpt.PipelineTask.Cache.MaxAge != nil {
+		maxAge = &rpt.PipelineTask.Cache.MaxAge.Duration
+	}
+	cached := resources.FindCachedTaskRun(ctx, taskRuns, rpt.PipelineTask.Name, key, maxAge, c.Clock.Now())
+	if cached == nil {
+		return false, nil
+	}
+
+	logger.Infof("Reusing TaskRun %s with cache key %s for pipeline task %q of %q", cached.Name, key, rpt.PipelineTask.Name, pr.Name)
+	// Like the TaskRuns of a resumed PipelineRun, the reused TaskRun is co-owned by pr so that it
+	// isn't garbage collected along with the PipelineRun which created it while pr refers to it.
+	cr := v1beta1.ChildStatusReference{
+		TypeMeta:         runtime.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: pipeline.TaskRunControllerName},
+		Name:             cached.Name,
+		PipelineTaskName: rpt.PipelineTask.Name,
+	}
+	if err := c.addOwnerReference(ctx, pr, cr, cached.OwnerReferences); err != nil {
+		return false, err
+	}
+	rpt.TaskRunName = cached.Name
+	rpt.TaskRun = cached
+	pr.Status.CachedTasks = append(pr.Status.CachedTasks, v1beta1.CachedTask{
+		Name:        rpt.PipelineTask.Name,
+		TaskRunName: cached.Name,
+		CacheKey:    key,
+	})
+	return true, nil
+}
+
+// cacheContext returns the cache context of rpt, including the digests of the files of its cached workspaces.
+func (c *Reconciler) cacheContext(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) (string, error) {
+	digests := map[string]string{}
+	for _, name := range rpt.PipelineTask.Cache.Workspaces {
+		for _, ws := range rpt.PipelineTask.Workspaces {
+			if ws.Name != name {
+				continue
+			}
+			binding := resources.PipelineRunWorkspaceBinding(pr, ws)
+			if binding == nil {
+				// An optional workspace which isn't bound has no files
+				continue
+			}
+			files, err := c.workspaceFiles(ctx, pr.Namespace, binding)
+			if err != nil {
+				return "", fmt.Errorf("failed to read the files of workspace %q of pipeline task %q: %w", name, rpt.PipelineTask.Name, err)
+			}
+			digests[name] = resources.FilesDigest(filesInSubPath(files, combinedSubPath(binding.SubPath, ws.SubPath)))
+		}
+	}
+	return rpt.CacheContext(pr, digests)
+}
+
+// workspaceFiles returns the content of the files of a workspace bound to a ConfigMap or a
+// Secret, by path, as the kubelet would mount them.
+func (c *Reconciler) workspaceFiles(ctx context.Context, namespace string, binding *v1beta1.WorkspaceBinding) (map[string][]byte, error) {
+	switch {
+	case binding.ConfigMap != nil:
+		cm, err := c.KubeClientSet.CoreV1().ConfigMaps(namespace).Get(ctx, binding.ConfigMap.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) && binding.ConfigMap.Optional != nil && *binding.ConfigMap.Optional {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		data := map[string][]byte{}
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			data[k] = v
+		}
+		return projectItems(data, binding.ConfigMap.Items), nil
+	case binding.Secret != nil:
+		secret, err := c.KubeClientSet.CoreV1().Secrets(namespace).Get(ctx, binding.Secret.SecretName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) && binding.Secret.Optional != nil && *binding.Secret.Optional {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return projectItems(secret.Data, binding.Secret.Items), nil
+	default:
+		return nil, fmt.Errorf("workspace %q isn't bound to a ConfigMap or a Secret", binding.Name)
+	}
+}
+
+// projectItems returns the files of data at the paths of items, if any, or at their keys otherwise.
+func projectItems(data map[string][]byte, items []corev1.KeyToPath) map[string][]byte {
+	if len(items) == 0 {
+		return data
+	}
+	files := map[string][]byte{}
+	for _, item := range items {
+		if v, ok := data[item.Key]; ok {
+			files[item.Path] = v
+		}
+	}
+	return files
+}
+
+// filesInSubPath returns the files under subPath, by path relative to subPath.
+func filesInSubPath(files map[string][]byte, subPath string) map[string][]byte {
+	subPath = strings.Trim(path.Clean("/"+subPath), "/")
+	if subPath == "" {
+		return files
+	}
+	filtered := map[string][]byte{}
+	for p, v := range files {
+		if rel := strings.TrimPrefix(p, subPath+"/"); rel != p {
+			filtered[rel] = v
+		} else if p == subPath {
+			filtered[path.Base(p)] = v
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCacheContextWorkspaceFiles(t *testing.T) {
+	rpt := &resources.ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:       "build",
+			TaskRef:    &v1beta1.TaskRef{Name: "build"},
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "config", Workspace: "settings"}},
+			Cache:      &v1beta1.PipelineTaskCache{Workspaces: []string{"config"}},
+		},
+		ResolvedTaskResources: &taskrunresources.ResolvedTaskResources{
+			TaskSpec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "golang"}}},
+		},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:      "settings",
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}},
+			}},
+		},
+	}
+	settings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "foo"},
+		Data:       map[string]string{"go.mod": "module foo"},
+	}
+	c := &Reconciler{KubeClientSet: fake.NewSimpleClientset(settings)}
+	ctx := context.Background()
+
+	want, err := c.cacheContext(ctx, rpt, pr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wantDigest := resources.FilesDigest(map[string][]byte{"go.mod": []byte("module foo")})
+	if got, _ := rpt.CacheContext(pr, map[string]string{"config": wantDigest}); got != want {
+		t.Errorf("Expected the cache context to include the digest of the ConfigMap, got %s and %s", got, want)
+	}
+
+	// The same binding with a different content of the ConfigMap has a different key
+	settings.Data["go.mod"] = "module bar"
+	if _, err := c.KubeClientSet.CoreV1().ConfigMaps("foo").Update(ctx, settings, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, err := c.cacheContext(ctx, rpt, pr); err != nil || got == want {
+		t.Errorf("Expected the cache context to depend on the content of the ConfigMap, got %s, %v", got, err)
+	}
+
+	// A missing ConfigMap is an error unless it is optional
+	pr.Spec.Workspaces[0].ConfigMap.Name = "missing"
+	if _, err := c.cacheContext(ctx, rpt, pr); err == nil {
+		t.Errorf("Expected an error for a missing ConfigMap")
+	}
+	optional := true
+	pr.Spec.Workspaces[0].ConfigMap.Optional = &optional
+	if _, err := c.cacheContext(ctx, rpt, pr); err != nil {
+		t.Errorf("Unexpected error for a missing optional ConfigMap: %v", err)
+	}
+}
+
+func TestWorkspaceFilesSecretItemsAndSubPath(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("abc"), "ca.crt": []byte("def")},
+	}
+	c := &Reconciler{KubeClientSet: fake.NewSimpleClientset(secret)}
+	binding := &v1beta1.WorkspaceBinding{
+		Name: "credentials",
+		Secret: &corev1.SecretVolumeSource{
+			SecretName: "credentials",
+			Items:      []corev1.KeyToPath{{Key: "token", Path: "auth/token"}},
+		},
+	}
+
+	files, err := c.workspaceFiles(context.Background(), "foo", binding)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string][]byte{"auth/token": []byte("abc")}, files); d != "" {
+		t.Errorf("Wrong files %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(map[string][]byte{"token": []byte("abc")}, filesInSubPath(files, "auth/")); d != "" {
+		t.Errorf("Wrong files in sub path %s", diff.PrintWantGot(d))
+	}
+}
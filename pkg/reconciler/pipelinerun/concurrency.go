@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+var cancelPipelineRunPatchBytes []byte
+
+func init() {
+	var err error
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1beta1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
+}
+
+// concurrencyKey returns the key shared by the PipelineRuns of a namespace that are limited
+// together by policy, or "" if pr doesn't have the label or the named pipelineRef the scope
+// of policy requires, in which case the policy doesn't limit it.
+func concurrencyKey(policy config.ConcurrencyPolicy, pr *v1beta1.PipelineRun) string {
+	switch policy.Scope {
+	case config.ConcurrencyScopeLabel:
+		value, ok := pr.Labels[policy.LabelKey]
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("label/%s=%s", policy.LabelKey, value)
+	case config.ConcurrencyScopeNamespace:
+		return "namespace"
+	default:
+		if pr.Spec.PipelineRef == nil || pr.Spec.PipelineRef.Name == "" {
+			return ""
+		}
+		return fmt.Sprintf("pipeline/%s", pr.Spec.PipelineRef.Name)
+	}
+}
+
+// concurrencyLimiter serializes the concurrency decisions taken for the PipelineRuns
+// sharing a concurrency key, which may be reconciled in parallel by different workers.
+// It only serializes the decisions taken by this replica of the controller: the limits
+// are enforced as long as a single replica reconciles all the PipelineRuns of a namespace,
+// which is the case with the default single bucket of config-leader-election.
+type concurrencyLimiter struct {
+	mu   sync.Mutex
+	keys map[string]*concurrencyKeyLock
+}
+
+// concurrencyKeyLock is the lock of a concurrency key of a namespace.
+type concurrencyKeyLock struct {
+	mu sync.Mutex
+	// refs is the number of reconciliations holding or waiting for the lock, and is
+	// guarded by the mutex of the concurrencyLimiter.
+	refs int
+}
+
+func newConcurrencyLimiter() *concurrencyLimiter {
+	return &concurrencyLimiter{keys: map[string]*concurrencyKeyLock{}}
+}
+
+// lock locks the concurrency keys of a namespace, in order so that concurrent
+// reconciliations locking some of the same keys can't deadlock.
+func (l *concurrencyLimiter) lock(namespace string, keys []string) {
+	for _, key := range keys {
+		id := namespace + "/" + key
+		l.mu.Lock()
+		kl, ok := l.keys[id]
+		if !ok {
+			kl = &concurrencyKeyLock{}
+			l.keys[id] = kl
+		}
+		kl.refs++
+		l.mu.Unlock()
+		kl.mu.Lock()
+	}
+}
+
+// unlock unlocks the concurrency keys of a namespace, and forgets them once they are
+// not used anymore.
+func (l *concurrencyLimiter) unlock(namespace string, keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		id := namespace + "/" + key
+		kl := l.keys[id]
+		kl.mu.Unlock()
+		kl.refs--
+		if kl.refs == 0 {
+			delete(l.keys, id)
+		}
+	}
+}
+
+// isQueued returns true if the PipelineRun is waiting to be started.
+func isQueued(pr *v1beta1.PipelineRun) bool {
+	return !pr.HasStarted() && !pr.IsDone() && !pr.IsPending() && !pr.IsDryRun() && !pr.IsCancelled()
+}
+
+// queuePriority returns the priority of a queued PipelineRun.
+func queuePriority(pr *v1beta1.PipelineRun) int {
+	if pr.Spec.Concurrency != nil {
+		return pr.Spec.Concurrency.Priority
+	}
+	return 0
+}
+
+// sortQueue sorts the queued PipelineRuns in the order in which a policy with order
+// starts them.
+func sortQueue(queue []*v1beta1.PipelineRun, order string) {
+	sort.SliceStable(queue, func(i, j int) bool {
+		if order == config.ConcurrencyOrderPriority {
+			if pi, pj := queuePriority(queue[i]), queuePriority(queue[j]); pi != pj {
+				return pi > pj
+			}
+		}
+		if !queue[i].CreationTimestamp.Equal(&queue[j].CreationTimestamp) {
+			return queue[i].CreationTimestamp.Before(&queue[j].CreationTimestamp)
+		}
+		return queue[i].Name < queue[j].Name
+	})
+}
+
+// reconcileConcurrency applies the concurrency policies of the namespace of a PipelineRun that
+// has not started yet. It returns true if the PipelineRun can be started, or false if it has
+// been queued or cancelled by one of the policies, in which case its status has been updated
+// accordingly.
+func (c *Reconciler) reconcileConcurrency(ctx context.Context, pr *v1beta1.PipelineRun) (bool, error) {
+	var policies []config.ConcurrencyPolicy
+	var keys []string
+	for _, policy := range config.FromContextOrDefaults(ctx).Defaults.ConcurrencyPolicies(pr.Namespace) {
+		if key := concurrencyKey(policy, pr); key != "" {
+			policies = append(policies, policy)
+			keys = append(keys, key)
+		}
+	}
+	if len(policies) == 0 {
+		return true, nil
+	}
+
+	locked := sets.NewString(keys...).List()
+	c.concurrencyLimiter.lock(pr.Namespace, locked)
+	defer c.concurrencyLimiter.unlock(pr.Namespace, locked)
+
+	prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(k8slabels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list PipelineRuns in namespace %s: %w", pr.Namespace, err)
+	}
+	for i, policy := range policies {
+		start, err := c.applyConcurrencyPolicy(ctx, policy, keys[i], pr, prs)
+		if err != nil || !start {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// applyConcurrencyPolicy applies a concurrency policy limiting pr with key, among the PipelineRuns
+// prs of its namespace. It returns true if the policy lets pr start, cancelling the oldest running
+// PipelineRuns if needed, or false if it has been queued or cancelled.
+func (c *Reconciler) applyConcurrencyPolicy(ctx context.Context, policy config.ConcurrencyPolicy, key string, pr *v1beta1.PipelineRun, prs []*v1beta1.PipelineRun) (bool, error) {
+	logger := logging.FromContext(ctx)
+	var running, queue []*v1beta1.PipelineRun
+	for _, other := range prs {
+		if other.Name == pr.Name || concurrencyKey(policy, other) != key {
+			continue
+		}
+		switch {
+		case other.HasStarted() && !other.IsDone():
+			running = append(running, other)
+		case isQueued(other):
+			queue = append(queue, other)
+		}
+	}
+	queue = append(queue, pr)
+	sortQueue(queue, policy.Order)
+	position := 0
+	for i, queued := range queue {
+		if queued.Name == pr.Name {
+			position = i + 1
+			break
+		}
+	}
+	// The PipelineRuns ahead of pr in the queue are started before it, and may already have
+	// been started by the last reconciliations even if the lister doesn't show it yet, so
+	// they count against the limit along with the running PipelineRuns.
+	ahead := queue[:position-1]
+	if len(running)+position <= policy.MaxRuns {
+		return true, nil
+	}
+
+	pr.Status.Concurrency = &v1beta1.PipelineRunConcurrencyStatus{Key: key}
+	switch policy.Strategy {
+	case config.ConcurrencyStrategyCancelNewest:
+		pr.Status.InitializeConditions(c.Clock)
+		pr.Status.MarkFailed(ReasonConcurrencyLimitReached,
+			"PipelineRun %q was cancelled because %d PipelineRun(s) with concurrency key %q are already running or starting", pr.Name, len(running)+len(ahead), key)
+		return false, nil
+	case config.ConcurrencyStrategyCancelOldest:
+		var cancellable []*v1beta1.PipelineRun
+		for _, r := range running {
+			if !r.IsCancelled() {
+				cancellable = append(cancellable, r)
+			}
+		}
+		sort.SliceStable(cancellable, func(i, j int) bool {
+			return cancellable[i].Status.StartTime.Before(cancellable[j].Status.StartTime)
+		})
+		// The running PipelineRuns are older than the ones which haven't started yet
+		cancellable = append(cancellable, ahead...)
+		for i := 0; i < len(cancellable)+1-policy.MaxRuns; i++ {
+			logger.Infof("Cancelling PipelineRun %s to make room for PipelineRun %s with concurrency key %q", cancellable[i].Name, pr.Name, key)
+			if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, cancellable[i].Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil && !errors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to cancel PipelineRun %s: %w", cancellable[i].Name, err)
+			}
+		}
+		return true, nil
+	}
+
+	// A queued PipelineRun is pending rather than running, as it hasn't started yet
+	pr.Status.Concurrency.QueuePosition = position
+	pr.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: ReasonPending,
+		Message: fmt.Sprintf("PipelineRun %q is queued at position %d: %d PipelineRun(s) with concurrency key %q are already running",
+			pr.Name, position, len(running), key),
+	})
+	return false, nil
+}
+
+// enqueueQueuedPipelineRuns enqueues the queued PipelineRuns of a namespace.
+func enqueueQueuedPipelineRuns(impl *controller.Impl, c *Reconciler, namespace string) {
+	prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(k8slabels.Everything())
+	if err != nil {
+		return
+	}
+	for _, pr := range prs {
+		if isQueued(pr) {
+			impl.Enqueue(pr)
+		}
+	}
+}
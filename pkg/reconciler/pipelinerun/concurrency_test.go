@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestConcurrencyKey(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy config.ConcurrencyPolicy
+		pr     *v1beta1.PipelineRun
+		want   string
+	}{{
+		name:   "label",
+		policy: config.ConcurrencyPolicy{Scope: config.ConcurrencyScopeLabel, LabelKey: "environment"},
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"environment": "prod"}},
+		},
+		want: "label/environment=prod",
+	}, {
+		name:   "empty label value",
+		policy: config.ConcurrencyPolicy{Scope: config.ConcurrencyScopeLabel, LabelKey: "environment"},
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"environment": ""}},
+		},
+		want: "label/environment=",
+	}, {
+		name:   "missing label",
+		policy: config.ConcurrencyPolicy{Scope: config.ConcurrencyScopeLabel, LabelKey: "environment"},
+		pr:     &v1beta1.PipelineRun{},
+	}, {
+		name:   "namespace",
+		policy: config.ConcurrencyPolicy{Scope: config.ConcurrencyScopeNamespace},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{PipelineSpec: &v1beta1.PipelineSpec{}},
+		},
+		want: "namespace",
+	}, {
+		name:   "pipeline",
+		policy: config.ConcurrencyPolicy{Scope: config.ConcurrencyScopePipeline},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "build"}},
+		},
+		want: "pipeline/build",
+	}, {
+		name:   "pipeline with an embedded pipelineSpec",
+		policy: config.ConcurrencyPolicy{Scope: config.ConcurrencyScopePipeline},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{PipelineSpec: &v1beta1.PipelineSpec{}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, concurrencyKey(tc.policy, tc.pr)); d != "" {
+				t.Errorf("Wrong concurrency key %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSortQueue(t *testing.T) {
+	queued := func(name string, created int, priority int) *v1beta1.PipelineRun {
+		pr := &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Date(2022, 1, created, 0, 0, 0, 0, time.UTC)),
+			},
+		}
+		if priority != 0 {
+			pr.Spec.Concurrency = &v1beta1.PipelineRunConcurrency{Priority: priority}
+		}
+		return pr
+	}
+	for _, tc := range []struct {
+		order string
+		want  []string
+	}{{
+		order: config.ConcurrencyOrderFIFO,
+		want:  []string{"negative", "oldest", "low", "newest-high", "high"},
+	}, {
+		order: config.ConcurrencyOrderPriority,
+		want:  []string{"newest-high", "high", "low", "oldest", "negative"},
+	}} {
+		t.Run(tc.order, func(t *testing.T) {
+			queue := []*v1beta1.PipelineRun{
+				queued("newest-high", 3, 100),
+				queued("low", 2, 1),
+				queued("oldest", 1, 0),
+				queued("high", 4, 10),
+				queued("negative", 1, -1),
+			}
+			sortQueue(queue, tc.order)
+
+			var got []string
+			for _, pr := range queue {
+				got = append(got, pr.Name)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Wrong queue order %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func withConcurrencyPolicies(t *testing.T, policies ...config.ConcurrencyPolicy) context.Context {
+	t.Helper()
+	ctx := logtesting.TestContextWithLogger(t)
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.Defaults.DefaultConcurrencyPolicies = policies
+	return config.ToContext(ctx, cfg)
+}
+
+func newConcurrencyTestReconciler(t *testing.T, prs ...*v1beta1.PipelineRun) (*Reconciler, cache.Indexer) {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pr := range prs {
+		if err := indexer.Add(pr); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	return &Reconciler{
+		pipelineRunLister:  listers.NewPipelineRunLister(indexer),
+		concurrencyLimiter: newConcurrencyLimiter(),
+	}, indexer
+}
+
+func TestReconcileConcurrency_PipelineRunsAheadCountAgainstTheLimit(t *testing.T) {
+	// The PipelineRuns ahead in the queue count against the limit, whether they were already
+	// started by a previous reconciliation whose update isn't in the lister yet or not.
+	first := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "foo", CreationTimestamp: metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))},
+	}
+	second := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "foo", CreationTimestamp: metav1.NewTime(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC))},
+	}
+	c, indexer := newConcurrencyTestReconciler(t, first, second)
+	ctx := withConcurrencyPolicies(t, config.ConcurrencyPolicy{
+		Scope: config.ConcurrencyScopeNamespace, MaxRuns: 1, Strategy: config.ConcurrencyStrategyQueue, Order: config.ConcurrencyOrderFIFO,
+	})
+
+	if start, err := c.reconcileConcurrency(ctx, first.DeepCopy()); err != nil || !start {
+		t.Fatalf("Expected PipelineRun first to start, got %t, %v", start, err)
+	}
+	pr := second.DeepCopy()
+	if start, err := c.reconcileConcurrency(ctx, pr); err != nil || start {
+		t.Fatalf("Expected PipelineRun second to be queued, got %t, %v", start, err)
+	}
+	if d := cmp.Diff(2, pr.Status.Concurrency.QueuePosition); d != "" {
+		t.Errorf("Wrong queue position %s", diff.PrintWantGot(d))
+	}
+
+	// Once the first PipelineRun is done, it doesn't count as running anymore
+	first = first.DeepCopy()
+	first.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	if err := indexer.Update(first); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if start, err := c.reconcileConcurrency(ctx, second.DeepCopy()); err != nil || !start {
+		t.Fatalf("Expected PipelineRun second to start, got %t, %v", start, err)
+	}
+}
+
+func TestReconcileConcurrency_EveryPolicyLimits(t *testing.T) {
+	// A PipelineRun only starts if every policy in scope lets it start, and the running
+	// PipelineRuns count against a policy whether they request a priority or not.
+	running := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "foo", Labels: map[string]string{"environment": "prod"}},
+		Spec:       v1beta1.PipelineRunSpec{PipelineSpec: &v1beta1.PipelineSpec{}},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			StartTime: &metav1.Time{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		}},
+	}
+	for _, tc := range []struct {
+		name      string
+		pr        *v1beta1.PipelineRun
+		wantStart bool
+		wantKey   string
+	}{{
+		name: "limited by the label policy",
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "foo", Labels: map[string]string{"environment": "prod"}},
+			Spec:       v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "build"}},
+		},
+		wantKey: "label/environment=prod",
+	}, {
+		name: "out of the scope of the label policy",
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "foo", Labels: map[string]string{"environment": "dev"}},
+			Spec:       v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "build"}},
+		},
+		wantStart: true,
+	}, {
+		name: "a higher priority does not raise the limit",
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "foo", Labels: map[string]string{"environment": "prod"}},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineSpec: &v1beta1.PipelineSpec{},
+				Concurrency:  &v1beta1.PipelineRunConcurrency{Priority: 100},
+			},
+		},
+		wantKey: "label/environment=prod",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newConcurrencyTestReconciler(t, running, tc.pr)
+			ctx := withConcurrencyPolicies(t, config.ConcurrencyPolicy{
+				Scope: config.ConcurrencyScopePipeline, MaxRuns: 1, Strategy: config.ConcurrencyStrategyQueue, Order: config.ConcurrencyOrderFIFO,
+			}, config.ConcurrencyPolicy{
+				Namespace: "foo", Scope: config.ConcurrencyScopeLabel, LabelKey: "environment", MaxRuns: 1,
+				Strategy: config.ConcurrencyStrategyQueue, Order: config.ConcurrencyOrderPriority,
+			})
+
+			pr := tc.pr.DeepCopy()
+			start, err := c.reconcileConcurrency(ctx, pr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if start != tc.wantStart {
+				t.Errorf("Expected start to be %t, got %t", tc.wantStart, start)
+			}
+			var gotKey string
+			if pr.Status.Concurrency != nil {
+				gotKey = pr.Status.Concurrency.Key
+			}
+			if d := cmp.Diff(tc.wantKey, gotKey); d != "" {
+				t.Errorf("Wrong concurrency key %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -34,14 +34,23 @@ import (
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 )
 
+const (
+	// TracerProviderName is the name of the service the spans of the PipelineRun reconciler are exported for
+	TracerProviderName = "pipelinerun-reconciler"
+	// TracerName is the name of the tracer of the PipelineRun reconciler
+	TracerName = "PipelineRunReconciler"
+)
+
 // NewController instantiates a new controller.Impl from knative.dev/pkg/controller
 func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -55,6 +64,8 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		resolutionInformer := resolutioninformer.Get(ctx)
 		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger))
 		configStore.WatchConfigs(cmw)
+		tracerProvider := tracing.New(ctx, TracerProviderName, logger.Named("tracing"))
+		cmw.Watch(config.GetTracingConfigName(), tracerProvider.OnConfigMap)
 
 		c := &Reconciler{
 			KubeClientSet:       kubeclientset,
@@ -69,6 +80,8 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			metrics:             pipelinerunmetrics.Get(ctx),
 			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
 			resolutionRequester: resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			tracerProvider:      tracerProvider,
+			concurrencyLimiter:  newConcurrencyLimiter(),
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -77,7 +90,27 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			}
 		})
 
+		c.enqueueAfter = impl.EnqueueAfter
+
 		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		// A PipelineRun that finishes or is deleted frees a concurrency slot, so the queued
+		// PipelineRuns of its namespace need to be reconciled again.
+		pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				old, ok := oldObj.(*v1beta1.PipelineRun)
+				if !ok {
+					return
+				}
+				if pr, ok := newObj.(*v1beta1.PipelineRun); ok && !old.IsDone() && pr.IsDone() {
+					enqueueQueuedPipelineRuns(impl, c, pr.Namespace)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if object, err := kmeta.DeletionHandlingAccessor(obj); err == nil {
+					enqueueQueuedPipelineRuns(impl, c, object.GetNamespace())
+				}
+			},
+		})
 
 		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
@@ -92,6 +125,9 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		// PipelineRuns are reconciled again once the delivery of their cloud events completes
+		cloudeventclient.GetDeliveryTracker(ctx).RegisterEnqueue(pipeline.PipelineRunControllerName, impl.EnqueueKey)
+
 		return impl
 	}
 }
@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+)
+
+// dryRun validates the results and workspaces of the PipelineTasks of pr, which the PipelineRun
+// reconciler otherwise validates right before creating the first TaskRun or Run, then stores the
+// execution plan of pr in its status and marks it as succeeded. No TaskRun, Run, PVC or Affinity
+// Assistant is created for pr.
+func dryRun(ctx context.Context, pr *v1beta1.PipelineRun, pipelineSpec *v1beta1.PipelineSpec, facts *resources.PipelineRunFacts) error {
+	if err := validatePipelineTaskResultsAndWorkspaces(ctx, pr, pipelineSpec, facts.State); err != nil {
+		return err
+	}
+	pr.Status.Plan = facts.Plan()
+	pr.Status.MarkSucceeded(ReasonDryRunCompleted, planSummary(pr.Status.Plan))
+	return nil
+}
+
+// planSummary returns how many PipelineTasks of the plan would run, be skipped, or depend on
+// the results of other PipelineTasks.
+func planSummary(plan *v1beta1.PipelineRunPlan) string {
+	outcomes := map[v1beta1.PlannedOutcome]int{}
+	for _, pts := range [][]v1beta1.PlannedPipelineTask{plan.Tasks, plan.Finally} {
+		for _, pt := range pts {
+			outcomes[pt.Outcome]++
+		}
+	}
+	return fmt.Sprintf("Tasks to run: %d, Skipped: %d, Depending on results: %d",
+		outcomes[v1beta1.PlannedOutcomeRun], outcomes[v1beta1.PlannedOutcomeSkip], outcomes[v1beta1.PlannedOutcomeUnknown])
+}
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -53,7 +54,10 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"github.com/tektoncd/pipeline/pkg/workspace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -108,6 +112,9 @@ const (
 	ReasonCancelled = pipelinerunmetrics.ReasonCancelled
 	// ReasonPending indicates that a PipelineRun is pending.
 	ReasonPending = "PipelineRunPending"
+	// ReasonConcurrencyLimitReached indicates that a PipelineRun was cancelled because
+	// the concurrency limit of its concurrency key was reached.
+	ReasonConcurrencyLimitReached = "ConcurrencyLimitReached"
 	// ReasonCouldntCancel indicates that a PipelineRun was cancelled but attempting to update
 	// all of the running TaskRuns as cancelled failed.
 	ReasonCouldntCancel = "PipelineRunCouldntCancel"
@@ -123,6 +130,25 @@ const (
 	// ReasonResolvingPipelineRef indicates that the PipelineRun is waiting for
 	// its pipelineRef to be asynchronously resolved.
 	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
+	// ReasonInvalidMatrixParameterTypes indicates that the params in the Matrix of a PipelineTask
+	// did not resolve to arrays
+	ReasonInvalidMatrixParameterTypes = "InvalidMatrixParameterTypes"
+	// ReasonMaxMatrixCombinationsCountExceeded indicates that the Matrix of a PipelineTask resolved
+	// to more combinations than the maximum allowed
+	ReasonMaxMatrixCombinationsCountExceeded = "MaxMatrixCombinationsCountExceeded"
+	// ReasonCouldntResume indicates that the PipelineRun named by spec.resumeFrom couldn't be
+	// retrieved or hasn't completed
+	ReasonCouldntResume = "CouldntResumePipelineRun"
+
+	// ReasonDryRunCompleted indicates that the execution plan of a PipelineRun in DryRun
+	// spec status was computed
+	ReasonDryRunCompleted = "PipelineRunDryRunCompleted"
+	// ReasonDryRunNotEnabled indicates that a PipelineRun in DryRun spec status failed because
+	// the alpha API fields were disabled, so that it is never run for real
+	ReasonDryRunNotEnabled = "PipelineRunDryRunNotEnabled"
+	// ReasonInvalidCacheWorkspace indicates that the reason for the failure status is that
+	// a cached workspace of a PipelineTask isn't bound to a ConfigMap or a Secret
+	ReasonInvalidCacheWorkspace = "InvalidCacheWorkspace"
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -141,6 +167,12 @@ type Reconciler struct {
 	metrics             *pipelinerunmetrics.Recorder
 	pvcHandler          volumeclaim.PvcHandler
 	resolutionRequester resolution.Requester
+	tracerProvider      trace.TracerProvider
+	concurrencyLimiter  *concurrencyLimiter
+
+	// enqueueAfter schedules another reconciliation of a PipelineRun, e.g. once the
+	// backoff of a PipelineTask retry has elapsed
+	enqueueAfter func(interface{}, time.Duration)
 }
 
 var (
@@ -154,10 +186,34 @@ var (
 func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	// All the reconciliations of the PipelineRun are traced as part of the same trace
+	ctx = tracing.ContextWithRootSpan(ctx, c.tracerProvider.Tracer(TracerName), &pr.ObjectMeta, tracing.PipelineRunSpanContextAnnotation, "", "PipelineRun",
+		attribute.String("namespace", pr.Namespace), attribute.String("pipelinerun", pr.Name))
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:ReconcileKind")
+	defer span.End()
 
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
+	// Apply the concurrency policies before starting the PipelineRun, so that the time
+	// spent waiting in the queue does not count towards its timeout. A queued PipelineRun
+	// which is cancelled or stopped leaves the queue through the normal cancellation path.
+	if !pr.HasStarted() && !pr.IsPending() && !pr.IsDryRun() && !pr.IsDone() &&
+		!pr.IsCancelled() && !pr.IsGracefullyCancelled() && !pr.IsGracefullyStopped() &&
+		config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		start, err := c.reconcileConcurrency(ctx, pr)
+		if err != nil {
+			logger.Errorf("Failed to apply the concurrency policy of PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if !start {
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		}
+		if pr.Status.Concurrency != nil {
+			pr.Status.Concurrency.QueuePosition = 0
+		}
+	}
+
 	if !pr.HasStarted() && !pr.IsPending() {
 		pr.Status.InitializeConditions(c.Clock)
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -261,19 +317,31 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, pr *v1beta1.Pi
 		// We get latest pipelinerun cr already to avoid recount
 		newPr, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name)
 		if err != nil && !kerrors.IsNotFound(err) {
-			logger.Errorf("Error getting PipelineRun %s when updating metrics: %w", pr.Name, err)
+			logger.Errorf("Error getting PipelineRun %s when updating metrics: %v", pr.Name, err)
 			return
 		} else if kerrors.IsNotFound(err) || newPr == nil {
-			logger.Debugf("Pipelinerun %s not found when updating metrics: %w", pr.Name, err)
+			logger.Debugf("Pipelinerun %s not found when updating metrics: %v", pr.Name, err)
 			return
 		}
 
 		before := newPr.Status.GetCondition(apis.ConditionSucceeded)
+		childSelector := k8slabels.SelectorFromSet(k8slabels.Set{pipeline.PipelineRunLabelKey: pr.Name})
+		taskRuns, err := c.taskRunLister.TaskRuns(pr.Namespace).List(childSelector)
+		if err != nil {
+			logger.Errorf("Error listing TaskRuns of PipelineRun %s when updating metrics: %v", pr.Name, err)
+		}
+		runs, err := c.runLister.Runs(pr.Namespace).List(childSelector)
+		if err != nil {
+			logger.Errorf("Error listing Runs of PipelineRun %s when updating metrics: %v", pr.Name, err)
+		}
 		go func(metrics *pipelinerunmetrics.Recorder) {
 			err := metrics.DurationAndCount(pr, before)
 			if err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
+			if err := metrics.PipelineTaskMetrics(pr, before, taskRuns, runs); err != nil {
+				logger.Warnf("Failed to log the pipeline task metrics : %v", err)
+			}
 		}(c.metrics)
 	}
 }
@@ -283,6 +351,9 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 
 	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
 	events.Emit(ctx, beforeCondition, afterCondition, pr)
+	if !beforeCondition.IsTrue() && !beforeCondition.IsFalse() && pr.IsDone() {
+		c.traceCompletion(ctx, pr)
+	}
 	_, err := c.updateLabelsAndAnnotations(ctx, pr)
 	if err != nil {
 		logger.Warn("Failed to update PipelineRun labels/annotations", zap.Error(err))
@@ -296,6 +367,64 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 	return merr
 }
 
+// traceCompletion records the root span of the trace of a PipelineRun which is done, from
+// its creation to its completion, and the spans of its PipelineTasks, from the creation of
+// their first TaskRun or Run to the completion of their last one.
+func (c *Reconciler) traceCompletion(ctx context.Context, pr *v1beta1.PipelineRun) {
+	logger := logging.FromContext(ctx)
+	tracer := c.tracerProvider.Tracer(TracerName)
+	end := c.Clock.Now()
+	if pr.Status.CompletionTime != nil {
+		end = pr.Status.CompletionTime.Time
+	}
+
+	type interval struct{ start, end time.Time }
+	pipelineTasks := map[string]*interval{}
+	record := func(pipelineTask string, created metav1.Time, completed *metav1.Time) {
+		if pipelineTask == "" {
+			return
+		}
+		// A child still running when the PipelineRun is done ends with it
+		done := end
+		if completed != nil {
+			done = completed.Time
+		}
+		i, ok := pipelineTasks[pipelineTask]
+		if !ok {
+			pipelineTasks[pipelineTask] = &interval{start: created.Time, end: done}
+			return
+		}
+		if created.Time.Before(i.start) {
+			i.start = created.Time
+		}
+		if done.After(i.end) {
+			i.end = done
+		}
+	}
+	childSelector := k8slabels.SelectorFromSet(k8slabels.Set{pipeline.PipelineRunLabelKey: pr.Name})
+	taskRuns, err := c.taskRunLister.TaskRuns(pr.Namespace).List(childSelector)
+	if err != nil {
+		logger.Errorf("Error listing TaskRuns of PipelineRun %s when tracing it: %v", pr.Name, err)
+	}
+	for _, tr := range taskRuns {
+		record(tr.Labels[pipeline.PipelineTaskLabelKey], tr.CreationTimestamp, tr.Status.CompletionTime)
+	}
+	runs, err := c.runLister.Runs(pr.Namespace).List(childSelector)
+	if err != nil {
+		logger.Errorf("Error listing Runs of PipelineRun %s when tracing it: %v", pr.Name, err)
+	}
+	for _, r := range runs {
+		record(r.Labels[pipeline.PipelineTaskLabelKey], r.CreationTimestamp, r.Status.CompletionTime)
+	}
+
+	for name, i := range pipelineTasks {
+		tracing.EndPipelineTaskSpan(ctx, tracer, &pr.ObjectMeta, name, i.start, i.end,
+			attribute.String("namespace", pr.Namespace), attribute.String("pipelinerun", pr.Name), attribute.String("pipelineTask", name))
+	}
+	tracing.EndRootSpan(ctx, tracer, &pr.ObjectMeta, tracing.PipelineRunSpanContextAnnotation, "", "PipelineRun", pr.CreationTimestamp.Time, end,
+		attribute.String("namespace", pr.Namespace), attribute.String("pipelinerun", pr.Name))
+}
+
 // resolvePipelineState will attempt to resolve each referenced task in the pipeline's spec and all of the resources
 // specified by those tasks.
 func (c *Reconciler) resolvePipelineState(
@@ -304,6 +433,8 @@ func (c *Reconciler) resolvePipelineState(
 	pipelineMeta *metav1.ObjectMeta,
 	pr *v1beta1.PipelineRun,
 	providedResources map[string]*resourcev1alpha1.PipelineResource) (resources.PipelineRunState, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "resolvePipelineState")
+	defer span.End()
 	pst := resources.PipelineRunState{}
 	// Resolve each task individually because they each could have a different reference context (remote or local).
 	for _, task := range tasks {
@@ -354,6 +485,8 @@ func (c *Reconciler) resolvePipelineState(
 }
 
 func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, getPipelineFunc rprp.GetPipeline) error {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "reconcile")
+	defer span.End()
 	defer c.durationAndCountMetrics(ctx, pr)
 	logger := logging.FromContext(ctx)
 	cfg := config.FromContextOrDefaults(ctx)
@@ -365,6 +498,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return nil
 	}
 
+	// A PipelineRun in DryRun spec status must not run its PipelineTasks, even when the alpha
+	// API fields were disabled after it was created
+	if pr.IsDryRun() && cfg.FeatureFlags.EnableAPIFields != config.AlphaAPIFields {
+		err := fmt.Errorf("the DryRun spec status requires %q to be %q", "enable-api-fields", config.AlphaAPIFields)
+		pr.Status.MarkFailed(ReasonDryRunNotEnabled, "PipelineRun %s/%s can't be run: %s", pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	pipelineMeta, pipelineSpec, err := rprp.GetPipelineData(ctx, pr, getPipelineFunc)
 	switch {
 	case errors.Is(err, remote.ErrorRequestInProgress):
@@ -494,6 +635,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Seed the status with the outcome of the PipelineRun this PipelineRun resumes
+	if pr.Spec.ResumeFrom != "" && !isResumed(pr) && cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		if err := c.resumeFromPipelineRun(ctx, pr, pipelineMeta.Name, pipelineSpec); err != nil {
+			logger.Errorf("Failed to resume PipelineRun %s from %s: %v", pr.Name, pr.Spec.ResumeFrom, err)
+			return err
+		}
+	}
+
 	// Apply parameter substitution from the PipelineRun
 	pipelineSpec = resources.ApplyParameters(ctx, pipelineSpec, pr)
 	pipelineSpec = resources.ApplyContexts(pipelineSpec, pipelineMeta.Name, pr)
@@ -530,6 +679,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		TimeoutsState: resources.PipelineRunTimeoutsState{
 			Clock: c.Clock,
 		},
+		ResumedSkips: resumedSkips(pr),
 	}
 	if pr.Status.StartTime != nil {
 		pipelineRunFacts.TimeoutsState.StartTime = &pr.Status.StartTime.Time
@@ -568,23 +718,16 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 	}
 
-	if pipelineRunFacts.State.IsBeforeFirstTaskRun() {
-		if err := resources.ValidatePipelineTaskResults(pipelineRunFacts.State); err != nil {
-			logger.Errorf("Failed to resolve task result reference for %q with error %v", pr.Name, err)
-			pr.Status.MarkFailed(ReasonInvalidTaskResultReference, err.Error())
-			return controller.NewPermanentError(err)
-		}
-
-		if err := resources.ValidatePipelineResults(pipelineSpec, pipelineRunFacts.State); err != nil {
-			logger.Errorf("Failed to resolve task result reference for %q with error %v", pr.Name, err)
-			pr.Status.MarkFailed(ReasonInvalidTaskResultReference, err.Error())
-			return controller.NewPermanentError(err)
-		}
+	// A PipelineRun in DryRun spec status only reports what it would run.
+	if pr.IsDryRun() {
+		return dryRun(ctx, pr, pipelineSpec, pipelineRunFacts)
+	}
 
-		if err := resources.ValidateOptionalWorkspaces(pipelineSpec.Workspaces, pipelineRunFacts.State); err != nil {
-			logger.Errorf("Optional workspace not supported by task: %v", err)
-			pr.Status.MarkFailed(ReasonRequiredWorkspaceMarkedOptional, err.Error())
-			return controller.NewPermanentError(err)
+	// The TaskRuns and Runs reused from the PipelineRun this PipelineRun resumes do not count,
+	// so that the workspaces of the new PipelineTasks are still set up.
+	if withoutReusedTasks(pr, pipelineRunFacts.State).IsBeforeFirstTaskRun() {
+		if err := validatePipelineTaskResultsAndWorkspaces(ctx, pr, pipelineSpec, pipelineRunFacts.State); err != nil {
+			return err
 		}
 
 		if pr.HasVolumeClaimTemplate() {
@@ -632,6 +775,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 					logger.Errorf("Failed to timeout tasks for PipelineRun %s/%s: %s", pr.Name, pr.Name, errString)
 					return fmt.Errorf("error(s) from cancelling TaskRun(s) from PipelineRun %s: %s", pr.Name, errString)
 				}
+				emitPipelineTasksTimedOut(ctx, pr, tasksToTimeOut, "tasks", pr.Spec.Timeouts.Tasks.Duration)
 			}
 		}
 	} else if pr.HasFinallyTimedOut(ctx, c.Clock) {
@@ -649,6 +793,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 				logger.Errorf("Failed to timeout finally tasks for PipelineRun %s/%s: %s", pr.Name, pr.Name, errString)
 				return fmt.Errorf("error(s) from cancelling TaskRun(s) from PipelineRun %s: %s", pr.Name, errString)
 			}
+			emitPipelineTasksTimedOut(ctx, pr, tasksToTimeOut, "finally", pr.Spec.Timeouts.Finally.Duration)
 		}
 	}
 	if err := c.runNextSchedulableTask(ctx, pr, pipelineRunFacts, as); err != nil {
@@ -687,7 +832,9 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		pr.Status.ChildReferences = pipelineRunFacts.State.GetChildReferences()
 	}
 
-	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	skippedTasks := pipelineRunFacts.GetSkippedTasks()
+	emitPipelineTasksSkipped(ctx, pr, pr.Status.SkippedTasks, skippedTasks)
+	pr.Status.SkippedTasks = skippedTasks
 	if after.Status == corev1.ConditionTrue || after.Status == corev1.ConditionFalse {
 		pr.Status.PipelineResults, err = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results,
 			pipelineRunFacts.State.GetTaskRunsResults(), pipelineRunFacts.State.GetRunsResults())
@@ -701,10 +848,37 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	return nil
 }
 
+// validatePipelineTaskResultsAndWorkspaces validates the results and the workspaces used by
+// the PipelineTasks of pr, and marks pr as failed if they are invalid.
+func validatePipelineTaskResultsAndWorkspaces(ctx context.Context, pr *v1beta1.PipelineRun, pipelineSpec *v1beta1.PipelineSpec, state resources.PipelineRunState) error {
+	logger := logging.FromContext(ctx)
+
+	if err := resources.ValidatePipelineTaskResults(state); err != nil {
+		logger.Errorf("Failed to resolve task result reference for %q with error %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidTaskResultReference, err.Error())
+		return controller.NewPermanentError(err)
+	}
+
+	if err := resources.ValidatePipelineResults(pipelineSpec, state); err != nil {
+		logger.Errorf("Failed to resolve task result reference for %q with error %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidTaskResultReference, err.Error())
+		return controller.NewPermanentError(err)
+	}
+
+	if err := resources.ValidateOptionalWorkspaces(pipelineSpec.Workspaces, state); err != nil {
+		logger.Errorf("Optional workspace not supported by task: %v", err)
+		pr.Status.MarkFailed(ReasonRequiredWorkspaceMarkedOptional, err.Error())
+		return controller.NewPermanentError(err)
+	}
+	return nil
+}
+
 // runNextSchedulableTask gets the next schedulable Tasks from the dag based on the current
 // pipeline run state, and starts them
 // after all DAG tasks are done, it's responsible for scheduling final tasks and start executing them
 func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.PipelineRun, pipelineRunFacts *resources.PipelineRunFacts, as artifacts.ArtifactStorageInterface) error {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "runNextSchedulableTask")
+	defer span.End()
 
 	logger := logging.FromContext(ctx)
 	recorder := controller.GetEventRecorder(ctx)
@@ -755,6 +929,55 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 			continue
 		}
 
+		// A failed PipelineTask with a retryPolicy backoff is retried once the backoff has elapsed.
+		if wait := rpt.RetryBackoffRemaining(c.Clock); wait > 0 {
+			logger.Infof("Waiting %s before retrying pipeline task %q of %q", wait, rpt.PipelineTask.Name, pr.Name)
+			if c.enqueueAfter != nil {
+				c.enqueueAfter(pr, wait)
+			}
+			continue
+		}
+
+		// A looped PipelineTask whose until condition doesn't hold yet runs its next iteration
+		// in a new TaskRun once the delay of its loop has elapsed.
+		if rpt.HasNextLoopIteration() {
+			if wait := rpt.LoopDelayRemaining(c.Clock); wait > 0 {
+				logger.Infof("Waiting %s before the next iteration of pipeline task %q of %q", wait, rpt.PipelineTask.Name, pr.Name)
+				if c.enqueueAfter != nil {
+					c.enqueueAfter(pr, wait)
+				}
+				continue
+			}
+			rpt.StartNextLoopIteration(pr.Name)
+		}
+
+		// The Matrix may reference results which are only resolved now, so its
+		// fan out can only be validated and named right before it's executed.
+		if rpt.IsMatrixed() {
+			if err := resources.ValidateMatrixParamTypes(rpt.PipelineTask); err != nil {
+				logger.Infof("Failed to fan out pipeline task %q for %q with error %v", rpt.PipelineTask.Name, pr.Name, err)
+				pr.Status.MarkFailed(ReasonInvalidMatrixParameterTypes, err.Error())
+				return controller.NewPermanentError(err)
+			}
+			if err := resources.ValidateMatrixCombinationsCount(ctx, rpt.PipelineTask); err != nil {
+				logger.Infof("Failed to fan out pipeline task %q for %q with error %v", rpt.PipelineTask.Name, pr.Name, err)
+				pr.Status.MarkFailed(ReasonMaxMatrixCombinationsCountExceeded, err.Error())
+				return controller.NewPermanentError(err)
+			}
+			rpt.SetMatrixFanOutNames(pr)
+		}
+
+		// A PipelineTask with a cache reuses a previous successful TaskRun with the same cache key.
+		if rpt.TaskRun == nil && isCacheEnabled(ctx, rpt) {
+			reused, err := c.reuseCachedTaskRun(ctx, rpt, pr)
+			if err != nil {
+				return err
+			}
+			if reused {
+				continue
+			}
+		}
+
 		switch {
 		case rpt.IsCustomTask() && rpt.IsMatrixed():
 			rpt.Runs, err = c.createRuns(ctx, rpt, pr)
@@ -846,6 +1069,9 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 }
 
 func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params []v1beta1.Param, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createTaskRun",
+		trace.WithAttributes(attribute.String("pipelineTask", rpt.PipelineTask.Name), attribute.String("taskrun", taskRunName)))
+	defer span.End()
 	logger := logging.FromContext(ctx)
 
 	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
@@ -856,17 +1082,22 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		}
 		// Don't modify the lister cache's copy.
 		tr = tr.DeepCopy()
+		failureReason := tr.Status.GetCondition(apis.ConditionSucceeded).Reason
 		// is a retry
 		addRetryHistory(tr)
 		clearStatus(tr)
 		tr.Status.MarkResourceOngoing("", "")
 		logger.Infof("Updating taskrun %s with cleared status and retry history (length: %d).", tr.GetName(), len(tr.Status.RetriesStatus))
-		return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).UpdateStatus(ctx, tr, metav1.UpdateOptions{})
+		updated, err := c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).UpdateStatus(ctx, tr, metav1.UpdateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		retry := len(updated.Status.RetriesStatus) - updated.GetPodEvictionRetriesCount()
+		emitPipelineTaskRetried(ctx, pr, rpt.PipelineTask.Name, taskRunName, failureReason, retry, rpt.PipelineTask.Retries)
+		return updated, nil
 	}
 
 	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask)
-	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
-	params = append(params, rpt.PipelineTask.Params...)
 	tr = &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            taskRunName,
@@ -875,24 +1106,24 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 			Labels:          combineTaskRunAndTaskSpecLabels(pr, rpt.PipelineTask),
 			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rpt.PipelineTask),
 		},
-		Spec: v1beta1.TaskRunSpec{
-			Params:             params,
-			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
-			PodTemplate:        taskRunSpec.TaskPodTemplate,
-			StepOverrides:      taskRunSpec.StepOverrides,
-			SidecarOverrides:   taskRunSpec.SidecarOverrides,
-			ComputeResources:   taskRunSpec.ComputeResources,
-		}}
+		Spec: newTaskRunSpec(rpt, pr, params),
+	}
 
-	if rpt.PipelineTask.Timeout != nil {
-		tr.Spec.Timeout = rpt.PipelineTask.Timeout
+	if isCacheEnabled(ctx, rpt) {
+		cacheContext, err := c.cacheContext(ctx, rpt, pr)
+		if err != nil {
+			return nil, err
+		}
+		key, err := resources.CacheKey(ctx, &tr.Spec, cacheContext)
+		if err != nil {
+			return nil, err
+		}
+		tr.Labels[pipeline.CacheKeyLabelKey] = key
+		tr.Annotations[pipeline.CacheContextAnnotationKey] = cacheContext
 	}
 
-	if rpt.ResolvedTaskResources.TaskName != "" {
-		// We pass the entire, original task ref because it may contain additional references like a Bundle url.
-		tr.Spec.TaskRef = rpt.PipelineTask.TaskRef
-	} else if rpt.ResolvedTaskResources.TaskSpec != nil {
-		tr.Spec.TaskSpec = rpt.ResolvedTaskResources.TaskSpec
+	if rpt.IsLooped() {
+		tr.Labels[pipeline.LoopIterationLabelKey] = strconv.Itoa(rpt.LoopIteration())
 	}
 
 	var pipelinePVCWorkspaceName string
@@ -906,9 +1137,42 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
 	}
 
+	// The trace of the TaskRun is part of the span of its PipelineTask
+	tracing.InjectPipelineTaskSpanContext(&pr.ObjectMeta, rpt.PipelineTask.Name, &tr.ObjectMeta)
+
 	resources.WrapSteps(&tr.Spec, rpt.PipelineTask, rpt.ResolvedTaskResources.Inputs, rpt.ResolvedTaskResources.Outputs, storageBasePath)
 	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rpt.PipelineTask.Name)
-	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
+	tr, err = c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	emitPipelineTaskScheduled(ctx, pr, rpt.PipelineTask.Name, "TaskRun", taskRunName)
+	return tr, nil
+}
+
+// newTaskRunSpec returns the spec of a TaskRun of rpt with params, without its workspaces and resources.
+func newTaskRunSpec(rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun, params []v1beta1.Param) v1beta1.TaskRunSpec {
+	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
+	spec := v1beta1.TaskRunSpec{
+		Params:             append(params, rpt.PipelineTask.Params...),
+		ServiceAccountName: taskRunSpec.TaskServiceAccountName,
+		PodTemplate:        taskRunSpec.TaskPodTemplate,
+		StepOverrides:      taskRunSpec.StepOverrides,
+		SidecarOverrides:   taskRunSpec.SidecarOverrides,
+		ComputeResources:   taskRunSpec.ComputeResources,
+	}
+
+	if rpt.PipelineTask.Timeout != nil {
+		spec.Timeout = rpt.PipelineTask.Timeout
+	}
+
+	if rpt.ResolvedTaskResources.TaskName != "" {
+		// We pass the entire, original task ref because it may contain additional references like a Bundle url.
+		spec.TaskRef = rpt.PipelineTask.TaskRef
+	} else if rpt.ResolvedTaskResources.TaskSpec != nil {
+		spec.TaskSpec = rpt.ResolvedTaskResources.TaskSpec
+	}
+	return spec
 }
 
 func (c *Reconciler) createRuns(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) ([]*v1alpha1.Run, error) {
@@ -926,6 +1190,9 @@ func (c *Reconciler) createRuns(ctx context.Context, rpt *resources.ResolvedPipe
 }
 
 func (c *Reconciler) createRun(ctx context.Context, runName string, params []v1beta1.Param, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) (*v1alpha1.Run, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createRun",
+		trace.WithAttributes(attribute.String("pipelineTask", rpt.PipelineTask.Name), attribute.String("run", runName)))
+	defer span.End()
 	logger := logging.FromContext(ctx)
 	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
 	params = append(params, rpt.PipelineTask.Params...)
@@ -979,8 +1246,16 @@ func (c *Reconciler) createRun(ctx context.Context, runName string, params []v1b
 		r.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
 	}
 
+	// The Run is part of the span of its PipelineTask, for custom task controllers which trace it
+	tracing.InjectPipelineTaskSpanContext(&pr.ObjectMeta, rpt.PipelineTask.Name, &r.ObjectMeta)
+
 	logger.Infof("Creating a new Run object %s", runName)
-	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
+	run, err := c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	emitPipelineTaskScheduled(ctx, pr, rpt.PipelineTask.Name, "Run", runName)
+	return run, nil
 }
 
 // propagateWorkspaces identifies the workspaces that the pipeline task usess
@@ -42,11 +42,14 @@ import (
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	eventstest "github.com/tektoncd/pipeline/test/events"
 	"github.com/tektoncd/pipeline/test/names"
 	"github.com/tektoncd/pipeline/test/parse"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"gomodules.xyz/jsonpatch/v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -133,6 +136,10 @@ func getPipelineRunController(t *testing.T, d test.Data) (test.Assets, func()) {
 // controller initialization.
 func initializePipelineRunControllerAssets(t *testing.T, d test.Data, opts pipeline.Options) (test.Assets, func()) {
 	ctx, _ := ttesting.SetupFakeContext(t)
+	return initializePipelineRunControllerAssetsWithContext(ctx, t, d, opts)
+}
+
+func initializePipelineRunControllerAssetsWithContext(ctx context.Context, t *testing.T, d test.Data, opts pipeline.Options) (test.Assets, func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	test.EnsureConfigurationConfigMapsExist(&d)
 	c, informers := test.SeedTestData(t, ctx, d)
@@ -2429,6 +2436,260 @@ spec:
 	}
 }
 
+func TestReconcileWithConcurrency(t *testing.T) {
+	// TestReconcileWithConcurrency runs "Reconcile" on a PipelineRun limited by a concurrency policy
+	// while other PipelineRuns sharing its concurrency key are running or queued.
+	runningPipelineRun := func(name, startTime string) *v1beta1.PipelineRun {
+		return parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+  labels:
+    environment: prod
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  startTime: "%s"
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+`, name, startTime))
+	}
+	queuedPipelineRun := func(name, creationTimestamp string, priority int) *v1beta1.PipelineRun {
+		return parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+  creationTimestamp: "%s"
+  labels:
+    environment: prod
+spec:
+  pipelineRef:
+    name: test-pipeline
+  concurrency:
+    priority: %d
+`, name, creationTimestamp, priority))
+	}
+
+	for _, tc := range []struct {
+		name              string
+		policies          string
+		priority          int
+		others            []*v1beta1.PipelineRun
+		wantReason        string
+		wantStatus        corev1.ConditionStatus
+		wantKey           string
+		wantQueuePosition int
+		wantCancelled     []string
+	}{{
+		name: "queued behind an older queued PipelineRun",
+		policies: `- scope: Label
+  labelKey: environment
+  maxRuns: 2`,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+			queuedPipelineRun("queued-1", "2021-12-31T00:00:00Z", 0),
+		},
+		wantReason:        ReasonPending,
+		wantStatus:        corev1.ConditionUnknown,
+		wantKey:           "label/environment=prod",
+		wantQueuePosition: 2,
+	}, {
+		name: "started before an older queued PipelineRun with a lower priority",
+		policies: `- scope: Label
+  labelKey: environment
+  maxRuns: 2
+  order: Priority`,
+		priority: 10,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+			queuedPipelineRun("queued-1", "2021-12-31T00:00:00Z", 0),
+		},
+		wantReason: v1beta1.PipelineRunReasonRunning.String(),
+		wantStatus: corev1.ConditionUnknown,
+	}, {
+		name: "priority ignored by a FIFO policy",
+		policies: `- scope: Label
+  labelKey: environment
+  maxRuns: 2`,
+		priority: 10,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+			queuedPipelineRun("queued-1", "2021-12-31T00:00:00Z", 0),
+		},
+		wantReason:        ReasonPending,
+		wantStatus:        corev1.ConditionUnknown,
+		wantKey:           "label/environment=prod",
+		wantQueuePosition: 2,
+	}, {
+		name: "cancelled when the limit is reached",
+		policies: `- scope: Namespace
+  maxRuns: 1
+  strategy: CancelNewest`,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+		},
+		wantReason: ReasonConcurrencyLimitReached,
+		wantStatus: corev1.ConditionFalse,
+		wantKey:    "namespace",
+	}, {
+		name: "oldest running PipelineRun cancelled to make room",
+		policies: `- scope: Pipeline
+  maxRuns: 2
+  strategy: CancelOldest`,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+			runningPipelineRun("running-2", "2021-12-31T00:00:00Z"),
+		},
+		wantReason:    v1beta1.PipelineRunReasonRunning.String(),
+		wantStatus:    corev1.ConditionUnknown,
+		wantKey:       "pipeline/test-pipeline",
+		wantCancelled: []string{"running-2"},
+	}, {
+		name: "queued by the policy of its namespace",
+		policies: `- scope: Namespace
+  maxRuns: 5
+- namespace: foo
+  scope: Namespace
+  maxRuns: 1`,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+		},
+		wantReason:        ReasonPending,
+		wantStatus:        corev1.ConditionUnknown,
+		wantKey:           "namespace",
+		wantQueuePosition: 1,
+	}, {
+		name: "not limited by the policy of another namespace",
+		policies: `- namespace: bar
+  scope: Namespace
+  maxRuns: 1`,
+		others: []*v1beta1.PipelineRun{
+			runningPipelineRun("running-1", "2022-01-01T00:00:00Z"),
+		},
+		wantReason: v1beta1.PipelineRunReasonRunning.String(),
+		wantStatus: corev1.ConditionUnknown,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := append([]*v1beta1.PipelineRun{
+				queuedPipelineRun("test-pipeline-run-concurrency", "2022-01-01T00:00:00Z", tc.priority),
+			}, tc.others...)
+			defaults := newDefaultsConfigMap()
+			defaults.Data["default-concurrency-policies"] = tc.policies
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), defaults},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-concurrency", []string{}, false)
+			checkPipelineRunConditionStatusAndReason(t, reconciledRun, tc.wantStatus, tc.wantReason)
+
+			var gotKey string
+			var gotQueuePosition int
+			if reconciledRun.Status.Concurrency != nil {
+				gotKey, gotQueuePosition = reconciledRun.Status.Concurrency.Key, reconciledRun.Status.Concurrency.QueuePosition
+			}
+			if d := cmp.Diff(tc.wantKey, gotKey); d != "" {
+				t.Errorf("Unexpected concurrency key %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantQueuePosition, gotQueuePosition); d != "" {
+				t.Errorf("Unexpected queue position %s", diff.PrintWantGot(d))
+			}
+			if tc.wantReason == ReasonPending && reconciledRun.Status.StartTime != nil {
+				t.Errorf("Start time should be nil, not: %s", reconciledRun.Status.StartTime)
+			}
+
+			var cancelled []string
+			for _, other := range tc.others {
+				pr, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, other.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Failed to get PipelineRun %s: %v", other.Name, err)
+				}
+				if pr.IsCancelled() {
+					cancelled = append(cancelled, pr.Name)
+				}
+			}
+			if d := cmp.Diff(tc.wantCancelled, cancelled); d != "" {
+				t.Errorf("Unexpected cancelled PipelineRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithConcurrencyCancelledWhileQueued(t *testing.T) {
+	// A queued PipelineRun which is cancelled or stopped leaves the queue and ends
+	// through the normal cancellation path instead of being queued again.
+	for _, specStatus := range []v1beta1.PipelineRunSpecStatus{
+		v1beta1.PipelineRunSpecStatusCancelled,
+		v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+	} {
+		t.Run(string(specStatus), func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-queued
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  status: %s
+status:
+  concurrency:
+    key: namespace
+    queuePosition: 1
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: PipelineRunPending
+`, specStatus)), parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-running
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+`)}
+			defaults := newDefaultsConfigMap()
+			defaults.Data["default-concurrency-policies"] = "- scope: Namespace\n  maxRuns: 1"
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), defaults},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			wantEvents := []string{
+				"Normal Started",
+				"Warning Failed PipelineRun \"test-pipeline-run-queued\" was cancelled",
+			}
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-queued", wantEvents, false)
+			checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonCancelled)
+			if reconciledRun.Status.CompletionTime == nil {
+				t.Errorf("Expected a CompletionTime on the cancelled PipelineRun but was nil")
+			}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+					t.Errorf("Expected no TaskRun to be created, got %v", a)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcileWithTimeoutDeprecated(t *testing.T) {
 	// TestReconcileWithTimeoutDeprecated runs "Reconcile" on a PipelineRun that has timed out.
 	// It verifies that reconcile is successful, no TaskRun is created, the PipelineTask is marked as skipped, and the
@@ -3420,14 +3681,860 @@ status:
 
 			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-retry-run-with-timeout", []string{}, false)
 
-			if len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus) != tc.retries {
-				t.Fatalf(" %d retries expected but got %d ", tc.retries, len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus))
-			}
+			if len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus) != tc.retries {
+				t.Fatalf(" %d retries expected but got %d ", tc.retries, len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus))
+			}
+
+			if status := reconciledRun.Status.TaskRuns["hello-world-1"].Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.conditionSucceeded {
+				t.Fatalf("Succeeded expected to be %s but is %s", tc.conditionSucceeded, status)
+			}
+		})
+	}
+}
+
+// TestReconcileWithRetryPolicy tests that a failed PipelineTask with a retryPolicy is only retried
+// for the allowed failure reasons, and once the backoff has elapsed.
+func TestReconcileWithRetryPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		reason         string
+		completionTime string
+		wantRetries    int
+		wantSucceeded  corev1.ConditionStatus
+	}{{
+		name:           "backoff elapsed",
+		reason:         "TaskRunTimeout",
+		completionTime: "2021-12-31T23:30:00Z",
+		wantRetries:    1,
+		wantSucceeded:  corev1.ConditionUnknown,
+	}, {
+		name:           "backoff not elapsed",
+		reason:         "TaskRunTimeout",
+		completionTime: "2021-12-31T23:59:55Z",
+		wantRetries:    0,
+		wantSucceeded:  corev1.ConditionFalse,
+	}, {
+		name:           "failure reason not retried",
+		reason:         "Failed",
+		completionTime: "2021-12-31T23:30:00Z",
+		wantRetries:    0,
+		wantSucceeded:  corev1.ConditionFalse,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline-retry
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    retries: 2
+    retryPolicy:
+      backoff: 1m
+      retryOn:
+      - TaskRunTimeout
+    taskRef:
+      name: hello-world
+`)}
+			prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-retry-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline-retry
+  serviceAccountName: test-sa
+  timeout: 12h0m0s
+status:
+  startTime: "2021-12-31T23:00:00Z"
+`)}
+			ts := []*v1beta1.Task{simpleHelloWorldTask}
+			trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("test-pipeline-retry-run-hello-world-1", "foo",
+					"test-pipeline-retry-run", "test-pipeline-retry", "hello-world-1", false),
+				fmt.Sprintf(`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: %s
+  completionTime: %q
+`, tc.reason, tc.completionTime))}
+			prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+				trs[0].Name: {
+					PipelineTaskName: "hello-world-1",
+					Status:           &trs[0].Status,
+				},
+			}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "test-pipeline-retry-run", []string{}, false)
+
+			tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, trs[0].Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get TaskRun %s: %v", trs[0].Name, err)
+			}
+			if len(tr.Status.RetriesStatus) != tc.wantRetries {
+				t.Errorf("%d retries expected but got %d", tc.wantRetries, len(tr.Status.RetriesStatus))
+			}
+			if status := tr.Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.wantSucceeded {
+				t.Errorf("Succeeded expected to be %s but is %s", tc.wantSucceeded, status)
+			}
+		})
+	}
+}
+
+func TestReconcileResumeFrom(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline-resume
+  namespace: foo
+spec:
+  params:
+  - name: lint
+    type: string
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world
+  - name: lint
+    when:
+    - input: $(params.lint)
+      operator: in
+      values: ["true"]
+    taskRef:
+      name: hello-world
+  - name: test
+    runAfter: [build]
+    taskRef:
+      name: hello-world
+`)}
+	original := parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-resume-run-1
+  namespace: foo
+  uid: original-uid
+spec:
+  pipelineRef:
+    name: test-pipeline-resume
+  params:
+  - name: lint
+    value: "false"
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: Failed
+  pipelineSpec:
+    params:
+    - name: lint
+      type: string
+    tasks:
+    - name: build
+      taskRef:
+        name: hello-world
+        kind: Task
+    - name: lint
+      when:
+      - input: "false"
+        operator: in
+        values: ["true"]
+      taskRef:
+        name: hello-world
+        kind: Task
+    - name: test
+      runAfter: [build]
+      taskRef:
+        name: hello-world
+        kind: Task
+  skippedTasks:
+  - name: lint
+    reason: When Expressions evaluated to false
+`)
+	trs := []*v1beta1.TaskRun{
+		mustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta("test-pipeline-resume-run-1-build", "foo", "test-pipeline-resume-run-1", "test-pipeline-resume", "build", false), `
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+`),
+		mustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta("test-pipeline-resume-run-1-test", "foo", "test-pipeline-resume-run-1", "test-pipeline-resume", "test", false), `
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+`),
+	}
+	original.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+		trs[0].Name: {PipelineTaskName: "build", Status: &trs[0].Status},
+		trs[1].Name: {PipelineTaskName: "test", Status: &trs[1].Status},
+	}
+	resumed := parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-resume-run-2
+  namespace: foo
+  uid: resumed-uid
+spec:
+  pipelineRef:
+    name: test-pipeline-resume
+  params:
+  - name: lint
+    value: "false"
+  resumeFrom: test-pipeline-resume-run-1
+`)
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{original, resumed},
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-resume-run-2", []string{}, false)
+
+	wantResumedFrom := &v1beta1.ResumedFrom{
+		Name:                "test-pipeline-resume-run-1",
+		UID:                 "original-uid",
+		ReusedPipelineTasks: []string{"build"},
+		SkippedTasks:        []v1beta1.SkippedTask{{Name: "lint", Reason: v1beta1.WhenExpressionsSkip}},
+	}
+	if reconciledRun.Status.Provenance == nil {
+		t.Fatalf("Expected the provenance of the PipelineRun to be set")
+	}
+	if d := cmp.Diff(wantResumedFrom, reconciledRun.Status.Provenance.ResumedFrom); d != "" {
+		t.Errorf("Unexpected resumedFrom %s", diff.PrintWantGot(d))
+	}
+
+	// Only the failed PipelineTask is run again: build is reused and lint stays skipped.
+	var created []string
+	for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions(), 2) {
+		created = append(created, tr.Labels[pipeline.PipelineTaskLabelKey])
+	}
+	if d := cmp.Diff([]string{"test"}, created); d != "" {
+		t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+	}
+	if _, ok := reconciledRun.Status.TaskRuns["test-pipeline-resume-run-1-build"]; !ok {
+		t.Errorf("Expected the TaskRun of build to be reused, got %v", reconciledRun.Status.TaskRuns)
+	}
+	wantSkipped := []v1beta1.SkippedTask{{Name: "lint", Reason: v1beta1.WhenExpressionsSkip}}
+	if d := cmp.Diff(wantSkipped, reconciledRun.Status.SkippedTasks, cmpopts.IgnoreFields(v1beta1.SkippedTask{}, "WhenExpressions")); d != "" {
+		t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+	}
+
+	// The reused TaskRun is owned by both PipelineRuns, and still controlled by the original one.
+	reused, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-resume-run-1-build", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the reused TaskRun: %v", err)
+	}
+	wantOwners := append(trs[0].OwnerReferences, metav1.OwnerReference{
+		APIVersion: "tekton.dev/v1beta1",
+		Kind:       "PipelineRun",
+		Name:       "test-pipeline-resume-run-2",
+		UID:        "resumed-uid",
+	})
+	if d := cmp.Diff(wantOwners, reused.OwnerReferences); d != "" {
+		t.Errorf("Unexpected owners of the reused TaskRun %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileResumeFromDifferentSpecOrParams(t *testing.T) {
+	// A PipelineRun can't resume a PipelineRun which ran a different Pipeline spec or params.
+	for _, tc := range []struct {
+		name         string
+		originalTask string
+		params       string
+	}{{
+		name:         "different params",
+		originalTask: "hello-world-1",
+		params:       "v2",
+	}, {
+		name:         "different spec",
+		originalTask: "hello-world-0",
+		params:       "v1",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			original := parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-1
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  params:
+  - name: version
+    value: v1
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: Failed
+  pipelineSpec:
+    tasks:
+    - name: %s
+      taskRef:
+        name: hello-world
+        kind: Task
+`, tc.originalTask))
+			resumed := parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-2
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  params:
+  - name: version
+    value: %s
+  resumeFrom: test-pipeline-run-1
+`, tc.params))
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{original, resumed},
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-2", []string{}, true)
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if !condition.IsFalse() || condition.Reason != ReasonCouldntResume {
+				t.Errorf("Expected the PipelineRun to fail with reason %s, got %v", ReasonCouldntResume, condition)
+			}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+					t.Errorf("Expected no TaskRun to be created, got %v", a)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline-dry-run
+  namespace: foo
+spec:
+  params:
+  - name: lint
+    type: string
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world
+  - name: lint
+    when:
+    - input: $(params.lint)
+      operator: in
+      values: ["true"]
+    taskRef:
+      name: hello-world
+  - name: test
+    runAfter: [build]
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-dry-run-1
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline-dry-run
+  params:
+  - name: lint
+    value: "false"
+  status: DryRun
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Succeeded Tasks to run: 2, Skipped: 1, Depending on results: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-dry-run-1", wantEvents, false)
+
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionTrue, ReasonDryRunCompleted)
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created in a dry run, got %v", a)
+		}
+	}
+	taskRef := &v1beta1.TaskRef{Name: "hello-world", Kind: v1beta1.NamespacedTaskKind}
+	wantPlan := &v1beta1.PipelineRunPlan{
+		Tasks: []v1beta1.PlannedPipelineTask{{
+			Name:    "build",
+			TaskRef: taskRef,
+			Outcome: v1beta1.PlannedOutcomeRun,
+		}, {
+			Name:           "lint",
+			TaskRef:        taskRef,
+			Outcome:        v1beta1.PlannedOutcomeSkip,
+			SkippingReason: v1beta1.WhenExpressionsSkip,
+		}, {
+			Name:     "test",
+			TaskRef:  taskRef,
+			RunAfter: []string{"build"},
+			Outcome:  v1beta1.PlannedOutcomeRun,
+		}},
+	}
+	if d := cmp.Diff(wantPlan, reconciledRun.Status.Plan); d != "" {
+		t.Errorf("Unexpected plan %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileDryRunWithoutAlphaAPIFields(t *testing.T) {
+	// A PipelineRun in DryRun spec status fails instead of running its PipelineTasks when
+	// the alpha API fields were disabled after it was created.
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-dry-run-1
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  status: DryRun
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed PipelineRun foo/test-pipeline-dry-run-1 can't be run",
+		"Warning InternalError 1 error occurred",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-dry-run-1", wantEvents, true)
+
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonDryRunNotEnabled)
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created, got %v", a)
+		}
+	}
+}
+
+func TestReconcileWithCache(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline-cache
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    cache:
+      inputs: ["sha256:abc"]
+    taskRef:
+      name: hello-world
+  - name: lint
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-cache-run-1
+  namespace: foo
+  uid: test-pipeline-cache-run-1-uid
+spec:
+  pipelineRef:
+    name: test-pipeline-cache
+`), parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-cache-run-2
+  namespace: foo
+  uid: test-pipeline-cache-run-2-uid
+spec:
+  pipelineRef:
+    name: test-pipeline-cache
+`)}
+	d := test.Data{
+		PipelineRuns: prs[:1],
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	// The first PipelineRun misses the cache and labels the TaskRun of build with its cache key.
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-cache-run-1", []string{}, false)
+	if len(reconciledRun.Status.CachedTasks) != 0 {
+		t.Errorf("Expected no cached tasks but got %v", reconciledRun.Status.CachedTasks)
+	}
+	var cached *v1beta1.TaskRun
+	for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions(), 2) {
+		if tr.Labels[pipeline.PipelineTaskLabelKey] == "build" {
+			cached = tr
+		} else if _, ok := tr.Labels[pipeline.CacheKeyLabelKey]; ok {
+			t.Errorf("Expected no cache key on the TaskRun of %s", tr.Labels[pipeline.PipelineTaskLabelKey])
+		}
+	}
+	if cached == nil || cached.Labels[pipeline.CacheKeyLabelKey] == "" || cached.Annotations[pipeline.CacheContextAnnotationKey] == "" {
+		t.Fatalf("Expected the TaskRun of build to have a cache key and a cache context, got %v", cached)
+	}
+	key := cached.Labels[pipeline.CacheKeyLabelKey]
+
+	// The second PipelineRun reuses the successful TaskRun of build.
+	cached.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	cached.Status.CompletionTime = &metav1.Time{Time: now}
+	d = test.Data{
+		PipelineRuns: prs[1:],
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     []*v1beta1.TaskRun{cached},
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt = newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients = prt.reconcileRun("foo", "test-pipeline-cache-run-2", []string{}, false)
+	wantCached := []v1beta1.CachedTask{{Name: "build", TaskRunName: cached.Name, CacheKey: key}}
+	if d := cmp.Diff(wantCached, reconciledRun.Status.CachedTasks); d != "" {
+		t.Errorf("Unexpected cached tasks %s", diff.PrintWantGot(d))
+	}
+	var created []string
+	for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions(), 1) {
+		created = append(created, tr.Labels[pipeline.PipelineTaskLabelKey])
+	}
+	if d := cmp.Diff([]string{"lint"}, created); d != "" {
+		t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+	}
+	if trs, ok := reconciledRun.Status.TaskRuns[cached.Name]; !ok || trs.PipelineTaskName != "build" {
+		t.Errorf("Expected the TaskRun of build to be reused, got %v", reconciledRun.Status.TaskRuns)
+	}
+	reused, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, cached.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun %s: %v", cached.Name, err)
+	}
+	var owners []string
+	for _, owner := range reused.OwnerReferences {
+		owners = append(owners, owner.Name)
+	}
+	if d := cmp.Diff([]string{"test-pipeline-cache-run-1", "test-pipeline-cache-run-2"}, owners); d != "" {
+		t.Errorf("Expected the reused TaskRun to be co-owned by the PipelineRun reusing it %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithLoop(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline-loop
+  namespace: foo
+spec:
+  tasks:
+  - name: poll
+    loop:
+      until:
+      - input: $(tasks.poll.results.status)
+        operator: in
+        values: ["healthy"]
+      maxIterations: 2
+    taskRef:
+      name: hello-world
+`)}
+	pr := parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-loop-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline-loop
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+    reason: Running
+  taskRuns:
+    test-pipeline-loop-run-poll:
+      pipelineTaskName: poll
+`)
+	iteration := func(name, status string) *v1beta1.TaskRun {
+		return mustParseTaskRunWithObjectMeta(t, taskRunObjectMeta(name, "foo", "test-pipeline-loop-run", "test-pipeline-loop", "poll", false), `
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: status
+    value: `+status+`
+`)
+	}
+
+	// The until condition doesn't hold after the first iteration, so a second one is created.
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     []*v1beta1.TaskRun{iteration("test-pipeline-loop-run-poll", "starting")},
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-loop-run", []string{}, false)
+	created := getTaskRunCreations(t, clients.Pipeline.Actions(), 1)
+	if len(created) != 1 || created[0].Name != "test-pipeline-loop-run-poll-1" || created[0].Labels[pipeline.LoopIterationLabelKey] != "1" {
+		t.Fatalf("Expected the TaskRun of the second iteration to be created, got %v", created)
+	}
+	for _, name := range []string{"test-pipeline-loop-run-poll", "test-pipeline-loop-run-poll-1"} {
+		if trs, ok := reconciledRun.Status.TaskRuns[name]; !ok || trs.PipelineTaskName != "poll" {
+			t.Errorf("Expected TaskRun %s of poll in the status, got %v", name, reconciledRun.Status.TaskRuns)
+		}
+	}
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+
+	// The until condition still doesn't hold after the last iteration, so the PipelineRun fails.
+	pr = reconciledRun
+	d = test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     []*v1beta1.TaskRun{iteration("test-pipeline-loop-run-poll", "starting"), iteration("test-pipeline-loop-run-poll-1", "starting")},
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt = newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients = prt.reconcileRun("foo", "test-pipeline-loop-run", []string{"Normal Started", "Warning Failed Tasks Completed: 1 \\(Failed: 1, Cancelled 0\\), Skipped: 0"}, false)
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created after the last iteration, got %v", a)
+		}
+	}
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, v1beta1.PipelineRunReasonFailed.String())
+}
+
+func TestReconcileWithTracing(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetTracingConfigName()},
+			Data:       map[string]string{"tracing.enabled": "true"},
+		}},
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := initializePipelineRunControllerAssetsWithContext(tracing.WithExporter(ctx, exporter), t, d, pipeline.Options{Images: images})
+	prt := &PipelineRunTest{Data: d, Test: t, TestAssets: testAssets, Cancel: cancel}
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
+	if _, ok := reconciledRun.Annotations[tracing.PipelineRunSpanContextAnnotation]; !ok {
+		t.Errorf("expected the span context of the PipelineRun to be stored in its annotations but got %v", reconciledRun.Annotations)
+	}
+
+	traceID := trace.SpanContextFromContext(tracing.ContextWithParentSpan(context.Background(), &reconciledRun.ObjectMeta, tracing.PipelineRunSpanContextAnnotation)).TraceID()
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	if _, ok := spans["PipelineRun"]; ok {
+		t.Errorf("expected the PipelineRun span not to be exported before the PipelineRun is done")
+	}
+	for _, name := range []string{"PipelineRun:ReconcileKind", "reconcile", "resolvePipelineState", "runNextSchedulableTask", "createTaskRun"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("expected span %s to be exported", name)
+		} else if span.SpanContext.TraceID() != traceID {
+			t.Errorf("expected span %s to be part of the trace of the PipelineRun", name)
+		}
+	}
+
+	// The TaskRun is traced as part of the span of its PipelineTask
+	created := getTaskRunCreations(t, clients.Pipeline.Actions(), 1)
+	want := &metav1.ObjectMeta{}
+	tracing.InjectPipelineTaskSpanContext(&reconciledRun.ObjectMeta, "hello-world-1", want)
+	if got := created[0].Annotations[tracing.PipelineRunSpanContextAnnotation]; got != want.Annotations[tracing.PipelineRunSpanContextAnnotation] {
+		t.Errorf("expected the TaskRun to carry the span context %s of its PipelineTask but got %s", want.Annotations[tracing.PipelineRunSpanContextAnnotation], got)
+	}
+}
+
+func TestReconcileWithTracingOnCompletion(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-completed
+  namespace: foo
+  creationTimestamp: "2022-10-01T12:00:00Z"
+  annotations:
+    tekton.dev/pipelinerunSpanContext: '{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}'
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+status:
+  startTime: "2022-10-01T12:00:00Z"
+  conditions:
+  - reason: Running
+    status: Unknown
+    type: Succeeded
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipeline-run-completed-hello-world
+    pipelineTaskName: hello-world-1
+`)}
+	tr := createHelloWorldTaskRunWithStatusTaskLabel(t, "test-pipeline-run-completed-hello-world", "foo",
+		"test-pipeline-run-completed", "test-pipeline", "", "hello-world-1",
+		apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	tr.CreationTimestamp = metav1.Date(2022, time.October, 1, 12, 0, 1, 0, time.UTC)
+	tr.Status.CompletionTime = &metav1.Time{Time: time.Date(2022, time.October, 1, 12, 1, 0, 0, time.UTC)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     []*v1beta1.TaskRun{tr},
+		ConfigMaps: []*corev1.ConfigMap{
+			withEmbeddedStatus(newFeatureFlagsConfigMap(), config.MinimalEmbeddedStatus),
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetTracingConfigName()},
+				Data:       map[string]string{"tracing.enabled": "true"},
+			},
+		},
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := initializePipelineRunControllerAssetsWithContext(tracing.WithExporter(ctx, exporter), t, d, pipeline.Options{Images: images})
+	prt := &PipelineRunTest{Data: d, Test: t, TestAssets: testAssets, Cancel: cancel}
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Succeeded Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Skipped: 0",
+	}
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-completed", wantEvents, false)
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	root, ok := spans["PipelineRun"]
+	if !ok {
+		t.Fatalf("expected the PipelineRun span to be exported once the PipelineRun is done")
+	}
+	if root.SpanContext.SpanID().String() != "b7ad6b7169203331" || root.Parent.IsValid() {
+		t.Errorf("expected the PipelineRun span to be the root span stored in its annotations but got %v", root.SpanContext)
+	}
+	if !root.StartTime.Equal(reconciledRun.CreationTimestamp.Time) || !root.EndTime.Equal(reconciledRun.Status.CompletionTime.Time) {
+		t.Errorf("expected the PipelineRun span to last from its creation to its completion but got %v to %v", root.StartTime, root.EndTime)
+	}
+	pt, ok := spans["PipelineTask:hello-world-1"]
+	if !ok {
+		t.Fatalf("expected the span of the PipelineTask to be exported once the PipelineRun is done")
+	}
+	if pt.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Errorf("expected the PipelineTask span to be a child of the PipelineRun span")
+	}
+	if !pt.StartTime.Equal(tr.CreationTimestamp.Time) || !pt.EndTime.Equal(tr.Status.CompletionTime.Time) {
+		t.Errorf("expected the PipelineTask span to last from the creation to the completion of its TaskRun but got %v to %v", pt.StartTime, pt.EndTime)
+	}
+}
+
+func TestReconcileResumeFromRunningPipelineRun(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-1
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+    reason: Running
+`), parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-2
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  resumeFrom: test-pipeline-run-1
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-2", []string{}, true)
 
-			if status := reconciledRun.Status.TaskRuns["hello-world-1"].Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.conditionSucceeded {
-				t.Fatalf("Succeeded expected to be %s but is %s", tc.conditionSucceeded, status)
-			}
-		})
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonCouldntResume {
+		t.Errorf("Expected the PipelineRun to fail with reason %s, got %v", ReasonCouldntResume, condition)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created, got %v", a)
+		}
 	}
 }
 
@@ -3783,6 +4890,84 @@ spec:
 	}
 }
 
+func TestReconcileWithPipelineTaskEvents(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  params:
+  - name: run
+    type: string
+  tasks:
+  - name: a-task
+    taskRef:
+      name: a-task
+  - name: b-task
+    taskRef:
+      name: b-task
+    when:
+    - input: $(params.run)
+      operator: in
+      values:
+      - "yes"
+  - name: c-task
+    retries: 2
+    taskRef:
+      name: c-task
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-events
+  namespace: foo
+spec:
+  params:
+  - name: run
+    value: "no"
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1beta1.Task{
+		{ObjectMeta: baseObjectMeta("a-task", "foo")},
+		{ObjectMeta: baseObjectMeta("b-task", "foo")},
+		{ObjectMeta: baseObjectMeta("c-task", "foo")},
+	}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-events-c-task", "foo", "test-pipeline-run-events", "test-pipeline", "c-task", false),
+		`
+spec:
+  taskRef:
+    name: c-task
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: Failed
+`)}
+	cm := newFeatureFlagsConfigMap()
+	cm.Data["emit-pipelinetask-events"] = "true"
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{cm},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		`Normal PipelineTaskScheduled PipelineTask "a-task" scheduled as TaskRun "test-pipeline-run-events-a-task"`,
+		`Normal PipelineTaskRetried PipelineTask "c-task" retried as TaskRun "test-pipeline-run-events-c-task" failed with reason "Failed" \(retry 1 of 2\)`,
+		`Normal PipelineTaskSkipped PipelineTask "b-task" skipped: When Expressions evaluated to false \(when "no" in \["yes"\]\)`,
+		"Normal Running Tasks Completed: 0",
+	}
+	prt.reconcileRun("foo", "test-pipeline-run-events", wantEvents, false)
+}
+
 func TestReconcileWithWhenExpressions(t *testing.T) {
 	//		(b)
 	//		/
@@ -5395,6 +6580,114 @@ func TestReconcileWithPipelineResults_OnFailedPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcileWithOnErrorContinue(t *testing.T) {
+	// TestReconcileWithOnErrorContinue runs "Reconcile" on a PipelineRun where a PipelineTask with
+	// onError set to continue failed. It verifies that the PipelineTasks running after it are still
+	// scheduled, and that the ones consuming its missing results are skipped.
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: lint
+    onError: continue
+    taskRef:
+      name: a-task
+  - name: build
+    runAfter:
+    - lint
+    taskRef:
+      name: a-task
+  - name: report
+    params:
+    - name: bParam
+      value: $(tasks.lint.results.aResult)
+    taskRef:
+      name: b-task
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-on-error-lint", "foo",
+			"test-pipeline-run-on-error", "test-pipeline", "lint", false),
+		`
+spec:
+  resources: {}
+  serviceAccountName: test-sa
+  taskRef:
+    name: a-task
+  timeout: 1h0m0s
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: Failed
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run-on-error
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+status:
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+  startTime: "2022-01-01T00:00:00Z"
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipeline-run-on-error-lint
+    pipelineTaskName: lint
+`)}
+	ts := []*v1beta1.Task{
+		{ObjectMeta: baseObjectMeta("a-task", "foo")},
+		parse.MustParseTask(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  params:
+  - name: bParam
+    type: string
+`),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{withEmbeddedStatus(withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), config.MinimalEmbeddedStatus)},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-on-error", []string{}, false)
+
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=build,tekton.dev/pipelineRun=test-pipeline-run-on-error",
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRuns: %s", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Errorf("Expected the TaskRun of the PipelineTask running after the failed one to be created, got %d TaskRuns", len(taskRuns.Items))
+	}
+
+	wantSkippedTasks := []v1beta1.SkippedTask{{
+		Name:   "report",
+		Reason: v1beta1.MissingResultsSkip,
+	}}
+	if d := cmp.Diff(wantSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+	}
+}
+
 func runTestReconcileWithPipelineResultsOnFailedPipelineRun(t *testing.T, embeddedStatus string) {
 	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
@@ -9367,6 +10660,156 @@ spec:
 	}
 }
 
+func TestReconciler_PipelineTaskMatrixWithArrayResults(t *testing.T) {
+	names.TestingSeed()
+
+	task := parse.MustParseTask(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+    - name: service
+  steps:
+    - name: echo
+      image: alpine
+      script: |
+        echo "$(params.service)"
+`)
+	detectChanges := parse.MustParseTask(t, `
+metadata:
+  name: detect-changes
+  namespace: foo
+spec:
+  results:
+    - name: services
+      type: array
+  steps:
+    - name: echo
+      image: alpine
+      script: |
+        printf '["api", "web", "worker"]' | tee /tekton/results/services
+`)
+	p := parse.MustParsePipeline(t, `
+metadata:
+  name: p
+  namespace: foo
+spec:
+  tasks:
+    - name: detect-changes
+      taskRef:
+        name: detect-changes
+    - name: build
+      taskRef:
+        name: mytask
+      matrix:
+        params:
+          - name: service
+            value: $(tasks.detect-changes.results.services[*])
+`)
+
+	tests := []struct {
+		name                 string
+		services             []string
+		maxCombinationsCount int
+		wantTaskRuns         []string
+		wantCondition        *apis.Condition
+		permanentError       bool
+	}{{
+		name:                 "fans out to the resolved array result",
+		services:             []string{"api", "web", "worker"},
+		maxCombinationsCount: 10,
+		wantTaskRuns:         []string{"pr-build-0", "pr-build-1", "pr-build-2"},
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonRunning.String(),
+			Message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0",
+		},
+	}, {
+		name:                 "resolved array result exceeds the maximum combinations count",
+		services:             []string{"api", "web", "worker"},
+		maxCombinationsCount: 2,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonMaxMatrixCombinationsCountExceeded,
+			Message: `matrix of pipeline task "build" generates 3 combinations, which exceeds the maximum of 2`,
+		},
+		permanentError: true,
+	}, {
+		name:                 "skipped when the resolved array result is empty",
+		services:             []string{},
+		maxCombinationsCount: 10,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.PipelineRunReasonCompleted.String(),
+			Message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Skipped: 1",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := parse.MustParsePipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  pipelineRef:
+    name: p
+`)
+			tr := mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("pr-detect-changes", "foo", "pr", "p", "detect-changes", false),
+				`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: detect-changes
+status:
+  conditions:
+  - type: Succeeded
+    status: "True"
+    reason: Succeeded
+`)
+			tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{
+				Name:  "services",
+				Type:  v1beta1.ResultsTypeArray,
+				Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: tt.services},
+			}}
+			cms := []*corev1.ConfigMap{withEmbeddedStatus(withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), config.MinimalEmbeddedStatus)}
+			cms = append(cms, withMaxMatrixCombinationsCount(newDefaultsConfigMap(), tt.maxCombinationsCount))
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Pipelines:    []*v1beta1.Pipeline{p},
+				Tasks:        []*v1beta1.Task{task, detectChanges},
+				TaskRuns:     []*v1beta1.TaskRun{tr},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "pr", []string{}, tt.permanentError)
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: "tekton.dev/pipelineRun=pr,tekton.dev/pipelineTask=build",
+			})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRun's %s", err)
+			}
+			var gotTaskRuns []string
+			for _, tr := range taskRuns.Items {
+				gotTaskRuns = append(gotTaskRuns, tr.Name)
+			}
+			if d := cmp.Diff(tt.wantTaskRuns, gotTaskRuns); d != "" {
+				t.Errorf("TaskRuns diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.wantCondition, reconciledRun.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+				t.Errorf("Condition diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconciler_PipelineTaskMatrixWithRetries(t *testing.T) {
 	names.TestingSeed()
 
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// EventReasonPipelineTaskScheduled is the reason of the events emitted when a TaskRun or
+	// a Run is created for a PipelineTask
+	EventReasonPipelineTaskScheduled = "PipelineTaskScheduled"
+	// EventReasonPipelineTaskSkipped is the reason of the events emitted when a PipelineTask
+	// is skipped
+	EventReasonPipelineTaskSkipped = "PipelineTaskSkipped"
+	// EventReasonPipelineTaskRetried is the reason of the events emitted when the failed
+	// TaskRun of a PipelineTask is retried
+	EventReasonPipelineTaskRetried = "PipelineTaskRetried"
+	// EventReasonPipelineTaskTimedOut is the reason of the events emitted when a PipelineTask
+	// is cancelled because the tasks or finally timeout of the PipelineRun was reached
+	EventReasonPipelineTaskTimedOut = "PipelineTaskTimedOut"
+)
+
+// emitPipelineTaskEvent emits a Kubernetes event about a PipelineTask on its PipelineRun,
+// if the events about PipelineTasks are enabled.
+func emitPipelineTaskEvent(ctx context.Context, pr *v1beta1.PipelineRun, eventType, reason, messageFmt string, args ...interface{}) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EmitPipelineTaskEvents {
+		return
+	}
+	controller.GetEventRecorder(ctx).Eventf(pr, eventType, reason, messageFmt, args...)
+}
+
+// emitPipelineTaskScheduled emits an event for the TaskRun or Run of the given kind created
+// for a PipelineTask.
+func emitPipelineTaskScheduled(ctx context.Context, pr *v1beta1.PipelineRun, pipelineTask, kind, name string) {
+	emitPipelineTaskEvent(ctx, pr, corev1.EventTypeNormal, EventReasonPipelineTaskScheduled,
+		"PipelineTask %q scheduled as %s %q", pipelineTask, kind, name)
+}
+
+// emitPipelineTaskRetried emits an event for the retry of the TaskRun of a PipelineTask
+// which failed with the given reason.
+func emitPipelineTaskRetried(ctx context.Context, pr *v1beta1.PipelineRun, pipelineTask, taskRun, failureReason string, retry, retries int) {
+	emitPipelineTaskEvent(ctx, pr, corev1.EventTypeNormal, EventReasonPipelineTaskRetried,
+		"PipelineTask %q retried as TaskRun %q failed with reason %q (retry %d of %d)", pipelineTask, taskRun, failureReason, retry, retries)
+}
+
+// emitPipelineTasksSkipped emits an event for each PipelineTask skipped in the PipelineRun
+// which wasn't skipped before, along with the reason and the when expressions it was skipped for.
+func emitPipelineTasksSkipped(ctx context.Context, pr *v1beta1.PipelineRun, before, after []v1beta1.SkippedTask) {
+	skipped := sets.NewString()
+	for _, st := range before {
+		skipped.Insert(st.Name)
+	}
+	for _, st := range after {
+		if skipped.Has(st.Name) {
+			continue
+		}
+		message := fmt.Sprintf("PipelineTask %q skipped: %s", st.Name, st.Reason)
+		if len(st.WhenExpressions) > 0 {
+			message += fmt.Sprintf(" (when %s)", formatWhenExpressions(st.WhenExpressions))
+		}
+		emitPipelineTaskEvent(ctx, pr, corev1.EventTypeNormal, EventReasonPipelineTaskSkipped, "%s", message)
+	}
+}
+
+// emitPipelineTasksTimedOut emits an event for each PipelineTask cancelled once the tasks
+// or finally timeout of the PipelineRun was reached.
+func emitPipelineTasksTimedOut(ctx context.Context, pr *v1beta1.PipelineRun, pipelineTasks sets.String, timeoutName string, timeout time.Duration) {
+	for _, pipelineTask := range pipelineTasks.List() {
+		emitPipelineTaskEvent(ctx, pr, corev1.EventTypeWarning, EventReasonPipelineTaskTimedOut,
+			"PipelineTask %q timed out: the %s timeout of %s of the PipelineRun was reached", pipelineTask, timeoutName, timeout)
+	}
+}
+
+// formatWhenExpressions formats when expressions the way they read in a Pipeline,
+// e.g. `"$(params.branch)" in ["main"]`.
+func formatWhenExpressions(whenExpressions []v1beta1.WhenExpression) string {
+	var formatted []string
+	for _, we := range whenExpressions {
+		formatted = append(formatted, fmt.Sprintf("%q %s %q", we.Input, we.Operator, we.Values))
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	eventstest "github.com/tektoncd/pipeline/test/events"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+)
+
+func pipelineTaskEventsContext(enabled bool) (context.Context, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.FeatureFlags.EmitPipelineTaskEvents = enabled
+	return config.ToContext(ctx, cfg), recorder
+}
+
+func TestEmitPipelineTasksTimedOut(t *testing.T) {
+	ctx, recorder := pipelineTaskEventsContext(true)
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"}}
+
+	emitPipelineTasksTimedOut(ctx, pr, sets.NewString("b-task", "a-task"), "tasks", time.Hour)
+
+	wantEvents := []string{
+		`Warning PipelineTaskTimedOut PipelineTask "a-task" timed out: the tasks timeout of 1h0m0s of the PipelineRun was reached`,
+		`Warning PipelineTaskTimedOut PipelineTask "b-task" timed out: the tasks timeout of 1h0m0s of the PipelineRun was reached`,
+	}
+	if err := eventstest.CheckEventsOrdered(t, recorder.Events, t.Name(), wantEvents); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEmitPipelineTasksSkippedOnlyOnce(t *testing.T) {
+	ctx, recorder := pipelineTaskEventsContext(true)
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"}}
+	before := []v1beta1.SkippedTask{{Name: "a-task", Reason: v1beta1.StoppingSkip}}
+	after := []v1beta1.SkippedTask{before[0], {Name: "b-task", Reason: v1beta1.ParentTasksSkip}}
+
+	emitPipelineTasksSkipped(ctx, pr, before, after)
+
+	wantEvents := []string{
+		`Normal PipelineTaskSkipped PipelineTask "b-task" skipped: Parent Tasks were skipped`,
+	}
+	if err := eventstest.CheckEventsOrdered(t, recorder.Events, t.Name(), wantEvents); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEmitPipelineTaskEventDisabled(t *testing.T) {
+	ctx, recorder := pipelineTaskEventsContext(false)
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"}}
+
+	emitPipelineTaskScheduled(ctx, pr, "a-task", "TaskRun", "pr-a-task")
+
+	if len(recorder.Events) != 0 {
+		t.Errorf("Expected no event when the PipelineTask events are disabled, got %q", <-recorder.Events)
+	}
+}
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -179,9 +180,12 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
 			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, arrayReplacements, objectReplacements)
 			if pipelineTask.IsMatrixed() {
-				pipelineTask.Matrix.Params = replaceParamValues(pipelineTask.Matrix.Params, stringReplacements, nil, nil)
+				pipelineTask.Matrix.Params = replaceParamValues(pipelineTask.Matrix.Params, stringReplacements, arrayReplacements, nil)
 			}
 			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements, arrayReplacements)
+			if pipelineTask.Cache != nil {
+				pipelineTask.Cache.Inputs = replaceCacheInputs(pipelineTask.Cache.Inputs, stringReplacements)
+			}
 			if pipelineTask.TaskRef != nil && pipelineTask.TaskRef.Params != nil {
 				pipelineTask.TaskRef.Params = replaceParamValues(pipelineTask.TaskRef.Params, stringReplacements, arrayReplacements, objectReplacements)
 			}
@@ -234,6 +238,9 @@ func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string,
 			p.Tasks[i].Workspaces[j].SubPath = substitution.ApplyReplacements(p.Tasks[i].Workspaces[j].SubPath, replacements)
 		}
 		p.Tasks[i].WhenExpressions = p.Tasks[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
+		if p.Tasks[i].Cache != nil {
+			p.Tasks[i].Cache.Inputs = replaceCacheInputs(p.Tasks[i].Cache.Inputs, replacements)
+		}
 		if p.Tasks[i].TaskRef != nil && p.Tasks[i].TaskRef.Params != nil {
 			p.Tasks[i].TaskRef.Params = replaceParamValues(p.Tasks[i].TaskRef.Params, replacements, arrayReplacements, objectReplacements)
 		}
@@ -249,6 +256,9 @@ func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string,
 			p.Finally[i].Workspaces[j].SubPath = substitution.ApplyReplacements(p.Finally[i].Workspaces[j].SubPath, replacements)
 		}
 		p.Finally[i].WhenExpressions = p.Finally[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements, arrayReplacements)
+		if p.Finally[i].Cache != nil {
+			p.Finally[i].Cache.Inputs = replaceCacheInputs(p.Finally[i].Cache.Inputs, replacements)
+		}
 		if p.Finally[i].TaskRef != nil && p.Finally[i].TaskRef.Params != nil {
 			p.Finally[i].TaskRef.Params = replaceParamValues(p.Finally[i].TaskRef.Params, replacements, arrayReplacements, objectReplacements)
 		}
@@ -293,9 +303,18 @@ func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]st
 	return params
 }
 
+func replaceCacheInputs(inputs []string, replacements map[string]string) []string {
+	replaced := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		replaced = append(replaced, substitution.ApplyReplacements(input, replacements))
+	}
+	return replaced
+}
+
 // ApplyTaskResultsToPipelineResults applies the results of completed TasksRuns and Runs to a Pipeline's
 // list of PipelineResults, returning the computed set of PipelineRunResults. References to
-// non-existent TaskResults or failed TaskRuns or Runs result in a PipelineResult being considered invalid
+// non-existent TaskResults or failed TaskRuns or Runs, and references which don't match the type of
+// the referenced result or of the PipelineResult, result in a PipelineResult being considered invalid
 // and omitted from the returned slice. A nil slice is returned if no results are passed in or all
 // results are invalid.
 func ApplyTaskResultsToPipelineResults(
@@ -303,105 +322,156 @@ func ApplyTaskResultsToPipelineResults(
 	taskRunResults map[string][]v1beta1.TaskRunResult,
 	customTaskResults map[string][]v1alpha1.RunResult) ([]v1beta1.PipelineRunResult, error) {
 	var runResults []v1beta1.PipelineRunResult
-	var invalidPipelineResults []string
-	stringReplacements := map[string]string{}
-	arrayReplacements := map[string][]string{}
-	objectReplacements := map[string]map[string]string{}
+	var invalidPipelineResults, incompatiblePipelineResults []string
+	r := pipelineResultReplacements{
+		strings: map[string]string{},
+		arrays:  map[string][]string{},
+		objects: map[string]map[string]string{},
+	}
 	for _, pipelineResult := range results {
 		variablesInPipelineResult, _ := v1beta1.GetVarSubstitutionExpressionsForPipelineResult(pipelineResult)
 		if len(variablesInPipelineResult) == 0 {
 			continue
 		}
-		validPipelineResult := true
+		var err error
 		for _, variable := range variablesInPipelineResult {
-			if _, isMemoized := stringReplacements[variable]; isMemoized {
-				continue
-			}
-			if _, isMemoized := arrayReplacements[variable]; isMemoized {
-				continue
-			}
-			if _, isMemoized := objectReplacements[variable]; isMemoized {
-				continue
+			if err = r.resolve(pipelineResult, variable, taskRunResults, customTaskResults); err != nil {
+				break
 			}
-			variableParts := strings.Split(variable, ".")
-			if (variableParts[0] != v1beta1.ResultTaskPart && variableParts[0] != v1beta1.ResultFinallyPart) || variableParts[2] != v1beta1.ResultResultPart {
-				validPipelineResult = false
-				invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-				continue
-			}
-			switch len(variableParts) {
-			// For string result: tasks.<taskName>.results.<stringResultName>
-			// For array result: tasks.<taskName>.results.<arrayResultName>[*], tasks.<taskName>.results.<arrayResultName>[i]
-			// For object result: tasks.<taskName>.results.<objectResultName>[*],
-			case resultsParseNumber:
-				taskName, resultName := variableParts[1], variableParts[3]
-				resultName, stringIdx := v1beta1.ParseResultName(resultName)
-				if resultValue := taskResultValue(taskName, resultName, taskRunResults); resultValue != nil {
-					switch resultValue.Type {
-					case v1beta1.ParamTypeString:
-						stringReplacements[variable] = resultValue.StringVal
-					case v1beta1.ParamTypeArray:
-						if stringIdx != "*" {
-							intIdx, _ := strconv.Atoi(stringIdx)
-							if intIdx < len(resultValue.ArrayVal) {
-								stringReplacements[variable] = resultValue.ArrayVal[intIdx]
-							} else {
-								// referred array index out of bound
-								invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-								validPipelineResult = false
-							}
-						} else {
-							arrayReplacements[substitution.StripStarVarSubExpression(variable)] = resultValue.ArrayVal
-						}
-					case v1beta1.ParamTypeObject:
-						objectReplacements[substitution.StripStarVarSubExpression(variable)] = resultValue.ObjectVal
-					}
-				} else if resultValue := runResultValue(taskName, resultName, customTaskResults); resultValue != nil {
-					stringReplacements[variable] = *resultValue
-				} else {
-					// referred result name is not existent
-					invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-					validPipelineResult = false
-				}
-			// For object type result: tasks.<taskName>.results.<objectResultName>.<individualAttribute>
-			case objectElementResultsParseNumber:
-				taskName, resultName, objectKey := variableParts[1], variableParts[3], variableParts[4]
-				resultName, _ = v1beta1.ParseResultName(resultName)
-				if resultValue := taskResultValue(taskName, resultName, taskRunResults); resultValue != nil {
-					if _, ok := resultValue.ObjectVal[objectKey]; ok {
-						stringReplacements[variable] = resultValue.ObjectVal[objectKey]
-					} else {
-						// referred object key is not existent
-						invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-						validPipelineResult = false
-					}
-				} else {
-					// referred result name is not existent
-					invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-					validPipelineResult = false
-				}
-			default:
-				invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
-				validPipelineResult = false
+		}
+		finalValue := pipelineResult.Value
+		if err == nil {
+			finalValue.ApplyReplacements(r.strings, r.arrays, r.objects)
+			if pipelineResult.Type != "" && finalValue.Type != v1beta1.ParamType(pipelineResult.Type) {
+				err = errResultTypeMismatch
 			}
 		}
-		if validPipelineResult {
-			finalValue := pipelineResult.Value
-			finalValue.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
+		switch err {
+		case nil:
 			runResults = append(runResults, v1beta1.PipelineRunResult{
 				Name:  pipelineResult.Name,
 				Value: finalValue,
 			})
+		case errResultTypeMismatch:
+			incompatiblePipelineResults = append(incompatiblePipelineResults, pipelineResult.Name)
+		default:
+			invalidPipelineResults = append(invalidPipelineResults, pipelineResult.Name)
 		}
 	}
 
+	var errs []string
 	if len(invalidPipelineResults) > 0 {
-		return runResults, fmt.Errorf("invalid pipelineresults %v, the referred results don't exist", invalidPipelineResults)
+		errs = append(errs, fmt.Sprintf("invalid pipelineresults %v, the referred results don't exist", invalidPipelineResults))
+	}
+	if len(incompatiblePipelineResults) > 0 {
+		errs = append(errs, fmt.Sprintf("invalid pipelineresults %v, the referred results have incompatible types", incompatiblePipelineResults))
+	}
+	if len(errs) > 0 {
+		return runResults, errors.New(strings.Join(errs, "; "))
 	}
 
 	return runResults, nil
 }
 
+var (
+	errResultNotFound     = errors.New("the referred result doesn't exist")
+	errResultTypeMismatch = errors.New("the referred result has an incompatible type")
+)
+
+// pipelineResultReplacements holds the values of the result references found in PipelineResults.
+type pipelineResultReplacements struct {
+	strings map[string]string
+	arrays  map[string][]string
+	objects map[string]map[string]string
+}
+
+// resolve adds the value of the result referenced by variable in pipelineResult to the replacements.
+// It returns errResultTypeMismatch if the reference doesn't match the type of the referenced result,
+// or if a whole array or object result is not referenced where it can be substituted as a whole.
+func (r pipelineResultReplacements) resolve(pipelineResult v1beta1.PipelineResult, variable string,
+	taskRunResults map[string][]v1beta1.TaskRunResult, customTaskResults map[string][]v1alpha1.RunResult) error {
+	variableParts := strings.Split(variable, ".")
+	if len(variableParts) < resultsParseNumber || (variableParts[0] != v1beta1.ResultTaskPart && variableParts[0] != v1beta1.ResultFinallyPart) || variableParts[2] != v1beta1.ResultResultPart {
+		return errResultNotFound
+	}
+	taskName := variableParts[1]
+	resultName, stringIdx := v1beta1.ParseResultName(variableParts[3])
+	resultValue := taskResultValue(taskName, resultName, taskRunResults)
+
+	switch len(variableParts) {
+	// For string result: tasks.<taskName>.results.<stringResultName>
+	// For array result: tasks.<taskName>.results.<arrayResultName>[*], tasks.<taskName>.results.<arrayResultName>[i]
+	// For object result: tasks.<taskName>.results.<objectResultName>[*]
+	case resultsParseNumber:
+		if resultValue == nil {
+			// Results of custom tasks are always strings
+			if runValue := runResultValue(taskName, resultName, customTaskResults); runValue != nil {
+				if stringIdx != "" {
+					return errResultTypeMismatch
+				}
+				r.strings[variable] = *runValue
+				return nil
+			}
+			return errResultNotFound
+		}
+		switch {
+		case stringIdx == "" && (resultValue.Type == v1beta1.ParamTypeString || resultValue.Type == ""):
+			r.strings[variable] = resultValue.StringVal
+		case stringIdx == "*" && resultValue.Type == v1beta1.ParamTypeArray && isWholeValueReference(pipelineResult.Value, variable, v1beta1.ParamTypeArray):
+			r.arrays[substitution.StripStarVarSubExpression(variable)] = resultValue.ArrayVal
+		case stringIdx == "*" && resultValue.Type == v1beta1.ParamTypeObject && isWholeValueReference(pipelineResult.Value, variable, v1beta1.ParamTypeObject):
+			r.objects[substitution.StripStarVarSubExpression(variable)] = resultValue.ObjectVal
+		case stringIdx != "" && stringIdx != "*" && resultValue.Type == v1beta1.ParamTypeArray:
+			intIdx, _ := strconv.Atoi(stringIdx)
+			if intIdx >= len(resultValue.ArrayVal) {
+				// referred array index out of bound
+				return errResultNotFound
+			}
+			r.strings[variable] = resultValue.ArrayVal[intIdx]
+		default:
+			return errResultTypeMismatch
+		}
+	// For object type result: tasks.<taskName>.results.<objectResultName>.<individualAttribute>
+	case objectElementResultsParseNumber:
+		if resultValue == nil {
+			return errResultNotFound
+		}
+		if resultValue.Type != v1beta1.ParamTypeObject || stringIdx != "" {
+			return errResultTypeMismatch
+		}
+		value, ok := resultValue.ObjectVal[variableParts[4]]
+		if !ok {
+			// referred object key is not existent
+			return errResultNotFound
+		}
+		r.strings[variable] = value
+	default:
+		return errResultNotFound
+	}
+	return nil
+}
+
+// isWholeValueReference returns true if the whole array or object result referenced by variable
+// can be substituted in value: the value must be nothing but the reference, or an array result
+// can be one of the elements of an array value.
+func isWholeValueReference(value v1beta1.ResultValue, variable string, resultType v1beta1.ParamType) bool {
+	reference := fmt.Sprintf("$(%s)", variable)
+	switch value.Type {
+	case v1beta1.ParamTypeString:
+		return value.StringVal == reference
+	case v1beta1.ParamTypeArray:
+		if resultType != v1beta1.ParamTypeArray {
+			return false
+		}
+		for _, v := range value.ArrayVal {
+			if v == reference {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // taskResultValue returns the result value for a given pipeline task name and result name in a map of TaskRunResults for
 // pipeline task names. It returns nil if either the pipeline task name isn't present in the map, or if there is no
 // result with the result name in the pipeline task name's slice of results.
@@ -2077,6 +2077,39 @@ func TestApplyTaskResults_MinimalExpression(t *testing.T) {
 					}}},
 			},
 		}},
+	}, {
+		name: "Test whole array result substitution on minimal variable substitution expression - matrix",
+		resolvedResultRefs: ResolvedResultRefs{{
+			Value: *v1beta1.NewStructuredValues("arrayResultValueOne", "arrayResultValueTwo"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "aResult",
+				WholeArray:   true,
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Matrix: &v1beta1.Matrix{
+					Params: []v1beta1.Param{{
+						Name:  "bParam",
+						Value: *v1beta1.NewStructuredValues(`$(tasks.aTask.results.aResult[*])`),
+					}}},
+			},
+		}},
+		want: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Matrix: &v1beta1.Matrix{
+					Params: []v1beta1.Param{{
+						Name:  "bParam",
+						Value: *v1beta1.NewStructuredValues("arrayResultValueOne", "arrayResultValueTwo"),
+					}}},
+			},
+		}},
 	}, {
 		name: "Test array result substitution on minimal variable substitution expression - when expressions",
 		resolvedResultRefs: ResolvedResultRefs{{
@@ -3134,6 +3167,106 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 			Name:  "pipeline-result-2",
 			Value: *v1beta1.NewStructuredValues("do", "rae", "mi"),
 		}},
+	}, {
+		description: "whole-array-and-string-results-in-array-value",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.foo[*])", "$(tasks.pt2.results.bar)"),
+		}},
+		taskResults: map[string][]v1beta1.TaskRunResult{
+			"pt1": {{
+				Name:  "foo",
+				Value: *v1beta1.NewStructuredValues("do", "rae"),
+			}},
+			"pt2": {{
+				Name:  "bar",
+				Value: *v1beta1.NewStructuredValues("mi"),
+			}},
+		},
+		expectedResults: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: *v1beta1.NewStructuredValues("do", "rae", "mi"),
+		}},
+	}, {
+		description: "whole-object-result-from-finally-task",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Type:  v1beta1.ResultsTypeObject,
+			Value: *v1beta1.NewStructuredValues("$(finally.pt1.results.foo[*])"),
+		}},
+		taskResults: map[string][]v1beta1.TaskRunResult{
+			"pt1": {{
+				Name:  "foo",
+				Value: *v1beta1.NewObject(map[string]string{"key1": "val1", "key2": "val2"}),
+			}},
+		},
+		expectedResults: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: *v1beta1.NewObject(map[string]string{"key1": "val1", "key2": "val2"}),
+		}},
+	}, {
+		description: "object-keys-in-object-value",
+		results: []v1beta1.PipelineResult{{
+			Name: "pipeline-result-1",
+			Type: v1beta1.ResultsTypeObject,
+			Value: *v1beta1.NewObject(map[string]string{
+				"commit": "$(tasks.pt1.results.foo.commit)",
+				"url":    "$(tasks.pt1.results.foo.url)",
+			}),
+		}},
+		taskResults: map[string][]v1beta1.TaskRunResult{
+			"pt1": {{
+				Name:  "foo",
+				Value: *v1beta1.NewObject(map[string]string{"commit": "abc", "url": "https://example.com"}),
+			}},
+		},
+		expectedResults: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: *v1beta1.NewObject(map[string]string{"commit": "abc", "url": "https://example.com"}),
+		}},
+	}, {
+		description: "incompatible-result-references",
+		results: []v1beta1.PipelineResult{{
+			Name:  "array-without-index",
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.array)"),
+		}, {
+			Name:  "whole-array-in-string",
+			Value: *v1beta1.NewStructuredValues("values: $(tasks.pt1.results.array[*])"),
+		}, {
+			Name:  "whole-object-in-array",
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.object[*])", "$(tasks.pt1.results.string)"),
+		}, {
+			Name:  "indexed-object",
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.object[0])"),
+		}, {
+			Name:  "keyed-string",
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.string.key)"),
+		}, {
+			Name:  "string-declared-as-array",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.string)"),
+		}, {
+			Name:  "valid",
+			Value: *v1beta1.NewStructuredValues("$(tasks.pt1.results.array[1])"),
+		}},
+		taskResults: map[string][]v1beta1.TaskRunResult{
+			"pt1": {{
+				Name:  "array",
+				Value: *v1beta1.NewStructuredValues("do", "rae"),
+			}, {
+				Name:  "object",
+				Value: *v1beta1.NewObject(map[string]string{"key": "val"}),
+			}, {
+				Name:  "string",
+				Value: *v1beta1.NewStructuredValues("mi"),
+			}},
+		},
+		expectedResults: []v1beta1.PipelineRunResult{{
+			Name:  "valid",
+			Value: *v1beta1.NewStructuredValues("rae"),
+		}},
+		expectedError: fmt.Errorf("invalid pipelineresults [array-without-index whole-array-in-string whole-object-in-array indexed-object keyed-string string-declared-as-array], the referred results have incompatible types"),
 	}, {
 		description: "no-pipeline-results-no-returned-results",
 		results:     []v1beta1.PipelineResult{},
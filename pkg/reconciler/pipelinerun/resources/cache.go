@@ -0,0 +1,264 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// cacheKeyLength is the length of a cache key, truncated to fit in a label value.
+const cacheKeyLength = 63
+
+// IsCacheable returns true if the PipelineTask declares a cache and runs a single TaskRun.
+func (t ResolvedPipelineTask) IsCacheable() bool {
+	return t.PipelineTask != nil && t.PipelineTask.Cache != nil && !t.IsCustomTask() && !t.IsMatrixed() &&
+		t.ResolvedTaskResources != nil && t.ResolvedTaskResources.TaskSpec != nil
+}
+
+// CacheContext returns the digest of the inputs of the cache key of the PipelineTask which the
+// spec of its TaskRun doesn't hold: its resolved TaskSpec, its cache inputs, the workspace
+// bindings pr sets for it, and the digests of the files of its cached workspaces, by workspace
+// name. It must only be called once the results referenced by the PipelineTask are applied, and
+// returns an error if ValidateCacheWorkspaces does.
+func (t ResolvedPipelineTask) CacheContext(pr *v1beta1.PipelineRun, workspaceDigests map[string]string) (string, error) {
+	if err := t.ValidateCacheWorkspaces(pr); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(struct {
+		TaskSpec   *v1beta1.TaskSpec   `json:"taskSpec"`
+		Inputs     []string            `json:"inputs"`
+		Workspaces []cacheKeyWorkspace `json:"workspaces"`
+	}{
+		TaskSpec:   t.ResolvedTaskResources.TaskSpec,
+		Inputs:     t.PipelineTask.Cache.Inputs,
+		Workspaces: cacheKeyWorkspaces(t.PipelineTask, pr, workspaceDigests),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the cache context of pipeline task %q: %w", t.PipelineTask.Name, err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CacheKey returns the cache key of a TaskRun with spec, whose other inputs have the digest
+// cacheContext, as returned by CacheContext. The key is computed from the spec as the webhook
+// defaults it, so that the key of a TaskRun about to be created matches the one recomputed
+// from its spec once it is created.
+func CacheKey(ctx context.Context, spec *v1beta1.TaskRunSpec, cacheContext string) (string, error) {
+	spec = spec.DeepCopy()
+	spec.SetDefaults(ctx)
+	params := make([]v1beta1.Param, len(spec.Params))
+	copy(params, spec.Params)
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+
+	// The timeout and the workspaces of the TaskRun are left out: the timeout doesn't change what
+	// it runs, and the workspace bindings, which hold the names of the volumes created for the
+	// PipelineRun, are part of the cache context.
+	b, err := json.Marshal(struct {
+		TaskRef            *v1beta1.TaskRef                 `json:"taskRef,omitempty"`
+		TaskSpec           *v1beta1.TaskSpec                `json:"taskSpec,omitempty"`
+		Params             []v1beta1.Param                  `json:"params"`
+		ServiceAccountName string                           `json:"serviceAccountName"`
+		PodTemplate        *pod.Template                    `json:"podTemplate,omitempty"`
+		StepOverrides      []v1beta1.TaskRunStepOverride    `json:"stepOverrides,omitempty"`
+		SidecarOverrides   []v1beta1.TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+		ComputeResources   *corev1.ResourceRequirements     `json:"computeResources,omitempty"`
+		CacheContext       string                           `json:"cacheContext"`
+	}{
+		TaskRef:            spec.TaskRef,
+		TaskSpec:           spec.TaskSpec,
+		Params:             params,
+		ServiceAccountName: spec.ServiceAccountName,
+		PodTemplate:        spec.PodTemplate,
+		StepOverrides:      spec.StepOverrides,
+		SidecarOverrides:   spec.SidecarOverrides,
+		ComputeResources:   spec.ComputeResources,
+		CacheContext:       cacheContext,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the cache key: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:cacheKeyLength], nil
+}
+
+// ValidateCacheWorkspaces returns an error if the cache key of the PipelineTask wouldn't change
+// with the files of one of the workspaces pr binds for it. The files of a workspace listed in
+// cache.workspaces are part of the key, so it must be bound to a ConfigMap or a Secret, which
+// the controller can read. Any other workspace bound to something else than a ConfigMap, a
+// Secret or an emptyDir must be covered by cache.inputs, which must then reference a result of
+// a PipelineTask binding the same Pipeline workspace, such as the digest of its files.
+func (t ResolvedPipelineTask) ValidateCacheWorkspaces(pr *v1beta1.PipelineRun) error {
+	cached := sets.NewString(t.PipelineTask.Cache.Workspaces...)
+	var covered sets.String
+	for _, ws := range t.PipelineTask.Workspaces {
+		binding := PipelineRunWorkspaceBinding(pr, ws)
+		if binding == nil {
+			continue
+		}
+		if cached.Has(ws.Name) {
+			if binding.ConfigMap == nil && binding.Secret == nil {
+				return fmt.Errorf("cached workspace %q of pipeline task %q must be bound to a ConfigMap or a Secret", ws.Name, t.PipelineTask.Name)
+			}
+			continue
+		}
+		if binding.ConfigMap != nil || binding.Secret != nil || binding.EmptyDir != nil {
+			continue
+		}
+		if covered == nil {
+			covered = cacheInputWorkspaces(t.PipelineTask.Name, pr)
+		}
+		if !covered.Has(binding.Name) {
+			return fmt.Errorf("workspace %q of pipeline task %q must be listed in cache.workspaces, or cache.inputs must reference a result of a pipeline task using workspace %q", ws.Name, t.PipelineTask.Name, binding.Name)
+		}
+	}
+	return nil
+}
+
+// cacheInputWorkspaces returns the names of the Pipeline workspaces bound by the PipelineTasks
+// whose results the cache inputs of the PipelineTask named name reference, in the spec of the
+// Pipeline pr records in its status, before results are applied.
+func cacheInputWorkspaces(name string, pr *v1beta1.PipelineRun) sets.String {
+	workspaces := sets.NewString()
+	spec := pr.Status.PipelineSpec
+	if spec == nil {
+		return workspaces
+	}
+	tasks := append(append([]v1beta1.PipelineTask{}, spec.Tasks...), spec.Finally...)
+	var producers sets.String
+	for _, pt := range tasks {
+		if pt.Name == name && pt.Cache != nil {
+			producers = sets.NewString()
+			for _, ref := range v1beta1.PipelineTaskResultRefs(&v1beta1.PipelineTask{Cache: pt.Cache}) {
+				producers.Insert(ref.PipelineTask)
+			}
+		}
+	}
+	for _, pt := range tasks {
+		if !producers.Has(pt.Name) {
+			continue
+		}
+		for _, ws := range pt.Workspaces {
+			if ws.Workspace != "" {
+				workspaces.Insert(ws.Workspace)
+			} else {
+				workspaces.Insert(ws.Name)
+			}
+		}
+	}
+	return workspaces
+}
+
+// cacheKeyWorkspace is a workspace of a PipelineTask as part of its cache key.
+type cacheKeyWorkspace struct {
+	Name    string `json:"name"`
+	SubPath string `json:"subPath,omitempty"`
+	// Binding is the binding of the workspace in the PipelineRun, if any, without its
+	// name so that the key doesn't depend on the name of the Pipeline workspace.
+	Binding *v1beta1.WorkspaceBinding `json:"binding,omitempty"`
+	Digest  string                    `json:"digest,omitempty"`
+}
+
+func cacheKeyWorkspaces(pt *v1beta1.PipelineTask, pr *v1beta1.PipelineRun, digests map[string]string) []cacheKeyWorkspace {
+	workspaces := make([]cacheKeyWorkspace, 0, len(pt.Workspaces))
+	for _, ws := range pt.Workspaces {
+		w := cacheKeyWorkspace{Name: ws.Name, SubPath: ws.SubPath, Digest: digests[ws.Name]}
+		if binding := PipelineRunWorkspaceBinding(pr, ws); binding != nil {
+			w.Binding = binding.DeepCopy()
+			w.Binding.Name = ""
+		}
+		workspaces = append(workspaces, w)
+	}
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+	return workspaces
+}
+
+// PipelineRunWorkspaceBinding returns the binding pr sets for the workspace of a PipelineTask,
+// or nil if the workspace isn't bound.
+func PipelineRunWorkspaceBinding(pr *v1beta1.PipelineRun, ws v1beta1.WorkspacePipelineTaskBinding) *v1beta1.WorkspaceBinding {
+	name := ws.Workspace
+	if name == "" {
+		name = ws.Name
+	}
+	for i := range pr.Spec.Workspaces {
+		if pr.Spec.Workspaces[i].Name == name {
+			return &pr.Spec.Workspaces[i]
+		}
+	}
+	return nil
+}
+
+// FilesDigest returns the digest of files, by path, which doesn't depend on the order of files.
+func FilesDigest(files map[string][]byte) string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, path := range paths {
+		sum := sha256.Sum256(files[path])
+		fmt.Fprintf(h, "%s\x00%x\n", path, sum)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// FindCachedTaskRun returns the most recently completed TaskRun of taskRuns which can be reused
+// as the TaskRun of the PipelineTask named pipelineTask with the cache key key. It must have
+// been created by a PipelineRun for a PipelineTask of the same name, the cache key recomputed
+// from its spec must be key, and it must have succeeded less than maxAge before now if maxAge
+// is set. It returns nil if none of them can be reused.
+func FindCachedTaskRun(ctx context.Context, taskRuns []*v1beta1.TaskRun, pipelineTask, key string, maxAge *time.Duration, now time.Time) *v1beta1.TaskRun {
+	var cached *v1beta1.TaskRun
+	for _, tr := range taskRuns {
+		if !tr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() || tr.Status.CompletionTime == nil {
+			continue
+		}
+		if maxAge != nil && now.Sub(tr.Status.CompletionTime.Time) > *maxAge {
+			continue
+		}
+		if cached != nil && !tr.Status.CompletionTime.After(cached.Status.CompletionTime.Time) {
+			continue
+		}
+		if owner := metav1.GetControllerOf(tr); owner == nil || owner.Kind != pipeline.PipelineRunControllerName {
+			continue
+		}
+		if tr.Labels[pipeline.PipelineTaskLabelKey] != pipelineTask || tr.Labels[pipeline.CacheKeyLabelKey] != key {
+			continue
+		}
+		// The label could have been set by anyone able to create TaskRuns.
+		if k, err := CacheKey(ctx, &tr.Spec, tr.Annotations[pipeline.CacheContextAnnotationKey]); err != nil || k != key {
+			continue
+		}
+		cached = tr
+	}
+	return cached
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/kmeta"
+)
+
+func cacheableTask(inputs []string, params ...v1beta1.Param) ResolvedPipelineTask {
+	return ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:       "build",
+			TaskRef:    &v1beta1.TaskRef{Name: "build"},
+			Params:     params,
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "config", Workspace: "settings"}},
+			Cache:      &v1beta1.PipelineTaskCache{Inputs: inputs, Workspaces: []string{"config"}},
+		},
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: "build",
+			TaskSpec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "golang"}}},
+		},
+	}
+}
+
+func TestCacheContext(t *testing.T) {
+	pipelineRun := func() *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:      "settings",
+					ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}},
+				}},
+			},
+		}
+	}
+	digests := map[string]string{"config": "sha256:123"}
+	contextWith := func(rpt ResolvedPipelineTask, pr *v1beta1.PipelineRun, digests map[string]string) string {
+		t.Helper()
+		c, err := rpt.CacheContext(pr, digests)
+		if err != nil {
+			t.Fatalf("Unexpected error computing the cache context: %v", err)
+		}
+		return c
+	}
+	cacheContext := func(rpt ResolvedPipelineTask) string {
+		t.Helper()
+		return contextWith(rpt, pipelineRun(), digests)
+	}
+
+	want := cacheContext(cacheableTask([]string{"sha256:abc"}))
+	if got := cacheContext(cacheableTask([]string{"sha256:def"})); got == want {
+		t.Errorf("Expected the cache context to depend on the cache inputs")
+	}
+	changedSpec := cacheableTask([]string{"sha256:abc"})
+	changedSpec.ResolvedTaskResources.TaskSpec.Steps[0].Image = "golang:1.19"
+	if got := cacheContext(changedSpec); got == want {
+		t.Errorf("Expected the cache context to depend on the TaskSpec")
+	}
+
+	rpt := cacheableTask([]string{"sha256:abc"})
+	if got := contextWith(rpt, pipelineRun(), map[string]string{"config": "sha256:456"}); got == want {
+		t.Errorf("Expected the cache context to depend on the files of the cached workspaces")
+	}
+	otherBinding := pipelineRun()
+	otherBinding.Spec.Workspaces[0].ConfigMap.Name = "other-settings"
+	if got := contextWith(rpt, otherBinding, digests); got == want {
+		t.Errorf("Expected the cache context to depend on the workspace bindings")
+	}
+	otherMetadata := pipelineRun()
+	otherMetadata.Spec.TaskRunSpecs = []v1beta1.PipelineTaskRunSpec{{PipelineTaskName: "build", Metadata: &v1beta1.PipelineTaskMetadata{Labels: map[string]string{"team": "ci"}}}}
+	if got := contextWith(rpt, otherMetadata, digests); got != want {
+		t.Errorf("Expected the cache context not to depend on the metadata of the TaskRun, got %s and %s", got, want)
+	}
+}
+
+func cacheableTaskRunSpec(params ...v1beta1.Param) *v1beta1.TaskRunSpec {
+	return &v1beta1.TaskRunSpec{
+		TaskRef:            &v1beta1.TaskRef{Name: "build"},
+		Params:             params,
+		ServiceAccountName: "builder",
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	ctx := context.Background()
+	foo := v1beta1.Param{Name: "foo", Value: *v1beta1.NewStructuredValues("bar")}
+	baz := v1beta1.Param{Name: "baz", Value: *v1beta1.NewStructuredValues("qux")}
+	keyWith := func(spec *v1beta1.TaskRunSpec, cacheContext string) string {
+		t.Helper()
+		k, err := CacheKey(ctx, spec, cacheContext)
+		if err != nil {
+			t.Fatalf("Unexpected error computing the cache key: %v", err)
+		}
+		return k
+	}
+
+	want := keyWith(cacheableTaskRunSpec(foo, baz), "abc")
+	if len(want) != 63 {
+		t.Errorf("Expected a cache key of 63 characters but got %q", want)
+	}
+	if got := keyWith(cacheableTaskRunSpec(baz, foo), "abc"); got != want {
+		t.Errorf("Expected the cache key not to depend on the order of params, got %s and %s", got, want)
+	}
+	if got := keyWith(cacheableTaskRunSpec(foo), "abc"); got == want {
+		t.Errorf("Expected the cache key to depend on the params")
+	}
+	if got := keyWith(cacheableTaskRunSpec(foo, baz), "def"); got == want {
+		t.Errorf("Expected the cache key to depend on the cache context")
+	}
+	otherServiceAccount := cacheableTaskRunSpec(foo, baz)
+	otherServiceAccount.ServiceAccountName = "deployer"
+	if got := keyWith(otherServiceAccount, "abc"); got == want {
+		t.Errorf("Expected the cache key to depend on the service account of the TaskRun")
+	}
+	otherPodTemplate := cacheableTaskRunSpec(foo, baz)
+	otherPodTemplate.PodTemplate = &pod.Template{NodeSelector: map[string]string{"arch": "arm64"}}
+	if got := keyWith(otherPodTemplate, "abc"); got == want {
+		t.Errorf("Expected the cache key to depend on the pod template of the TaskRun")
+	}
+	otherTask := cacheableTaskRunSpec(foo, baz)
+	otherTask.TaskRef.Name = "lint"
+	if got := keyWith(otherTask, "abc"); got == want {
+		t.Errorf("Expected the cache key to depend on the Task of the TaskRun")
+	}
+	defaulted := cacheableTaskRunSpec(foo, baz)
+	defaulted.SetDefaults(ctx)
+	if got := keyWith(defaulted, "abc"); got != want {
+		t.Errorf("Expected the cache key not to change once the TaskRun is defaulted, got %s and %s", got, want)
+	}
+}
+
+func TestValidateCacheWorkspaces(t *testing.T) {
+	rpt := ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:       "build",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+			Cache:      &v1beta1.PipelineTaskCache{Inputs: []string{"sha256:abc"}},
+		},
+	}
+	pipelineSpec := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name:       "fetch",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "output", Workspace: "shared"}},
+		}, {
+			Name:       "lint",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "output", Workspace: "other"}},
+		}, {
+			Name:       "build",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+			Cache:      &v1beta1.PipelineTaskCache{Inputs: []string{"$(tasks.fetch.results.digest)"}},
+		}},
+	}
+	pvc := v1beta1.WorkspaceBinding{Name: "shared", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"}}
+	for _, tc := range []struct {
+		name         string
+		cached       []string
+		binding      v1beta1.WorkspaceBinding
+		pipelineSpec *v1beta1.PipelineSpec
+		wantErr      bool
+	}{{
+		name:    "cached workspace bound to a Secret",
+		cached:  []string{"source"},
+		binding: v1beta1.WorkspaceBinding{Name: "shared", Secret: &corev1.SecretVolumeSource{SecretName: "source"}},
+	}, {
+		name:    "cached workspace bound to an emptyDir",
+		cached:  []string{"source"},
+		binding: v1beta1.WorkspaceBinding{Name: "shared", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		wantErr: true,
+	}, {
+		name:    "workspace bound to a ConfigMap",
+		binding: v1beta1.WorkspaceBinding{Name: "shared", ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "source"}}},
+	}, {
+		name:    "workspace bound to an emptyDir",
+		binding: v1beta1.WorkspaceBinding{Name: "shared", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}, {
+		name:         "workspace covered by a result of a task using it",
+		binding:      pvc,
+		pipelineSpec: pipelineSpec,
+	}, {
+		name:    "workspace not covered",
+		binding: pvc,
+		wantErr: true,
+	}, {
+		name:    "workspace covered by a result of a task using another workspace",
+		binding: pvc,
+		pipelineSpec: &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{pipelineSpec.Tasks[1], {
+				Name:  "build",
+				Cache: &v1beta1.PipelineTaskCache{Inputs: []string{"$(tasks.lint.results.digest)"}},
+			}},
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rpt.PipelineTask.Cache.Workspaces = tc.cached
+			pr := &v1beta1.PipelineRun{
+				Spec:   v1beta1.PipelineRunSpec{Workspaces: []v1beta1.WorkspaceBinding{tc.binding}},
+				Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{PipelineSpec: tc.pipelineSpec}},
+			}
+			if err := rpt.ValidateCacheWorkspaces(pr); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateCacheWorkspaces() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilesDigest(t *testing.T) {
+	files := map[string][]byte{"a": []byte("foo"), "b/c": []byte("bar")}
+	want := FilesDigest(files)
+	if got := FilesDigest(map[string][]byte{"b/c": []byte("bar"), "a": []byte("foo")}); got != want {
+		t.Errorf("Expected the digest not to depend on the order of files, got %s and %s", got, want)
+	}
+	for _, other := range []map[string][]byte{
+		{"a": []byte("foo"), "b/c": []byte("baz")},
+		{"a": []byte("foo"), "b/d": []byte("bar")},
+		{"a": []byte("foo")},
+	} {
+		if got := FilesDigest(other); got == want {
+			t.Errorf("Expected the digest of %v to differ from %s", other, want)
+		}
+	}
+}
+
+func TestIsCacheable(t *testing.T) {
+	if !cacheableTask(nil).IsCacheable() {
+		t.Errorf("Expected a PipelineTask with a cache to be cacheable")
+	}
+	rpt := cacheableTask(nil)
+	rpt.PipelineTask.Cache = nil
+	if rpt.IsCacheable() {
+		t.Errorf("Expected a PipelineTask without a cache not to be cacheable")
+	}
+	rpt = cacheableTask(nil)
+	rpt.CustomTask = true
+	if rpt.IsCacheable() {
+		t.Errorf("Expected a custom task not to be cacheable")
+	}
+}
+
+func TestFindCachedTaskRun(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.December, 1, 12, 0, 0, 0, time.UTC)
+	key, err := CacheKey(ctx, cacheableTaskRunSpec(), "abc")
+	if err != nil {
+		t.Fatalf("Unexpected error computing the cache key: %v", err)
+	}
+	taskRun := func(name string, status corev1.ConditionStatus, completedAgo time.Duration) *v1beta1.TaskRun {
+		return &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(&v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr"}})},
+				Labels:          map[string]string{pipeline.PipelineTaskLabelKey: "build", pipeline.CacheKeyLabelKey: key},
+				Annotations:     map[string]string{pipeline.CacheContextAnnotationKey: "abc"},
+			},
+			Spec: *cacheableTaskRunSpec(),
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: status}}},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					CompletionTime: &metav1.Time{Time: now.Add(-completedAgo)},
+				},
+			},
+		}
+	}
+	recent := func(update func(tr *v1beta1.TaskRun)) *v1beta1.TaskRun {
+		tr := taskRun("recent", corev1.ConditionTrue, time.Minute)
+		update(tr)
+		return tr
+	}
+	hour := time.Hour
+	for _, tc := range []struct {
+		name     string
+		taskRuns []*v1beta1.TaskRun
+		maxAge   *time.Duration
+		want     string
+	}{{
+		name: "no TaskRuns",
+	}, {
+		name:     "most recent successful TaskRun",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, 3*time.Hour), taskRun("recent", corev1.ConditionTrue, 2*time.Hour), taskRun("failed", corev1.ConditionFalse, time.Minute)},
+		want:     "recent",
+	}, {
+		name:     "TaskRuns older than maxAge",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, 3*time.Hour), taskRun("recent", corev1.ConditionTrue, 30*time.Minute)},
+		maxAge:   &hour,
+		want:     "recent",
+	}, {
+		name:     "all TaskRuns older than maxAge",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, 3*time.Hour)},
+		maxAge:   &hour,
+	}, {
+		name:     "running TaskRun",
+		taskRuns: []*v1beta1.TaskRun{taskRun("running", corev1.ConditionUnknown, 0)},
+	}, {
+		name: "TaskRun not controlled by a PipelineRun",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, time.Hour), recent(func(tr *v1beta1.TaskRun) {
+			tr.OwnerReferences = nil
+		})},
+		want: "old",
+	}, {
+		name: "TaskRun of another pipeline task",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, time.Hour), recent(func(tr *v1beta1.TaskRun) {
+			tr.Labels[pipeline.PipelineTaskLabelKey] = "lint"
+		})},
+		want: "old",
+	}, {
+		name: "TaskRun whose spec doesn't match its cache key",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, time.Hour), recent(func(tr *v1beta1.TaskRun) {
+			tr.Spec.TaskRef.Name = "deploy"
+		})},
+		want: "old",
+	}, {
+		name: "TaskRun whose cache context doesn't match its cache key",
+		taskRuns: []*v1beta1.TaskRun{taskRun("old", corev1.ConditionTrue, time.Hour), recent(func(tr *v1beta1.TaskRun) {
+			tr.Annotations[pipeline.CacheContextAnnotationKey] = "def"
+		})},
+		want: "old",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FindCachedTaskRun(ctx, tc.taskRuns, "build", key, tc.maxAge, now)
+			switch {
+			case tc.want == "" && got != nil:
+				t.Errorf("Expected no cached TaskRun but got %s", got.Name)
+			case tc.want != "" && (got == nil || got.Name != tc.want):
+				t.Errorf("Expected cached TaskRun %s but got %v", tc.want, got)
+			}
+		})
+	}
+}
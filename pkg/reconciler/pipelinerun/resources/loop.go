@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/kmeta"
+)
+
+// IsLooped returns true if the PipelineTask declares a loop and runs a single TaskRun.
+func (t ResolvedPipelineTask) IsLooped() bool {
+	return t.PipelineTask != nil && t.PipelineTask.Loop != nil && !t.IsCustomTask() && !t.IsMatrixed()
+}
+
+// LoopIteration returns the iteration of the current TaskRun of a looped PipelineTask,
+// starting from 0.
+func (t ResolvedPipelineTask) LoopIteration() int {
+	return len(t.LoopTaskRuns)
+}
+
+// HasNextLoopIteration returns true if the current iteration of a looped PipelineTask
+// succeeded but its until condition doesn't hold yet, and it can run another iteration.
+func (t ResolvedPipelineTask) HasNextLoopIteration() bool {
+	return t.loopIterationPending() && t.LoopIteration()+1 < t.PipelineTask.Loop.MaxIterations
+}
+
+// isLoopInterrupted returns true if a looped PipelineTask of the DAG has a next iteration
+// to run, which the PipelineRun won't schedule since it's stopping, cancelled or stopped.
+func (t ResolvedPipelineTask) isLoopInterrupted(facts *PipelineRunFacts) bool {
+	if !facts.isDAGTask(t.PipelineTask.Name) || !t.HasNextLoopIteration() {
+		return false
+	}
+	return facts.IsStopping() || facts.IsCancelled() || facts.IsGracefullyCancelled() || facts.IsGracefullyStopped()
+}
+
+// isLoopExhausted returns true if the last iteration a looped PipelineTask is allowed to
+// run succeeded but its until condition still doesn't hold.
+func (t ResolvedPipelineTask) isLoopExhausted() bool {
+	return t.loopIterationPending() && t.LoopIteration()+1 >= t.PipelineTask.Loop.MaxIterations
+}
+
+func (t ResolvedPipelineTask) loopIterationPending() bool {
+	return t.IsLooped() && t.TaskRun.IsSuccessful() && !t.loopUntilHolds()
+}
+
+// loopUntilHolds returns true if the until condition of a looped PipelineTask holds for
+// the results of its current TaskRun.
+func (t ResolvedPipelineTask) loopUntilHolds() bool {
+	if t.TaskRun == nil {
+		return false
+	}
+	replacements := map[string]string{}
+	for _, result := range t.TaskRun.Status.TaskRunResults {
+		if result.Value.Type == v1beta1.ParamTypeString {
+			replacements[fmt.Sprintf("tasks.%s.results.%s", t.PipelineTask.Name, result.Name)] = result.Value.StringVal
+		}
+	}
+	until := make(v1beta1.WhenExpressions, len(t.PipelineTask.Loop.Until))
+	copy(until, t.PipelineTask.Loop.Until)
+	return until.ReplaceWhenExpressionsVariables(replacements, nil).AllowsExecution()
+}
+
+// LoopDelayRemaining returns how long to wait before the next iteration of a looped
+// PipelineTask can start according to its delay, or 0 if it can start now.
+func (t ResolvedPipelineTask) LoopDelayRemaining(c clock.PassiveClock) time.Duration {
+	if t.PipelineTask.Loop.Delay == nil || t.TaskRun.Status.CompletionTime == nil {
+		return 0
+	}
+	if wait := t.PipelineTask.Loop.Delay.Duration - c.Since(t.TaskRun.Status.CompletionTime.Time); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// StartNextLoopIteration moves the TaskRun of the current iteration of a looped PipelineTask
+// to its previous iterations and names the TaskRun of the next iteration.
+func (t *ResolvedPipelineTask) StartNextLoopIteration(prName string) {
+	t.LoopTaskRuns = append(t.LoopTaskRuns, t.TaskRun)
+	t.TaskRun = nil
+	t.TaskRunName = LoopTaskRunName(prName, t.PipelineTask.Name, t.LoopIteration())
+}
+
+// LoopTaskRunName returns the name of the TaskRun of the given iteration of a looped PipelineTask.
+// The first iteration is named like the TaskRun of a PipelineTask without a loop.
+func LoopTaskRunName(prName, ptName string, iteration int) string {
+	if iteration == 0 {
+		return kmeta.ChildName(prName, fmt.Sprintf("-%s", ptName))
+	}
+	return kmeta.ChildName(prName, fmt.Sprintf("-%s-%d", ptName, iteration))
+}
+
+// GetNamesOfLoopTaskRuns returns the names of the TaskRuns of the iterations of a looped
+// PipelineTask recorded in the PipelineRun status, in order, or the name of the TaskRun
+// of its first iteration if none is recorded yet.
+func GetNamesOfLoopTaskRuns(taskRunsStatus map[string]*v1beta1.PipelineRunTaskRunStatus, childRefs []v1beta1.ChildStatusReference, ptName, prName string) []string {
+	recorded := sets.NewString()
+	for _, cr := range childRefs {
+		if cr.Kind == pipeline.TaskRunControllerName && cr.PipelineTaskName == ptName {
+			recorded.Insert(cr.Name)
+		}
+	}
+	for k, v := range taskRunsStatus {
+		if v.PipelineTaskName == ptName {
+			recorded.Insert(k)
+		}
+	}
+
+	var taskRunNames []string
+	for i := 0; recorded.Has(LoopTaskRunName(prName, ptName, i)); i++ {
+		taskRunNames = append(taskRunNames, LoopTaskRunName(prName, ptName, i))
+	}
+	if len(taskRunNames) == 0 {
+		return []string{GetTaskRunName(taskRunsStatus, childRefs, ptName, prName)}
+	}
+	return taskRunNames
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func loopedTask(maxIterations int, iterations ...*v1beta1.TaskRun) ResolvedPipelineTask {
+	rpt := ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "poll",
+			TaskRef: &v1beta1.TaskRef{Name: "poll"},
+			Loop: &v1beta1.PipelineTaskLoop{
+				Until:         v1beta1.WhenExpressions{{Input: "$(tasks.poll.results.status)", Operator: selection.In, Values: []string{"healthy"}}},
+				MaxIterations: maxIterations,
+				Delay:         &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	if len(iterations) > 0 {
+		rpt.LoopTaskRuns = iterations[:len(iterations)-1]
+		rpt.TaskRun = iterations[len(iterations)-1]
+	}
+	return rpt
+}
+
+func withStatusResult(tr *v1beta1.TaskRun, status string) *v1beta1.TaskRun {
+	tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "status", Value: *v1beta1.NewStructuredValues(status)}}
+	return tr
+}
+
+func TestLoopIterations(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		rpt         ResolvedPipelineTask
+		wantNext    bool
+		wantSuccess bool
+		wantFailure bool
+	}{{
+		name: "not started",
+		rpt:  loopedTask(3),
+	}, {
+		name: "iteration running",
+		rpt:  loopedTask(3, makeStarted(trs[0])),
+	}, {
+		name:     "until doesn't hold",
+		rpt:      loopedTask(3, withStatusResult(makeSucceeded(trs[0]), "starting")),
+		wantNext: true,
+	}, {
+		name:        "until holds",
+		rpt:         loopedTask(3, withStatusResult(makeSucceeded(trs[0]), "starting"), withStatusResult(makeSucceeded(trs[1]), "healthy")),
+		wantSuccess: true,
+	}, {
+		name:        "until doesn't hold after the last iteration",
+		rpt:         loopedTask(2, withStatusResult(makeSucceeded(trs[0]), "starting"), withStatusResult(makeSucceeded(trs[1]), "starting")),
+		wantFailure: true,
+	}, {
+		name:        "iteration failed",
+		rpt:         loopedTask(3, makeFailed(trs[0])),
+		wantFailure: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rpt.HasNextLoopIteration(); got != tc.wantNext {
+				t.Errorf("expected HasNextLoopIteration: %t but got %t", tc.wantNext, got)
+			}
+			if got := tc.rpt.isSuccessful(); got != tc.wantSuccess {
+				t.Errorf("expected isSuccessful: %t but got %t", tc.wantSuccess, got)
+			}
+			if got := tc.rpt.isFailure(); got != tc.wantFailure {
+				t.Errorf("expected isFailure: %t but got %t", tc.wantFailure, got)
+			}
+		})
+	}
+}
+
+func TestLoopDelayRemaining(t *testing.T) {
+	rpt := loopedTask(3, withCompletionTime(makeSucceeded(trs[0]), now.Add(-20*time.Second)))
+	if got := rpt.LoopDelayRemaining(testClock); got != 40*time.Second {
+		t.Errorf("expected LoopDelayRemaining: 40s but got %s", got)
+	}
+	rpt = loopedTask(3, withCompletionTime(makeSucceeded(trs[0]), now.Add(-2*time.Minute)))
+	if got := rpt.LoopDelayRemaining(testClock); got != 0 {
+		t.Errorf("expected LoopDelayRemaining: 0s but got %s", got)
+	}
+}
+
+func TestStartNextLoopIteration(t *testing.T) {
+	first := withStatusResult(makeSucceeded(trs[0]), "starting")
+	rpt := loopedTask(3, first)
+	rpt.StartNextLoopIteration("pipelinerun")
+	if rpt.TaskRun != nil {
+		t.Errorf("expected no TaskRun for the next iteration but got %s", rpt.TaskRun.Name)
+	}
+	if d := cmp.Diff([]*v1beta1.TaskRun{first}, rpt.LoopTaskRuns); d != "" {
+		t.Errorf("unexpected previous iterations %s", diff.PrintWantGot(d))
+	}
+	if rpt.TaskRunName != "pipelinerun-poll-1" {
+		t.Errorf("expected TaskRun name pipelinerun-poll-1 but got %s", rpt.TaskRunName)
+	}
+}
+
+func TestGetNamesOfLoopTaskRuns(t *testing.T) {
+	childRef := func(name, ptName string) v1beta1.ChildStatusReference {
+		return v1beta1.ChildStatusReference{
+			TypeMeta:         runtime.TypeMeta{Kind: pipeline.TaskRunControllerName},
+			Name:             name,
+			PipelineTaskName: ptName,
+		}
+	}
+	for _, tc := range []struct {
+		name           string
+		taskRunsStatus map[string]*v1beta1.PipelineRunTaskRunStatus
+		childRefs      []v1beta1.ChildStatusReference
+		want           []string
+	}{{
+		name: "no iteration recorded",
+		want: []string{"pipelinerun-poll"},
+	}, {
+		name:      "iterations recorded in child references",
+		childRefs: []v1beta1.ChildStatusReference{childRef("pipelinerun-poll", "poll"), childRef("pipelinerun-other", "other"), childRef("pipelinerun-poll-1", "poll")},
+		want:      []string{"pipelinerun-poll", "pipelinerun-poll-1"},
+	}, {
+		name: "iterations recorded in the taskruns status",
+		taskRunsStatus: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"pipelinerun-poll-2": {PipelineTaskName: "poll"},
+			"pipelinerun-poll":   {PipelineTaskName: "poll"},
+			"pipelinerun-poll-1": {PipelineTaskName: "poll"},
+		},
+		want: []string{"pipelinerun-poll", "pipelinerun-poll-1", "pipelinerun-poll-2"},
+	}, {
+		name:      "taskrun not named after its iteration",
+		childRefs: []v1beta1.ChildStatusReference{childRef("resumed-poll", "poll")},
+		want:      []string{"resumed-poll"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := GetNamesOfLoopTaskRuns(tc.taskRunsStatus, tc.childRefs, "poll", "pipelinerun")
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected TaskRun names %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestLoopNextIterationWhenStopping(t *testing.T) {
+	looped := loopedTask(3, withStatusResult(makeSucceeded(trs[0]), "starting"))
+	failed := ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{Name: "deploy", TaskRef: &v1beta1.TaskRef{Name: "deploy"}},
+		TaskRun:      makeFailed(trs[1]),
+	}
+	for _, tc := range []struct {
+		name       string
+		state      PipelineRunState
+		specStatus v1beta1.PipelineRunSpecStatus
+		want       PipelineRunState
+		wantDone   bool
+	}{{
+		name:  "running",
+		state: PipelineRunState{&looped},
+		want:  PipelineRunState{&looped},
+	}, {
+		name:     "stopping",
+		state:    PipelineRunState{&looped, &failed},
+		wantDone: true,
+	}, {
+		name:       "gracefully stopped",
+		state:      PipelineRunState{&looped},
+		specStatus: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		wantDone:   true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				SpecStatus:      tc.specStatus,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Errorf("unexpected error getting DAG execution queue: %s", err)
+			}
+			if d := cmp.Diff(tc.want, queue); d != "" {
+				t.Errorf("Didn't get expected execution queue: %s", diff.PrintWantGot(d))
+			}
+			if got := facts.checkDAGTasksDone(); got != tc.wantDone {
+				t.Errorf("expected checkDAGTasksDone: %t but got %t", tc.wantDone, got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -30,6 +31,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 )
@@ -62,6 +65,9 @@ type ResolvedPipelineTask struct {
 	TaskRun      *v1beta1.TaskRun
 	TaskRunNames []string
 	TaskRuns     []*v1beta1.TaskRun
+	// If the PipelineTask has a Loop, LoopTaskRuns holds the TaskRuns of the previous
+	// iterations and TaskRun the one of the current iteration.
+	LoopTaskRuns []*v1beta1.TaskRun
 	// If the PipelineTask is a Custom Task, RunName and Run will be set.
 	CustomTask            bool
 	RunName               string
@@ -74,7 +80,7 @@ type ResolvedPipelineTask struct {
 
 // isDone returns true only if the task is skipped, succeeded or failed
 func (t ResolvedPipelineTask) isDone(facts *PipelineRunFacts) bool {
-	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure()
+	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure() || t.isLoopInterrupted(facts)
 }
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
@@ -137,7 +143,7 @@ func (t ResolvedPipelineTask) isSuccessful() bool {
 		}
 		return true
 	default:
-		return t.TaskRun.IsSuccessful()
+		return t.TaskRun.IsSuccessful() && (!t.IsLooped() || t.loopUntilHolds())
 	}
 }
 
@@ -154,6 +160,9 @@ func (t ResolvedPipelineTask) isFailure() bool {
 	if t.isSuccessful() {
 		return false
 	}
+	if t.isLoopExhausted() {
+		return true
+	}
 	var c *apis.Condition
 	var isDone bool
 
@@ -198,10 +207,16 @@ func (t ResolvedPipelineTask) isFailure() bool {
 	return isDone && c.IsFalse() && !t.hasRemainingRetries()
 }
 
+// isFailureIgnored returns true if the run has failed and the PipelineTask's onError is set
+// to continue, in which case the failure neither stops nor fails the PipelineRun.
+func (t ResolvedPipelineTask) isFailureIgnored() bool {
+	return t.PipelineTask.OnError == v1beta1.PipelineTaskContinue && !t.isCancelledForTimeOut() && !t.isCancelled() && t.isFailure()
+}
+
 // hasRemainingRetries returns true only when the number of retries already attempted
-// is less than the number of retries allowed.
+// is less than the number of retries allowed, and the failure reason, if any, is
+// retryable according to the retryPolicy of the PipelineTask.
 func (t ResolvedPipelineTask) hasRemainingRetries() bool {
-	var retriesDone int
 	switch {
 	case t.IsCustomTask() && t.IsMatrixed():
 		if len(t.Runs) == 0 {
@@ -209,8 +224,7 @@ func (t ResolvedPipelineTask) hasRemainingRetries() bool {
 		}
 		// has remaining retries when any Run has a remaining retry
 		for _, run := range t.Runs {
-			retriesDone = len(run.Status.RetriesStatus)
-			if retriesDone < t.PipelineTask.Retries {
+			if t.canRetry(len(run.Status.RetriesStatus), run.Status.GetCondition(apis.ConditionSucceeded)) {
 				return true
 			}
 		}
@@ -219,15 +233,14 @@ func (t ResolvedPipelineTask) hasRemainingRetries() bool {
 		if t.Run == nil {
 			return true
 		}
-		retriesDone = len(t.Run.Status.RetriesStatus)
+		return t.canRetry(len(t.Run.Status.RetriesStatus), t.Run.Status.GetCondition(apis.ConditionSucceeded))
 	case t.IsMatrixed():
 		if len(t.TaskRuns) == 0 {
 			return true
 		}
 		// has remaining retries when any TaskRun has a remaining retry
 		for _, taskRun := range t.TaskRuns {
-			retriesDone = len(taskRun.Status.RetriesStatus)
-			if retriesDone < t.PipelineTask.Retries {
+			if t.canRetry(taskRunRetriesDone(taskRun), taskRun.Status.GetCondition(apis.ConditionSucceeded)) {
 				return true
 			}
 		}
@@ -236,9 +249,59 @@ func (t ResolvedPipelineTask) hasRemainingRetries() bool {
 		if t.TaskRun == nil {
 			return true
 		}
-		retriesDone = len(t.TaskRun.Status.RetriesStatus)
+		return t.canRetry(taskRunRetriesDone(t.TaskRun), t.TaskRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+// canRetry returns true if a run of the PipelineTask, with the given number of retries done
+// and Succeeded condition, may still be retried.
+func (t ResolvedPipelineTask) canRetry(retriesDone int, c *apis.Condition) bool {
+	if retriesDone >= t.PipelineTask.Retries {
+		return false
+	}
+	return !c.IsFalse() || t.PipelineTask.RetryPolicy.IsRetryable(c.Reason)
+}
+
+// RetryBackoffRemaining returns how long to wait before the failed runs of the PipelineTask
+// can be retried according to the backoff of its retryPolicy, or 0 if they can be retried now.
+func (t ResolvedPipelineTask) RetryBackoffRemaining(c clock.PassiveClock) time.Duration {
+	if t.PipelineTask.RetryPolicy == nil {
+		return 0
+	}
+	var remaining time.Duration
+	wait := func(retriesDone int, completionTime *metav1.Time) {
+		if completionTime == nil {
+			return
+		}
+		if w := t.PipelineTask.RetryPolicy.Delay(retriesDone) - c.Since(completionTime.Time); w > remaining {
+			remaining = w
+		}
 	}
-	return retriesDone < t.PipelineTask.Retries
+	waitTaskRun := func(tr *v1beta1.TaskRun) {
+		if tr != nil && tr.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+			wait(taskRunRetriesDone(tr), tr.Status.CompletionTime)
+		}
+	}
+	waitRun := func(run *v1alpha1.Run) {
+		if run != nil && run.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+			wait(len(run.Status.RetriesStatus), run.Status.CompletionTime)
+		}
+	}
+	waitTaskRun(t.TaskRun)
+	for _, tr := range t.TaskRuns {
+		waitTaskRun(tr)
+	}
+	waitRun(t.Run)
+	for _, run := range t.Runs {
+		waitRun(run)
+	}
+	return remaining
+}
+
+// taskRunRetriesDone returns the number of retries of the TaskRun done on behalf of the
+// PipelineTask, leaving out the Pods recreated by the TaskRun itself after an eviction.
+func taskRunRetriesDone(tr *v1beta1.TaskRun) int {
+	return len(tr.Status.RetriesStatus) - tr.GetPodEvictionRetriesCount()
 }
 
 // isCancelledForTimeOut returns true only if the run is cancelled due to PipelineRun-controlled timeout
@@ -390,6 +453,8 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 	switch {
 	case facts.isFinalTask(t.PipelineTask.Name) || t.isScheduled():
 		skippingReason = v1beta1.None
+	case facts.ResumedSkips[t.PipelineTask.Name] != "":
+		skippingReason = facts.ResumedSkips[t.PipelineTask.Name]
 	case facts.IsStopping():
 		skippingReason = v1beta1.StoppingSkip
 	case facts.IsGracefullyCancelled():
@@ -402,6 +467,8 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 		skippingReason = v1beta1.MissingResultsSkip
 	case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 		skippingReason = v1beta1.WhenExpressionsSkip
+	case t.skipBecauseMatrixIsEmpty():
+		skippingReason = v1beta1.EmptyMatrixSkip
 	case t.skipBecausePipelineRunPipelineTimeoutReached(facts):
 		skippingReason = v1beta1.PipelineTimedOutSkip
 	case t.skipBecausePipelineRunTasksTimeoutReached(facts):
@@ -455,9 +522,9 @@ func (t *ResolvedPipelineTask) skipBecauseParentTaskWasSkipped(facts *PipelineRu
 	for _, p := range node.Prev {
 		parentTask := stateMap[p.Key]
 		if parentSkipStatus := parentTask.Skip(facts); parentSkipStatus.IsSkipped {
-			// if the parent task was skipped due to its `when` expressions,
+			// if the parent task was skipped due to its `when` expressions or its empty matrix,
 			// then we should ignore that and continue evaluating if we should skip because of other parent tasks
-			if parentSkipStatus.SkippingReason == v1beta1.WhenExpressionsSkip {
+			if parentSkipStatus.SkippingReason == v1beta1.WhenExpressionsSkip || parentSkipStatus.SkippingReason == v1beta1.EmptyMatrixSkip {
 				continue
 			}
 			return true
@@ -473,7 +540,7 @@ func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *Pipe
 		resolvedResultRefs, pt, err := ResolveResultRefs(facts.State, PipelineRunState{t})
 		rpt := facts.State.ToMap()[pt]
 		if rpt != nil {
-			if err != nil && (t.IsFinalTask(facts) || rpt.Skip(facts).SkippingReason == v1beta1.WhenExpressionsSkip) {
+			if err != nil && (t.IsFinalTask(facts) || rpt.Skip(facts).SkippingReason == v1beta1.WhenExpressionsSkip || rpt.isFailureIgnored()) {
 				return true
 			}
 		}
@@ -483,6 +550,21 @@ func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *Pipe
 	return false
 }
 
+// skipBecauseMatrixIsEmpty returns true if the task has a matrix whose params resolved to arrays
+// without any combinations, e.g. because an array result referenced in the matrix is empty
+func (t *ResolvedPipelineTask) skipBecauseMatrixIsEmpty() bool {
+	if !t.IsMatrixed() {
+		return false
+	}
+	for _, param := range t.PipelineTask.Matrix.Params {
+		if param.Value.Type != v1beta1.ParamTypeArray {
+			// the matrix references results which are not resolved yet
+			return false
+		}
+	}
+	return t.PipelineTask.GetMatrixCombinationsCount() == 0
+}
+
 // skipBecausePipelineRunPipelineTimeoutReached returns true if the task shouldn't be launched because the elapsed time since
 // the PipelineRun started is greater than the PipelineRun's pipeline timeout
 func (t *ResolvedPipelineTask) skipBecausePipelineRunPipelineTimeoutReached(facts *PipelineRunFacts) bool {
@@ -540,6 +622,8 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 			skippingReason = v1beta1.MissingResultsSkip
 		case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 			skippingReason = v1beta1.WhenExpressionsSkip
+		case t.skipBecauseMatrixIsEmpty():
+			skippingReason = v1beta1.EmptyMatrixSkip
 		case t.skipBecausePipelineRunPipelineTimeoutReached(facts):
 			skippingReason = v1beta1.PipelineTimedOutSkip
 		case t.skipBecausePipelineRunFinallyTimeoutReached(facts):
@@ -696,6 +780,28 @@ func ResolvePipelineTask(
 				return nil, err
 			}
 		}
+		// The TaskRuns can't be named yet if the Matrix references results which are not resolved,
+		// but the Task still needs to be resolved to validate it.
+		if len(rpt.TaskRunNames) == 0 {
+			if err := rpt.resolveTaskResources(ctx, getTask, pipelineTask, providedResources, nil); err != nil {
+				return nil, err
+			}
+		}
+	case rpt.IsLooped():
+		taskRunNames := GetNamesOfLoopTaskRuns(pipelineRun.Status.TaskRuns, pipelineRun.Status.ChildReferences, pipelineTask.Name, pipelineRun.Name)
+		for _, taskRunName := range taskRunNames[:len(taskRunNames)-1] {
+			taskRun, err := getTaskRun(taskRunName)
+			if err != nil && !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error retrieving TaskRun %s: %w", taskRunName, err)
+			}
+			if taskRun != nil {
+				rpt.LoopTaskRuns = append(rpt.LoopTaskRuns, taskRun)
+			}
+		}
+		rpt.TaskRunName = taskRunNames[len(taskRunNames)-1]
+		if err := rpt.resolvePipelineRunTaskWithTaskRun(ctx, rpt.TaskRunName, getTask, getTaskRun, pipelineTask, providedResources); err != nil {
+			return nil, err
+		}
 	default:
 		rpt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, pipelineRun.Status.ChildReferences, pipelineTask.Name, pipelineRun.Name)
 		if err := rpt.resolvePipelineRunTaskWithTaskRun(ctx, rpt.TaskRunName, getTask, getTaskRun, pipelineTask, providedResources); err != nil {
@@ -860,6 +966,19 @@ func getRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, childRefs [
 	return kmeta.ChildName(prName, fmt.Sprintf("-%s", ptName))
 }
 
+// SetMatrixFanOutNames sets the names of the TaskRuns or Runs that a matrixed PipelineTask fans out to
+// if they are not known yet, which is the case when its Matrix references results that are only resolved
+// once the PipelineTask is about to be executed.
+func (t *ResolvedPipelineTask) SetMatrixFanOutNames(pipelineRun *v1beta1.PipelineRun) {
+	combinationCount := t.PipelineTask.GetMatrixCombinationsCount()
+	switch {
+	case t.IsCustomTask() && len(t.RunNames) == 0:
+		t.RunNames = getNamesOfRuns(pipelineRun.Status.ChildReferences, t.PipelineTask.Name, pipelineRun.Name, combinationCount)
+	case !t.IsCustomTask() && len(t.TaskRunNames) == 0:
+		t.TaskRunNames = GetNamesOfTaskRuns(pipelineRun.Status.ChildReferences, t.PipelineTask.Name, pipelineRun.Name, combinationCount)
+	}
+}
+
 // getNamesOfRuns should return a unique names for `Runs` if they have not already been defined,
 // and the existing ones otherwise.
 func getNamesOfRuns(childRefs []v1beta1.ChildStatusReference, ptName, prName string, combinationCount int) []string {
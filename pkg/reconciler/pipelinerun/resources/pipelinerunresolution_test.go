@@ -338,6 +338,29 @@ func withRetries(tr *v1beta1.TaskRun) *v1beta1.TaskRun {
 	return tr
 }
 
+func withReason(tr *v1beta1.TaskRun, reason string) *v1beta1.TaskRun {
+	tr.Status.Conditions[0].Reason = reason
+	return tr
+}
+
+func withCompletionTime(tr *v1beta1.TaskRun, t time.Time) *v1beta1.TaskRun {
+	tr.Status.CompletionTime = &metav1.Time{Time: t}
+	return tr
+}
+
+func withPodEvictionRetries(tr *v1beta1.TaskRun) *v1beta1.TaskRun {
+	tr.Status.RetriesStatus = []v1beta1.TaskRunStatus{{
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: v1beta1.TaskRunReasonPodEvicted.String(),
+			}},
+		},
+	}}
+	return tr
+}
+
 func withRunRetries(r *v1alpha1.Run) *v1alpha1.Run {
 	r.Status.RetriesStatus = []v1alpha1.RunStatus{{
 		Status: duckv1.Status{
@@ -1227,6 +1250,80 @@ func TestIsSkipped(t *testing.T) {
 	}
 }
 
+func TestRetryBackoffRemaining(t *testing.T) {
+	retryPolicy := &v1beta1.RetryPolicy{
+		Backoff:    &metav1.Duration{Duration: 10 * time.Second},
+		MaxBackoff: &metav1.Duration{Duration: time.Minute},
+	}
+	for _, tc := range []struct {
+		name string
+		rpt  ResolvedPipelineTask
+		want time.Duration
+	}{{
+		name: "no retry policy",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1},
+			TaskRun:      withCompletionTime(makeFailed(trs[0]), now),
+		},
+		want: 0,
+	}, {
+		name: "taskrun not started",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1, RetryPolicy: retryPolicy},
+		},
+		want: 0,
+	}, {
+		name: "taskrun just failed",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 2, RetryPolicy: retryPolicy},
+			TaskRun:      withCompletionTime(makeFailed(trs[0]), now),
+		},
+		want: 10 * time.Second,
+	}, {
+		name: "retried taskrun failed a while ago",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 2, RetryPolicy: retryPolicy},
+			TaskRun:      withCompletionTime(withRetries(makeFailed(trs[0])), now.Add(-5*time.Second)),
+		},
+		want: 15 * time.Second,
+	}, {
+		name: "backoff elapsed",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 2, RetryPolicy: retryPolicy},
+			TaskRun:      withCompletionTime(makeFailed(trs[0]), now.Add(-time.Minute)),
+		},
+		want: 0,
+	}, {
+		name: "matrixed taskruns failed",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 2, Matrix: matrixedPipelineTask.Matrix, RetryPolicy: retryPolicy},
+			TaskRuns:     []*v1beta1.TaskRun{withCompletionTime(makeFailed(trs[0]), now.Add(-5*time.Second)), withCompletionTime(makeFailed(trs[1]), now.Add(-time.Second))},
+		},
+		want: 9 * time.Second,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rpt.RetryBackoffRemaining(testClock); got != tc.want {
+				t.Errorf("expected RetryBackoffRemaining: %s but got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryBackoffRemainingDoesNotModifyRuns(t *testing.T) {
+	taskRuns := make([]*v1beta1.TaskRun, 1, 2)
+	taskRuns[0] = withCompletionTime(makeFailed(trs[0]), now)
+	rpt := ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 2, Matrix: matrixedPipelineTask.Matrix, RetryPolicy: &v1beta1.RetryPolicy{}},
+		TaskRuns:     taskRuns,
+	}
+	other := rpt
+	other.TaskRun = withCompletionTime(makeFailed(trs[1]), now)
+	other.RetryBackoffRemaining(testClock)
+	if spare := taskRuns[:2][1]; spare != nil {
+		t.Errorf("expected the spare capacity of the TaskRuns to be left untouched, got %s", spare.Name)
+	}
+}
+
 func TestIsFailure(t *testing.T) {
 	for _, tc := range []struct {
 		name string
@@ -1317,6 +1414,41 @@ func TestIsFailure(t *testing.T) {
 		},
 		want: true,
 	}, {
+		name: "taskrun failed: retries remaining after pod eviction retries",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1},
+			TaskRun:      withPodEvictionRetries(makeFailed(trs[0])),
+		},
+		want: false,
+	}, {
+		name: "taskrun failed: retries remaining for a reason in retryOn",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1, RetryPolicy: &v1beta1.RetryPolicy{RetryOn: []string{v1beta1.TaskRunReasonTimedOut.String()}}},
+			TaskRun:      withReason(makeFailed(trs[0]), v1beta1.TaskRunReasonTimedOut.String()),
+		},
+		want: false,
+	}, {
+		name: "taskrun failed: no retries for a reason not in retryOn",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1, RetryPolicy: &v1beta1.RetryPolicy{RetryOn: []string{v1beta1.TaskRunReasonTimedOut.String()}}},
+			TaskRun:      withReason(makeFailed(trs[0]), v1beta1.TaskRunReasonFailed.String()),
+		},
+		want: true,
+	}, {
+		name: "taskrun failed: no retries for a reason in noRetryOn",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1, RetryPolicy: &v1beta1.RetryPolicy{NoRetryOn: []string{v1beta1.TaskRunReasonFailed.String()}}},
+			TaskRun:      withReason(makeFailed(trs[0]), v1beta1.TaskRunReasonFailed.String()),
+		},
+		want: true,
+	}, {
+		name: "matrixed taskruns failed: retries remaining for one of the reasons in retryOn",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{Name: "task", Retries: 1, Matrix: matrixedPipelineTask.Matrix, RetryPolicy: &v1beta1.RetryPolicy{RetryOn: []string{v1beta1.TaskRunReasonTimedOut.String()}}},
+			TaskRuns:     []*v1beta1.TaskRun{withReason(makeFailed(trs[0]), v1beta1.TaskRunReasonFailed.String()), withReason(makeFailed(trs[1]), v1beta1.TaskRunReasonTimedOut.String())},
+		},
+		want: false,
+	}, {
 
 		name: "run failed: no retries remaining",
 		rpt: ResolvedPipelineTask{
@@ -67,6 +67,11 @@ type PipelineRunFacts struct {
 	// The skip data is sensitive to changes in the state. The ResetSkippedCache method
 	// can be used to clean the cache and force re-computation when needed.
 	SkipCache map[string]TaskSkipStatus
+
+	// ResumedSkips holds the reasons why the PipelineRun this PipelineRun was resumed from
+	// skipped some of its PipelineTasks. Those PipelineTasks are skipped again for the same
+	// reason, without being re-evaluated.
+	ResumedSkips map[string]v1beta1.SkippingReason
 }
 
 // PipelineRunTimeoutsState records information about start times and timeouts for the PipelineRun, so that the PipelineRunFacts
@@ -88,6 +93,8 @@ type pipelineRunStatusCount struct {
 	Succeeded int
 	// failed tasks count
 	Failed int
+	// count of failed tasks whose onError is set to continue, which do not fail the pipeline
+	IgnoredFailed int
 	// cancelled tasks count
 	Cancelled int
 	// number of tasks which are still pending, have not executed
@@ -158,6 +165,10 @@ func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[str
 			continue
 		}
 
+		for _, taskRun := range rpt.LoopTaskRuns {
+			status[taskRun.Name] = rpt.getTaskRunStatus(taskRun, pr)
+		}
+
 		if rpt.TaskRun == nil {
 			continue
 		}
@@ -258,6 +269,9 @@ func (state PipelineRunState) GetChildReferences() []v1beta1.ChildStatusReferenc
 	var childRefs []v1beta1.ChildStatusReference
 
 	for _, rpt := range state {
+		for _, taskRun := range rpt.LoopTaskRuns {
+			childRefs = append(childRefs, rpt.getChildRefForTaskRun(taskRun))
+		}
 		switch {
 		case rpt.Run != nil:
 			childRefs = append(childRefs, rpt.getChildRefForRun(rpt.Run.Name))
@@ -305,7 +319,8 @@ func (t *ResolvedPipelineTask) getChildRefForTaskRun(taskRun *v1beta1.TaskRun) v
 }
 
 // getNextTasks returns a list of tasks which should be executed next i.e.
-// a list of tasks from candidateTasks which aren't yet indicated in state to be running and
+// a list of tasks from candidateTasks which aren't yet indicated in state to be running,
+// a list of looped tasks from candidateTasks which must run their next iteration and
 // a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
 func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*ResolvedPipelineTask {
 	tasks := []*ResolvedPipelineTask{}
@@ -313,6 +328,8 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
 			if t.TaskRun == nil && t.Run == nil && len(t.TaskRuns) == 0 && len(t.Runs) == 0 {
 				tasks = append(tasks, t)
+			} else if t.HasNextLoopIteration() {
+				tasks = append(tasks, t)
 			}
 		}
 	}
@@ -321,8 +338,9 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 }
 
 // getRetryableTasks returns a list of pipelinetasks which should be executed next when the pipelinerun is stopping,
-// i.e. a list of failed pipelinetasks from candidateTasks which haven't exhausted their retries. Note that if a
-// pipelinetask is cancelled, the retries are not exhausted - they are not retryable.
+// i.e. a list of failed pipelinetasks from candidateTasks which haven't exhausted their retries. Looped pipelinetasks
+// don't run their next iteration once the pipelinerun is stopping. Note that if a pipelinetask is cancelled, the
+// retries are not exhausted - they are not retryable.
 func (state PipelineRunState) getRetryableTasks(candidateTasks sets.String) []*ResolvedPipelineTask {
 	var tasks []*ResolvedPipelineTask
 	for _, t := range state {
@@ -365,7 +383,7 @@ func (state PipelineRunState) getRetryableTasks(candidateTasks sets.String) []*R
 func (facts *PipelineRunFacts) IsStopping() bool {
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			if t.isFailure() {
+			if t.isFailure() && !t.isFailureIgnored() {
 				return true
 			}
 		}
@@ -488,7 +506,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 	// get the count of successful tasks, failed tasks, cancelled tasks, skipped task, and incomplete tasks
 	s := facts.getPipelineTasksCount()
 	// completed task is a collection of successful, failed, cancelled tasks (skipped tasks are reported separately)
-	cmTasks := s.Succeeded + s.Failed + s.Cancelled
+	cmTasks := s.Succeeded + s.Failed + s.IgnoredFailed + s.Cancelled
 
 	// The completion reason is set from the TaskRun completion reason
 	// by default, set it to ReasonRunning
@@ -499,11 +517,15 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 		status := corev1.ConditionTrue
 		reason := v1beta1.PipelineRunReasonSuccessful.String()
 		message := fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Skipped: %d",
-			cmTasks, s.Failed, s.Cancelled, s.Skipped)
+			cmTasks, s.Failed+s.IgnoredFailed, s.Cancelled, s.Skipped)
 		// Set reason to ReasonCompleted - At least one is skipped
 		if s.Skipped > 0 {
 			reason = v1beta1.PipelineRunReasonCompleted.String()
 		}
+		// Set reason to ReasonCompletedWithFailures - At least one failed but was allowed to continue
+		if s.IgnoredFailed > 0 {
+			reason = v1beta1.PipelineRunReasonCompletedWithFailures.String()
+		}
 
 		switch {
 		case s.Failed > 0 || s.SkippedDueToTimeout > 0:
@@ -550,7 +572,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 		Status: corev1.ConditionUnknown,
 		Reason: reason,
 		Message: fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Incomplete: %d, Skipped: %d",
-			cmTasks, s.Failed, s.Cancelled, s.Incomplete, s.Skipped),
+			cmTasks, s.Failed+s.IgnoredFailed, s.Cancelled, s.Incomplete, s.Skipped),
 	}
 }
 
@@ -672,6 +694,7 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		Skipped:             0,
 		Succeeded:           0,
 		Failed:              0,
+		IgnoredFailed:       0,
 		Cancelled:           0,
 		Incomplete:          0,
 		SkippedDueToTimeout: 0,
@@ -687,6 +710,12 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		// increment cancelled counter since the task is cancelled
 		case t.isCancelled():
 			s.Cancelled++
+		// increment cancelled counter since the task is looped and its next iteration won't run
+		case t.isLoopInterrupted(facts):
+			s.Cancelled++
+		// increment ignored failure counter since the task has failed but its onError is set to continue
+		case t.isFailureIgnored():
+			s.IgnoredFailed++
 		// increment failure counter since the task has failed
 		case t.isFailure():
 			s.Failed++
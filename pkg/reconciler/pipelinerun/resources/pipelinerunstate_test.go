@@ -1046,6 +1046,18 @@ func TestDAGExecutionQueue(t *testing.T) {
 		Run:        makeRunFailed(runs[0]),
 		CustomTask: true,
 	}
+	failedTaskWithOnErrorContinue := ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "failedtaskwithonerrorcontinue",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			OnError: v1beta1.PipelineTaskContinue,
+		},
+		TaskRunName: "failedtaskwithonerrorcontinue",
+		TaskRun:     makeFailed(trs[0]),
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &task.Spec,
+		},
+	}
 	tcs := []struct {
 		name       string
 		state      PipelineRunState
@@ -1101,6 +1113,13 @@ func TestDAGExecutionQueue(t *testing.T) {
 			&failedTask, &failedRun, &failedTaskWithRetries, &failedRunWithRetries,
 		},
 		want: PipelineRunState{&failedTaskWithRetries, &failedRunWithRetries},
+	}, {
+		name: "running with a failed task with onError continue",
+		state: PipelineRunState{
+			&createdTask, &createdRun, &runningTask, &runningRun,
+			&successfulTask, &successfulRun, &failedTaskWithOnErrorContinue,
+		},
+		want: PipelineRunState{&createdTask, &createdRun},
 	}, {
 		name:  "all tasks finished",
 		state: PipelineRunState{&successfulTask, &successfulRun, &failedTask, &failedRun},
@@ -1775,6 +1794,38 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		TaskRun:      makeFailed(trs[0]),
 	}}
 
+	var continueTask = v1beta1.PipelineTask{
+		Name:    "mytask-continue",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		OnError: v1beta1.PipelineTaskContinue,
+	}
+	var afterContinueTask = v1beta1.PipelineTask{
+		Name:     "mytask-after-continue",
+		TaskRef:  &v1beta1.TaskRef{Name: "task"},
+		RunAfter: []string{"mytask-continue"},
+	}
+
+	// 2 Tasks, the first one failed with onError continue and the second one runs after it
+	var taskFailedWithOnErrorContinue = PipelineRunState{{
+		TaskRunName:  "failedTaskRun",
+		PipelineTask: &continueTask,
+		TaskRun:      makeFailed(trs[0]),
+	}, {
+		TaskRunName:  "notRunningTaskRun",
+		PipelineTask: &afterContinueTask,
+		TaskRun:      nil,
+	}}
+
+	var taskFailedWithOnErrorContinueCompleted = PipelineRunState{{
+		TaskRunName:  "failedTaskRun",
+		PipelineTask: &continueTask,
+		TaskRun:      makeFailed(trs[0]),
+	}, {
+		TaskRunName:  "succeededTaskRun",
+		PipelineTask: &afterContinueTask,
+		TaskRun:      makeSucceeded(trs[1]),
+	}}
+
 	tenMinutesAgo := now.Add(-10 * time.Minute)
 	fiveMinuteDuration := 5 * time.Minute
 
@@ -1900,6 +1951,20 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		expectedSucceeded: 1,
 		expectedFailed:    1,
 		expectedSkipped:   1,
+	}, {
+		name:               "task failed with onError continue; dependent not started",
+		state:              taskFailedWithOnErrorContinue,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedFailed:     1,
+		expectedIncomplete: 1,
+	}, {
+		name:              "task failed with onError continue; dependent succeeded",
+		state:             taskFailedWithOnErrorContinueCompleted,
+		expectedReason:    v1beta1.PipelineRunReasonCompletedWithFailures.String(),
+		expectedStatus:    corev1.ConditionTrue,
+		expectedSucceeded: 1,
+		expectedFailed:    1,
 	}, {
 		name:              "cancelled task should result in cancelled pipeline",
 		state:             cancelledTask,
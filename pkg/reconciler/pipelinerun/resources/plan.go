@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/matrix"
+)
+
+// Plan returns the execution plan of the PipelineRun before any of its PipelineTasks runs:
+// the dependencies, params and matrix combinations of each PipelineTask, and whether it
+// would run or be skipped, as far as this doesn't depend on the results or the execution
+// status of other PipelineTasks.
+func (facts *PipelineRunFacts) Plan() *v1beta1.PipelineRunPlan {
+	plan := &v1beta1.PipelineRunPlan{}
+	planned := map[string]*v1beta1.PlannedPipelineTask{}
+	stateMap := facts.State.ToMap()
+
+	var planTask func(rpt *ResolvedPipelineTask) *v1beta1.PlannedPipelineTask
+	planTask = func(rpt *ResolvedPipelineTask) *v1beta1.PlannedPipelineTask {
+		if p, ok := planned[rpt.PipelineTask.Name]; ok {
+			return p
+		}
+		var parents []*v1beta1.PlannedPipelineTask
+		if node, ok := facts.TasksGraph.Nodes[rpt.PipelineTask.Name]; ok {
+			for _, prev := range node.Prev {
+				if parent, ok := stateMap[prev.Key]; ok {
+					parents = append(parents, planTask(parent))
+				}
+			}
+		}
+		p := rpt.plan(parents, planned)
+		planned[rpt.PipelineTask.Name] = p
+		return p
+	}
+
+	for _, rpt := range facts.State {
+		p := planTask(rpt)
+		if facts.isFinalTask(rpt.PipelineTask.Name) {
+			plan.Finally = append(plan.Finally, *p)
+		} else {
+			plan.Tasks = append(plan.Tasks, *p)
+		}
+	}
+	return plan
+}
+
+// plan returns the plan of the PipelineTask, given the plans of its parents in the DAG and
+// of the PipelineTasks planned so far.
+func (t *ResolvedPipelineTask) plan(parents []*v1beta1.PlannedPipelineTask, planned map[string]*v1beta1.PlannedPipelineTask) *v1beta1.PlannedPipelineTask {
+	pt := t.PipelineTask
+	p := &v1beta1.PlannedPipelineTask{
+		Name:    pt.Name,
+		TaskRef: pt.TaskRef.DeepCopy(),
+		Params:  pt.Params,
+		Outcome: v1beta1.PlannedOutcomeRun,
+	}
+	for _, parent := range parents {
+		p.RunAfter = append(p.RunAfter, parent.Name)
+	}
+	sort.Strings(p.RunAfter)
+
+	staticMatrix := t.IsMatrixed()
+	if t.IsMatrixed() {
+		for _, param := range pt.Matrix.Params {
+			expressions, _ := v1beta1.GetVarSubstitutionExpressionsForParam(param)
+			if param.Value.Type != v1beta1.ParamTypeArray || referencesPipelineTasks(expressions) {
+				staticMatrix = false
+			}
+		}
+	}
+	if staticMatrix {
+		for _, combination := range matrix.FanOut(pt.Matrix.Params) {
+			p.MatrixCombinations = append(p.MatrixCombinations, v1beta1.PlannedMatrixCombination{Params: combination.Params})
+		}
+	}
+
+	dependsOnUnknown := false
+	for _, parent := range parents {
+		switch {
+		case parent.Outcome == v1beta1.PlannedOutcomeSkip && parent.SkippingReason != v1beta1.WhenExpressionsSkip && parent.SkippingReason != v1beta1.EmptyMatrixSkip:
+			return skipPlannedTask(p, v1beta1.ParentTasksSkip)
+		case parent.Outcome == v1beta1.PlannedOutcomeUnknown:
+			dependsOnUnknown = true
+		}
+	}
+	for _, ref := range v1beta1.PipelineTaskResultRefs(pt) {
+		if producer, ok := planned[ref.PipelineTask]; ok {
+			switch producer.Outcome {
+			case v1beta1.PlannedOutcomeSkip:
+				return skipPlannedTask(p, v1beta1.MissingResultsSkip)
+			case v1beta1.PlannedOutcomeUnknown:
+				dependsOnUnknown = true
+			}
+		}
+	}
+
+	staticWhen := true
+	for _, we := range pt.WhenExpressions {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		if referencesPipelineTasks(expressions) {
+			staticWhen = false
+		}
+	}
+	switch {
+	case staticWhen && !pt.WhenExpressions.AllowsExecution():
+		return skipPlannedTask(p, v1beta1.WhenExpressionsSkip)
+	case staticMatrix && len(p.MatrixCombinations) == 0:
+		return skipPlannedTask(p, v1beta1.EmptyMatrixSkip)
+	case !staticWhen || dependsOnUnknown:
+		p.Outcome = v1beta1.PlannedOutcomeUnknown
+	}
+	return p
+}
+
+func skipPlannedTask(p *v1beta1.PlannedPipelineTask, reason v1beta1.SkippingReason) *v1beta1.PlannedPipelineTask {
+	p.Outcome = v1beta1.PlannedOutcomeSkip
+	p.SkippingReason = reason
+	return p
+}
+
+// referencesPipelineTasks returns true if any of the variable substitution expressions refers
+// to the results or the execution status of a PipelineTask.
+func referencesPipelineTasks(expressions []string) bool {
+	for _, expression := range expressions {
+		if strings.HasPrefix(expression, "tasks.") {
+			return true
+		}
+	}
+	return false
+}
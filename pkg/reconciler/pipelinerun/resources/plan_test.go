@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestPlan(t *testing.T) {
+	taskRef := &v1beta1.TaskRef{Name: "task"}
+	never := v1beta1.WhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"bar"}}}
+	tasks := []v1beta1.PipelineTask{{
+		Name:    "build",
+		TaskRef: taskRef,
+		Params:  []v1beta1.Param{{Name: "revision", Value: *v1beta1.NewStructuredValues("main")}},
+	}, {
+		Name:            "lint",
+		TaskRef:         taskRef,
+		WhenExpressions: never,
+	}, {
+		Name:     "after-lint",
+		TaskRef:  taskRef,
+		RunAfter: []string{"lint"},
+	}, {
+		Name:    "report",
+		TaskRef: taskRef,
+		Params:  []v1beta1.Param{{Name: "lint", Value: *v1beta1.NewStructuredValues("$(tasks.lint.results.report)")}},
+	}, {
+		Name:     "publish-report",
+		TaskRef:  taskRef,
+		RunAfter: []string{"report"},
+	}, {
+		Name:            "deploy",
+		TaskRef:         taskRef,
+		WhenExpressions: v1beta1.WhenExpressions{{Input: "$(tasks.build.results.status)", Operator: selection.In, Values: []string{"ok"}}},
+	}, {
+		Name:     "smoke-test",
+		TaskRef:  taskRef,
+		RunAfter: []string{"deploy"},
+	}, {
+		Name:    "test",
+		TaskRef: taskRef,
+		Matrix: &v1beta1.Matrix{Params: []v1beta1.Param{
+			{Name: "os", Value: *v1beta1.NewStructuredValues("linux", "windows")},
+			{Name: "arch", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"amd64"}}},
+		}},
+	}, {
+		Name:    "test-nothing",
+		TaskRef: taskRef,
+		Matrix: &v1beta1.Matrix{Params: []v1beta1.Param{
+			{Name: "os", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}}},
+		}},
+	}}
+	finally := []v1beta1.PipelineTask{{
+		Name:            "notify",
+		TaskRef:         taskRef,
+		WhenExpressions: v1beta1.WhenExpressions{{Input: "$(tasks.status)", Operator: selection.In, Values: []string{"Failed"}}},
+	}, {
+		Name:    "cleanup",
+		TaskRef: taskRef,
+	}}
+
+	var state PipelineRunState
+	for i := range tasks {
+		state = append(state, &ResolvedPipelineTask{PipelineTask: &tasks[i]})
+	}
+	for i := range finally {
+		state = append(state, &ResolvedPipelineTask{PipelineTask: &finally[i]})
+	}
+	d, err := dag.Build(v1beta1.PipelineTaskList(tasks), v1beta1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatalf("Unexpected error building the DAG: %v", err)
+	}
+	df, err := dag.Build(v1beta1.PipelineTaskList(finally), map[string][]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error building the finally DAG: %v", err)
+	}
+	facts := &PipelineRunFacts{State: state, TasksGraph: d, FinalTasksGraph: df}
+
+	want := &v1beta1.PipelineRunPlan{
+		Tasks: []v1beta1.PlannedPipelineTask{{
+			Name:    "build",
+			TaskRef: taskRef,
+			Params:  tasks[0].Params,
+			Outcome: v1beta1.PlannedOutcomeRun,
+		}, {
+			Name:           "lint",
+			TaskRef:        taskRef,
+			Outcome:        v1beta1.PlannedOutcomeSkip,
+			SkippingReason: v1beta1.WhenExpressionsSkip,
+		}, {
+			Name:     "after-lint",
+			TaskRef:  taskRef,
+			RunAfter: []string{"lint"},
+			Outcome:  v1beta1.PlannedOutcomeRun,
+		}, {
+			Name:           "report",
+			TaskRef:        taskRef,
+			RunAfter:       []string{"lint"},
+			Params:         tasks[3].Params,
+			Outcome:        v1beta1.PlannedOutcomeSkip,
+			SkippingReason: v1beta1.MissingResultsSkip,
+		}, {
+			Name:           "publish-report",
+			TaskRef:        taskRef,
+			RunAfter:       []string{"report"},
+			Outcome:        v1beta1.PlannedOutcomeSkip,
+			SkippingReason: v1beta1.ParentTasksSkip,
+		}, {
+			Name:     "deploy",
+			TaskRef:  taskRef,
+			RunAfter: []string{"build"},
+			Outcome:  v1beta1.PlannedOutcomeUnknown,
+		}, {
+			Name:     "smoke-test",
+			TaskRef:  taskRef,
+			RunAfter: []string{"deploy"},
+			Outcome:  v1beta1.PlannedOutcomeUnknown,
+		}, {
+			Name:    "test",
+			TaskRef: taskRef,
+			MatrixCombinations: []v1beta1.PlannedMatrixCombination{{
+				Params: []v1beta1.Param{
+					{Name: "os", Value: *v1beta1.NewStructuredValues("linux")},
+					{Name: "arch", Value: *v1beta1.NewStructuredValues("amd64")},
+				},
+			}, {
+				Params: []v1beta1.Param{
+					{Name: "os", Value: *v1beta1.NewStructuredValues("windows")},
+					{Name: "arch", Value: *v1beta1.NewStructuredValues("amd64")},
+				},
+			}},
+			Outcome: v1beta1.PlannedOutcomeRun,
+		}, {
+			Name:           "test-nothing",
+			TaskRef:        taskRef,
+			Outcome:        v1beta1.PlannedOutcomeSkip,
+			SkippingReason: v1beta1.EmptyMatrixSkip,
+		}},
+		Finally: []v1beta1.PlannedPipelineTask{{
+			Name:    "notify",
+			TaskRef: taskRef,
+			Outcome: v1beta1.PlannedOutcomeUnknown,
+		}, {
+			Name:    "cleanup",
+			TaskRef: taskRef,
+			Outcome: v1beta1.PlannedOutcomeRun,
+		}},
+	}
+	if d := cmp.Diff(want, facts.Plan()); d != "" {
+		t.Errorf("Unexpected plan %s", diff.PrintWantGot(d))
+	}
+}
@@ -59,10 +59,11 @@ func ResolveResultRefs(pipelineRunState PipelineRunState, targets PipelineRunSta
 	return validateArrayResultsIndex(removeDup(allResolvedResultRefs))
 }
 
-// validateArrayResultsIndex checks if the result array indexing reference is out of bound of the array size
+// validateArrayResultsIndex checks if the result array indexing reference is out of bound of the array size.
+// References to the whole array don't index into it, so the array may be empty.
 func validateArrayResultsIndex(allResolvedResultRefs ResolvedResultRefs) (ResolvedResultRefs, string, error) {
 	for _, r := range allResolvedResultRefs {
-		if r.Value.Type == v1beta1.ParamTypeArray {
+		if r.Value.Type == v1beta1.ParamTypeArray && !r.ResultReference.WholeArray {
 			if r.ResultReference.ResultsIndex >= len(r.Value.ArrayVal) {
 				return nil, "", fmt.Errorf("Array Result Index %d for Task %s Result %s is out of bound of size %d", r.ResultReference.ResultsIndex, r.ResultReference.PipelineTask, r.ResultReference.Result, len(r.Value.ArrayVal))
 			}
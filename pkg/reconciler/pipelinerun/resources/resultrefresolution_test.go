@@ -172,6 +172,41 @@ var pipelineRunState = PipelineRunState{{
 			Value: *v1beta1.NewStructuredValues("$(tasks.dTask.results.dResult[3])"),
 		}},
 	},
+}, {
+	TaskRunName: "eTaskRun",
+	TaskRun: &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "eTaskRun",
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{successCondition},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskRunResults: []v1beta1.TaskRunResult{{
+					Name:  "eResult",
+					Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}},
+				}},
+			},
+		},
+	},
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:    "eTask",
+		TaskRef: &v1beta1.TaskRef{Name: "eTask"},
+		Params: []v1beta1.Param{{
+			Name:  "eParam",
+			Value: *v1beta1.NewStructuredValues("$(tasks.eTask.results.eResult[*])", "last"),
+		}},
+	},
+}, {
+	PipelineTask: &v1beta1.PipelineTask{
+		Name:    "fTask",
+		TaskRef: &v1beta1.TaskRef{Name: "fTask"},
+		Params: []v1beta1.Param{{
+			Name:  "fParam",
+			Value: *v1beta1.NewStructuredValues("$(tasks.eTask.results.eResult[0])"),
+		}},
+	},
 }}
 
 func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
@@ -555,6 +590,30 @@ func TestResolveResultRefs(t *testing.T) {
 		},
 		want:    nil,
 		wantErr: true,
+	}, {
+		name:             "Test successful whole empty array result references resolution - params",
+		pipelineRunState: pipelineRunState,
+		targets: PipelineRunState{
+			pipelineRunState[9],
+		},
+		want: ResolvedResultRefs{{
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}},
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "eTask",
+				Result:       "eResult",
+				WholeArray:   true,
+			},
+			FromTaskRun: "eTaskRun",
+		}},
+		wantErr: false,
+	}, {
+		name:             "Test unsuccessful empty array result references resolution - params",
+		pipelineRunState: pipelineRunState,
+		targets: PipelineRunState{
+			pipelineRunState[10],
+		},
+		want:    nil,
+		wantErr: true,
 	}, {
 		name:             "Test successful result references resolution - when expressions",
 		pipelineRunState: pipelineRunState,
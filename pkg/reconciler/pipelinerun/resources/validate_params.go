@@ -196,3 +196,32 @@ func findInvalidParamArrayReference(paramReference string, arrayParams map[strin
 		}
 	}
 }
+
+// ValidateMatrixParamTypes validates that the params in the Matrix of a PipelineTask are arrays once the
+// result references they contain have been resolved.
+func ValidateMatrixParamTypes(pt *v1beta1.PipelineTask) error {
+	if !pt.IsMatrixed() {
+		return nil
+	}
+	var wrongTypeParamNames []string
+	for _, param := range pt.Matrix.Params {
+		if param.Value.Type != v1beta1.ParamTypeArray {
+			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
+		}
+	}
+	if len(wrongTypeParamNames) != 0 {
+		return fmt.Errorf("matrix params of pipeline task %q must be arrays but these params are not: %s", pt.Name, wrongTypeParamNames)
+	}
+	return nil
+}
+
+// ValidateMatrixCombinationsCount validates that the Matrix of a PipelineTask does not generate more
+// combinations than the maximum allowed once the result references it contains have been resolved.
+func ValidateMatrixCombinationsCount(ctx context.Context, pt *v1beta1.PipelineTask) error {
+	matrixCombinationsCount := pt.GetMatrixCombinationsCount()
+	maxMatrixCombinationsCount := config.FromContextOrDefaults(ctx).Defaults.DefaultMaxMatrixCombinationsCount
+	if matrixCombinationsCount > maxMatrixCombinationsCount {
+		return fmt.Errorf("matrix of pipeline task %q generates %d combinations, which exceeds the maximum of %d", pt.Name, matrixCombinationsCount, maxMatrixCombinationsCount)
+	}
+	return nil
+}
@@ -719,3 +719,92 @@ func TestValidateParamArrayIndex_invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMatrixParamTypes(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		pt       *v1beta1.PipelineTask
+		expected error
+	}{{
+		name: "matrix params are arrays",
+		pt: &v1beta1.PipelineTask{
+			Name: "task",
+			Matrix: &v1beta1.Matrix{
+				Params: []v1beta1.Param{{Name: "platform", Value: *v1beta1.NewStructuredValues("linux", "mac")}},
+			},
+		},
+	}, {
+		name: "matrix params resolved to strings",
+		pt: &v1beta1.PipelineTask{
+			Name: "task",
+			Matrix: &v1beta1.Matrix{
+				Params: []v1beta1.Param{
+					{Name: "platform", Value: *v1beta1.NewStructuredValues("linux")},
+					{Name: "browser", Value: *v1beta1.NewStructuredValues("chrome", "safari")},
+				},
+			},
+		},
+		expected: fmt.Errorf(`matrix params of pipeline task "task" must be arrays but these params are not: [platform]`),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMatrixParamTypes(tt.pt)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("ValidateMatrixParamTypes() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expected.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateMatrixParamTypes() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateMatrixCombinationsCount(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.Defaults.DefaultMaxMatrixCombinationsCount = 4
+	ctx = config.ToContext(ctx, cfg)
+	for _, tt := range []struct {
+		name     string
+		pt       *v1beta1.PipelineTask
+		expected error
+	}{{
+		name: "count of combinations is within the maximum",
+		pt: &v1beta1.PipelineTask{
+			Name: "task",
+			Matrix: &v1beta1.Matrix{
+				Params: []v1beta1.Param{
+					{Name: "platform", Value: *v1beta1.NewStructuredValues("linux", "mac")},
+					{Name: "browser", Value: *v1beta1.NewStructuredValues("chrome", "safari")},
+				},
+			},
+		},
+	}, {
+		name: "count of combinations exceeds the maximum",
+		pt: &v1beta1.PipelineTask{
+			Name: "task",
+			Matrix: &v1beta1.Matrix{
+				Params: []v1beta1.Param{
+					{Name: "platform", Value: *v1beta1.NewStructuredValues("linux", "mac", "windows")},
+					{Name: "browser", Value: *v1beta1.NewStructuredValues("chrome", "safari")},
+				},
+			},
+		},
+		expected: fmt.Errorf(`matrix of pipeline task "task" generates 6 combinations, which exceeds the maximum of 4`),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMatrixCombinationsCount(ctx, tt.pt)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("ValidateMatrixCombinationsCount() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expected.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateMatrixCombinationsCount() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
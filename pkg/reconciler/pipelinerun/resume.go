@@ -0,0 +1,307 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+// isResumed returns true if the status of pr was already seeded from the PipelineRun it resumes.
+func isResumed(pr *v1beta1.PipelineRun) bool {
+	return pr.Status.Provenance != nil && pr.Status.Provenance.ResumedFrom != nil
+}
+
+// resumeFromPipelineRun seeds the status of pr with the children of the PipelineRun named by
+// spec.resumeFrom, for every PipelineTask whose TaskRuns and Runs all succeeded there. The
+// PipelineTasks that PipelineRun skipped because of their when expressions are recorded as
+// well, so that only the failed and not yet run PipelineTasks are scheduled. Finally tasks are
+// always run again.
+// pipelineSpec is the spec of the Pipeline named pipelineName that pr runs, before any
+// substitution, which must be the spec the original PipelineRun ran with the same params. The
+// reused TaskRuns and Runs get pr as an additional owner, so that they are not deleted along
+// with the original PipelineRun.
+func (c *Reconciler) resumeFromPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun, pipelineName string, pipelineSpec *v1beta1.PipelineSpec) error {
+	original, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Spec.ResumeFrom)
+	switch {
+	case errors.IsNotFound(err):
+		pr.Status.MarkFailed(ReasonCouldntResume, "PipelineRun %s/%s can't be resumed: PipelineRun %s was not found",
+			pr.Namespace, pr.Name, pr.Spec.ResumeFrom)
+		return controller.NewPermanentError(err)
+	case err != nil:
+		return fmt.Errorf("failed to get PipelineRun %s to resume from: %w", pr.Spec.ResumeFrom, err)
+	}
+	if !original.IsDone() {
+		err := fmt.Errorf("PipelineRun %s has not completed", original.Name)
+		pr.Status.MarkFailed(ReasonCouldntResume, "PipelineRun %s/%s can't be resumed: %s", pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+	if err := checkSameSpecAndParams(ctx, pr, original, pipelineName, pipelineSpec); err != nil {
+		pr.Status.MarkFailed(ReasonCouldntResume, "PipelineRun %s/%s can't be resumed: %s", pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
+	finallyTasks := sets.NewString()
+	if original.Status.PipelineSpec != nil {
+		for _, ft := range original.Status.PipelineSpec.Finally {
+			finallyTasks.Insert(ft.Name)
+		}
+	}
+	resumedFrom := &v1beta1.ResumedFrom{Name: original.Name, UID: original.UID}
+	childRefsByTask := childReferencesByPipelineTask(original)
+	ptNames := make([]string, 0, len(childRefsByTask))
+	for ptName := range childRefsByTask {
+		ptNames = append(ptNames, ptName)
+	}
+	sort.Strings(ptNames)
+
+	cfg := config.FromContextOrDefaults(ctx)
+	fullEmbedded := cfg.FeatureFlags.EmbeddedStatus == config.FullEmbeddedStatus || cfg.FeatureFlags.EmbeddedStatus == config.BothEmbeddedStatus
+	minimalEmbedded := cfg.FeatureFlags.EmbeddedStatus == config.MinimalEmbeddedStatus || cfg.FeatureFlags.EmbeddedStatus == config.BothEmbeddedStatus
+	for _, ptName := range ptNames {
+		if finallyTasks.Has(ptName) {
+			continue
+		}
+		childRefs := childRefsByTask[ptName]
+		taskRunStatuses := map[string]*v1beta1.PipelineRunTaskRunStatus{}
+		runStatuses := map[string]*v1beta1.PipelineRunRunStatus{}
+		owners := map[string][]metav1.OwnerReference{}
+		reusable := true
+		for _, cr := range childRefs {
+			var condition *apis.Condition
+			switch cr.Kind {
+			case pipeline.TaskRunControllerName:
+				tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(cr.Name)
+				if err != nil {
+					break
+				}
+				condition = tr.Status.GetCondition(apis.ConditionSucceeded)
+				taskRunStatuses[cr.Name] = &v1beta1.PipelineRunTaskRunStatus{PipelineTaskName: ptName, Status: tr.Status.DeepCopy()}
+				owners[cr.Name] = tr.OwnerReferences
+			case pipeline.RunControllerName:
+				run, err := c.runLister.Runs(pr.Namespace).Get(cr.Name)
+				if err != nil {
+					break
+				}
+				condition = run.Status.GetCondition(apis.ConditionSucceeded)
+				runStatuses[cr.Name] = &v1beta1.PipelineRunRunStatus{PipelineTaskName: ptName, Status: run.Status.DeepCopy()}
+				owners[cr.Name] = run.OwnerReferences
+			}
+			if !condition.IsTrue() {
+				reusable = false
+				break
+			}
+		}
+		if !reusable {
+			continue
+		}
+		for _, cr := range childRefs {
+			if err := c.addOwnerReference(ctx, pr, cr, owners[cr.Name]); err != nil {
+				return err
+			}
+		}
+
+		resumedFrom.ReusedPipelineTasks = append(resumedFrom.ReusedPipelineTasks, ptName)
+		if minimalEmbedded {
+			pr.Status.ChildReferences = append(pr.Status.ChildReferences, childRefs...)
+		}
+		if fullEmbedded {
+			for name, status := range taskRunStatuses {
+				if pr.Status.TaskRuns == nil {
+					pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{}
+				}
+				pr.Status.TaskRuns[name] = status
+			}
+			for name, status := range runStatuses {
+				if pr.Status.Runs == nil {
+					pr.Status.Runs = map[string]*v1beta1.PipelineRunRunStatus{}
+				}
+				pr.Status.Runs[name] = status
+			}
+		}
+	}
+
+	for _, st := range original.Status.SkippedTasks {
+		if st.Reason == v1beta1.WhenExpressionsSkip && !finallyTasks.Has(st.Name) {
+			resumedFrom.SkippedTasks = append(resumedFrom.SkippedTasks, st)
+		}
+	}
+
+	if pr.Status.Provenance == nil {
+		pr.Status.Provenance = &v1beta1.Provenance{}
+	}
+	pr.Status.Provenance.ResumedFrom = resumedFrom
+	return nil
+}
+
+// checkSameSpecAndParams returns an error if pr doesn't run the same Pipeline spec with the same
+// params as the PipelineRun original it resumes. The params, context and workspaces of original
+// are substituted in pipelineSpec to compare it with the spec original recorded in its status.
+func checkSameSpecAndParams(ctx context.Context, pr, original *v1beta1.PipelineRun, pipelineName string, pipelineSpec *v1beta1.PipelineSpec) error {
+	if !sameParams(pr.Spec.Params, original.Spec.Params) {
+		return fmt.Errorf("its params differ from the params of PipelineRun %s", original.Name)
+	}
+	if original.Status.PipelineSpec == nil {
+		return fmt.Errorf("PipelineRun %s didn't record the spec of its Pipeline", original.Name)
+	}
+	spec := resources.ApplyParameters(ctx, pipelineSpec, original)
+	spec = resources.ApplyContexts(spec, pipelineName, original)
+	spec = resources.ApplyWorkspaces(spec, original)
+	if !equality.Semantic.DeepEqual(spec, original.Status.PipelineSpec) {
+		return fmt.Errorf("the spec of its Pipeline differs from the spec of the Pipeline PipelineRun %s ran", original.Name)
+	}
+	return nil
+}
+
+// sameParams returns true if both lists hold the same values for the same params, in any order.
+func sameParams(a, b []v1beta1.Param) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := make(map[string]v1beta1.ParamValue, len(a))
+	for _, p := range a {
+		values[p.Name] = p.Value
+	}
+	for _, p := range b {
+		if v, ok := values[p.Name]; !ok || !equality.Semantic.DeepEqual(v, p.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// addOwnerReference adds pr to the owners of a TaskRun or Run reused from a resumed PipelineRun
+// or from the cache, which stays controlled by the PipelineRun which created it.
+func (c *Reconciler) addOwnerReference(ctx context.Context, pr *v1beta1.PipelineRun, cr v1beta1.ChildStatusReference, owners []metav1.OwnerReference) error {
+	for _, owner := range owners {
+		if owner.UID == pr.UID {
+			return nil
+		}
+	}
+	owner := metav1.OwnerReference{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       pipeline.PipelineRunControllerName,
+		Name:       pr.Name,
+		UID:        pr.UID,
+	}
+	op := jsonpatch.JsonPatchOperation{Operation: "add", Path: "/metadata/ownerReferences/-", Value: owner}
+	if len(owners) == 0 {
+		op = jsonpatch.JsonPatchOperation{Operation: "add", Path: "/metadata/ownerReferences", Value: []metav1.OwnerReference{owner}}
+	}
+	patch, err := json.Marshal([]jsonpatch.JsonPatchOperation{op})
+	if err != nil {
+		return err
+	}
+	switch cr.Kind {
+	case pipeline.TaskRunControllerName:
+		_, err = c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(ctx, cr.Name, types.JSONPatchType, patch, metav1.PatchOptions{}, "")
+	case pipeline.RunControllerName:
+		_, err = c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Patch(ctx, cr.Name, types.JSONPatchType, patch, metav1.PatchOptions{}, "")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add PipelineRun %s as an owner of %s %s: %w", pr.Name, cr.Kind, cr.Name, err)
+	}
+	return nil
+}
+
+// childReferencesByPipelineTask returns the TaskRuns and Runs of pr, grouped by PipelineTask,
+// whether pr records them as child references or as embedded statuses.
+func childReferencesByPipelineTask(pr *v1beta1.PipelineRun) map[string][]v1beta1.ChildStatusReference {
+	childRefs := map[string][]v1beta1.ChildStatusReference{}
+	seen := sets.NewString()
+	add := func(cr v1beta1.ChildStatusReference) {
+		if seen.Has(cr.Name) {
+			return
+		}
+		seen.Insert(cr.Name)
+		childRefs[cr.PipelineTaskName] = append(childRefs[cr.PipelineTaskName], cr)
+	}
+
+	for _, cr := range pr.Status.ChildReferences {
+		add(cr)
+	}
+	trNames := make([]string, 0, len(pr.Status.TaskRuns))
+	for name := range pr.Status.TaskRuns {
+		trNames = append(trNames, name)
+	}
+	sort.Strings(trNames)
+	for _, name := range trNames {
+		trs := pr.Status.TaskRuns[name]
+		cr := v1beta1.ChildStatusReference{Name: name, PipelineTaskName: trs.PipelineTaskName, WhenExpressions: trs.WhenExpressions}
+		cr.APIVersion = v1beta1.SchemeGroupVersion.String()
+		cr.Kind = pipeline.TaskRunControllerName
+		add(cr)
+	}
+	runNames := make([]string, 0, len(pr.Status.Runs))
+	for name := range pr.Status.Runs {
+		runNames = append(runNames, name)
+	}
+	sort.Strings(runNames)
+	for _, name := range runNames {
+		rs := pr.Status.Runs[name]
+		cr := v1beta1.ChildStatusReference{Name: name, PipelineTaskName: rs.PipelineTaskName, WhenExpressions: rs.WhenExpressions}
+		cr.APIVersion = v1alpha1.SchemeGroupVersion.String()
+		cr.Kind = pipeline.RunControllerName
+		add(cr)
+	}
+	return childRefs
+}
+
+// resumedSkips returns the reasons why the PipelineRun pr was resumed from skipped some of its
+// PipelineTasks, keyed by PipelineTask name.
+func resumedSkips(pr *v1beta1.PipelineRun) map[string]v1beta1.SkippingReason {
+	if !isResumed(pr) {
+		return nil
+	}
+	skips := map[string]v1beta1.SkippingReason{}
+	for _, st := range pr.Status.Provenance.ResumedFrom.SkippedTasks {
+		skips[st.Name] = st.Reason
+	}
+	return skips
+}
+
+// withoutReusedTasks returns the PipelineTasks of state which were not reused from the
+// PipelineRun pr was resumed from.
+func withoutReusedTasks(pr *v1beta1.PipelineRun, state resources.PipelineRunState) resources.PipelineRunState {
+	if !isResumed(pr) {
+		return state
+	}
+	reused := sets.NewString(pr.Status.Provenance.ResumedFrom.ReusedPipelineTasks...)
+	var remaining resources.PipelineRunState
+	for _, rpt := range state {
+		if !reused.Has(rpt.PipelineTask.Name) {
+			remaining = append(remaining, rpt)
+		}
+	}
+	return remaining
+}
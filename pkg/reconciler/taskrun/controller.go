@@ -33,6 +33,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -43,6 +44,13 @@ import (
 	"knative.dev/pkg/logging"
 )
 
+const (
+	// TracerProviderName is the name of the service the spans of the TaskRun reconciler are exported for
+	TracerProviderName = "taskrun-reconciler"
+	// TracerName is the name of the tracer of the TaskRun reconciler
+	TracerName = "TaskRunReconciler"
+)
+
 // NewController instantiates a new controller.Impl from knative.dev/pkg/controller
 func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -56,6 +64,8 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		resolutionInformer := resolutioninformer.Get(ctx)
 		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger))
 		configStore.WatchConfigs(cmw)
+		tracerProvider := tracing.New(ctx, TracerProviderName, logger.Named("tracing"))
+		cmw.Watch(config.GetTracingConfigName(), tracerProvider.OnConfigMap)
 
 		entrypointCache, err := pod.NewEntrypointCache(kubeclientset)
 		if err != nil {
@@ -76,6 +86,7 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			podLister:           podInformer.Lister(),
 			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
 			resolutionRequester: resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			tracerProvider:      tracerProvider,
 		}
 		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -91,6 +102,9 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		// TaskRuns are reconciled again once the delivery of their cloud events completes
+		cloudeventclient.GetDeliveryTracker(ctx).RegisterEnqueue(pipeline.TaskRunControllerName, impl.EnqueueKey)
+
 		return impl
 	}
 }
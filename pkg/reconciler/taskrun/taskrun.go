@@ -47,11 +47,15 @@ import (
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	_ "github.com/tektoncd/pipeline/pkg/taskrunmetrics/fake" // Make sure the taskrunmetrics are setup
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"github.com/tektoncd/pipeline/pkg/workspace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	corev1Listers "k8s.io/client-go/listers/core/v1"
@@ -83,6 +87,7 @@ type Reconciler struct {
 	metrics             *taskrunmetrics.Recorder
 	pvcHandler          volumeclaim.PvcHandler
 	resolutionRequester resolution.Requester
+	tracerProvider      trace.TracerProvider
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface
@@ -99,6 +104,12 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// By this time, params and workspaces should not be propagated for embedded tasks so we cannot
 	// validate that all parameter variables and workspaces used in the TaskSpec are declared by the Task.
 	ctx = config.SkipValidationDueToPropagatedParametersAndWorkspaces(ctx, true)
+	// All the reconciliations of the TaskRun are traced as part of the same trace, which is part
+	// of the trace of its PipelineRun if any
+	ctx = tracing.ContextWithRootSpan(ctx, c.tracerProvider.Tracer(TracerName), &tr.ObjectMeta, tracing.TaskRunSpanContextAnnotation, tracing.PipelineRunSpanContextAnnotation, "TaskRun",
+		attribute.String("namespace", tr.Namespace), attribute.String("taskrun", tr.Name))
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "TaskRun:ReconcileKind")
+	defer span.End()
 
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
@@ -162,7 +173,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	}
 
 	// Check for Pod Failures
-	if failed, reason, message := c.checkPodFailed(tr); failed {
+	if failed, reason, message := c.checkPodFailed(ctx, tr); failed {
+		err := c.failTaskRun(ctx, tr, reason, message)
+		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
+	}
+
+	// Check if the TaskRun Pod has been pending for longer than the pending timeout
+	if timedOut, reason, message := c.checkPodPendingTimeout(ctx, tr); timedOut {
 		err := c.failTaskRun(ctx, tr, reason, message)
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 	}
@@ -195,12 +212,34 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		// Compute the time since the task started.
 		elapsed := c.Clock.Since(tr.Status.StartTime.Time)
 		// Snooze this resource until the timeout has elapsed.
-		return controller.NewRequeueAfter(tr.GetTimeout(ctx) - elapsed)
+		waitTime := tr.GetTimeout(ctx) - elapsed
+		// Wake up earlier if the Pod is still pending and the pending timeout elapses first.
+		if pendingWaitTime, pending := c.getPendingTimeoutRemaining(ctx, tr); pending && pendingWaitTime < waitTime {
+			waitTime = pendingWaitTime
+		}
+		return controller.NewRequeueAfter(waitTime)
 	}
 	return nil
 }
 
-func (c *Reconciler) checkPodFailed(tr *v1beta1.TaskRun) (bool, v1beta1.TaskRunReason, string) {
+func (c *Reconciler) checkPodFailed(ctx context.Context, tr *v1beta1.TaskRun) (bool, v1beta1.TaskRunReason, string) {
+	if tr.Status.PodName != "" {
+		pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+		if err == nil {
+			if evicted, message := podconvert.IsPodEvicted(pod); evicted {
+				if tr.GetPodEvictionRetriesCount() >= tr.GetPodEvictionRetries(ctx) {
+					return true, v1beta1.TaskRunReasonEvicted, message
+				}
+				// Clear the evicted Pod, so that reconcile creates a new one
+				logging.FromContext(ctx).Infof("Recreating pod for taskrun %q: %s", tr.Name, message)
+				if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+					recorder.Eventf(tr, corev1.EventTypeWarning, v1beta1.TaskRunReasonPodEvicted.String(), "Recreating evicted pod %q", pod.Name)
+				}
+				addPodEvictionRetryHistory(tr, message)
+				return false, "", ""
+			}
+		}
+	}
 	for _, step := range tr.Status.Steps {
 		if step.Waiting != nil && step.Waiting.Reason == "ImagePullBackOff" {
 			image := step.ImageID
@@ -218,6 +257,59 @@ func (c *Reconciler) checkPodFailed(tr *v1beta1.TaskRun) (bool, v1beta1.TaskRunR
 	return false, "", ""
 }
 
+// checkPodPendingTimeout returns true, along with the reason and message to fail the
+// TaskRun with, if the TaskRun Pod has been pending for longer than the pending timeout.
+func (c *Reconciler) checkPodPendingTimeout(ctx context.Context, tr *v1beta1.TaskRun) (bool, v1beta1.TaskRunReason, string) {
+	remaining, pending := c.getPendingTimeoutRemaining(ctx, tr)
+	if !pending || remaining > 0 {
+		return false, "", ""
+	}
+	timeout := tr.GetPendingTimeout(ctx)
+	pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+	if err != nil {
+		// The Pod could not be created, e.g. because it exceeds the ResourceQuota of the namespace
+		message := fmt.Sprintf("The pod of TaskRun %q could not be created within the pending timeout %q: %s", tr.Name, timeout, tr.Status.GetCondition(apis.ConditionSucceeded).Message)
+		return true, v1beta1.TaskRunReasonUnschedulable, message
+	}
+	switch {
+	case podconvert.IsPodUnschedulable(pod):
+		message := fmt.Sprintf("The pod %q of TaskRun %q could not be scheduled within the pending timeout %q: %s", pod.Name, tr.Name, timeout, podconvert.GetWaitingMessage(pod))
+		return true, v1beta1.TaskRunReasonUnschedulable, message
+	case podconvert.IsPullImageError(pod):
+		message := fmt.Sprintf("The pod %q of TaskRun %q failed to pull its images within the pending timeout %q: %s", pod.Name, tr.Name, timeout, podconvert.GetWaitingMessage(pod))
+		return true, v1beta1.TaskRunReasonImagePullFailed, message
+	default:
+		message := fmt.Sprintf("The pod %q of TaskRun %q did not start running within the pending timeout %q: %s", pod.Name, tr.Name, timeout, podconvert.GetWaitingMessage(pod))
+		return true, v1beta1.TaskRunReasonPendingTimedOut, message
+	}
+}
+
+// getPendingTimeoutRemaining returns the time left before the pending timeout of the
+// TaskRun elapses, and whether the TaskRun is still waiting for its Pod to start running.
+// The pending time is counted from the creation of the Pod, or from the creation of the
+// TaskRun if the Pod could not be created because of the ResourceQuota of the namespace.
+func (c *Reconciler) getPendingTimeoutRemaining(ctx context.Context, tr *v1beta1.TaskRun) (time.Duration, bool) {
+	timeout := tr.GetPendingTimeout(ctx)
+	if timeout == config.NoTimeoutDuration {
+		return 0, false
+	}
+	var pendingSince time.Time
+	if tr.Status.PodName == "" {
+		condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+		if condition == nil || condition.Reason != podconvert.ReasonExceededResourceQuota {
+			return 0, false
+		}
+		pendingSince = tr.CreationTimestamp.Time
+	} else {
+		pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+		if err != nil || pod.Status.Phase != corev1.PodPending {
+			return 0, false
+		}
+		pendingSince = pod.CreationTimestamp.Time
+	}
+	return timeout - c.Clock.Since(pendingSince), true
+}
+
 func (c *Reconciler) durationAndCountMetrics(ctx context.Context, tr *v1beta1.TaskRun) {
 	logger := logging.FromContext(ctx)
 	if tr.IsDone() {
@@ -238,6 +330,9 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, tr *v1beta1.Ta
 			if err := metrics.CloudEvents(ctx, tr); err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
+			if err := metrics.StepTimings(ctx, tr, before); err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
 		}(c.metrics)
 	}
 }
@@ -249,8 +344,16 @@ func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1beta1.TaskRun) erro
 		return nil
 	}
 
-	// do not continue if the TaskSpec had no sidecars
-	if tr.Status.TaskSpec != nil && len(tr.Status.TaskSpec.Sidecars) == 0 {
+	// The log archiver isn't reported as a sidecar: it is stopped along with the sidecars
+	// once the steps completed, and reports the logs it archived once it terminated.
+	archiverRunning := false
+	if pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName); err == nil {
+		podconvert.SetTaskRunStatusStepLogs(pod, &tr.Status)
+		archiverRunning = podconvert.IsLogArchiverRunning(pod)
+	}
+
+	// do not continue if the TaskSpec had no sidecars, and the log archiver isn't running
+	if tr.Status.TaskSpec != nil && len(tr.Status.TaskSpec.Sidecars) == 0 && !archiverRunning {
 		return nil
 	}
 
@@ -263,8 +366,8 @@ func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1beta1.TaskRun) erro
 		}
 	}
 
-	// do not continue if there are no Running sidecars.
-	if !podconvert.IsSidecarStatusRunning(tr) {
+	// do not continue if there are no Running sidecars, and the log archiver isn't running.
+	if !podconvert.IsSidecarStatusRunning(tr) && !archiverRunning {
 		return nil
 	}
 
@@ -275,6 +378,9 @@ func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1beta1.TaskRun) erro
 		if podconvert.IsSidecarStatusRunning(tr) {
 			err = updateStoppedSidecarStatus(pod, tr)
 		}
+		// The log archiver reports the logs it archived once it terminated, which is
+		// after the steps completed.
+		podconvert.SetTaskRunStatusStepLogs(pod, &tr.Status)
 	}
 	if k8serrors.IsNotFound(err) {
 		// At this stage the TaskRun has been completed if the pod is not found, it won't come back,
@@ -295,6 +401,16 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	// Send k8s events and cloud events (when configured)
 	events.Emit(ctx, beforeCondition, afterCondition, tr)
 
+	// The root span of the trace of the TaskRun covers it from its creation to its completion
+	if !beforeCondition.IsTrue() && !beforeCondition.IsFalse() && tr.IsDone() {
+		end := c.Clock.Now()
+		if tr.Status.CompletionTime != nil {
+			end = tr.Status.CompletionTime.Time
+		}
+		tracing.EndRootSpan(ctx, c.tracerProvider.Tracer(TracerName), &tr.ObjectMeta, tracing.TaskRunSpanContextAnnotation, tracing.PipelineRunSpanContextAnnotation, "TaskRun",
+			tr.CreationTimestamp.Time, end, attribute.String("namespace", tr.Namespace), attribute.String("taskrun", tr.Name))
+	}
+
 	_, err := c.updateLabelsAndAnnotations(ctx, tr)
 	if err != nil {
 		logger.Warn("Failed to update TaskRun labels/annotations", zap.Error(err))
@@ -317,6 +433,8 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 // them in the TaskRun.Status so we don't need to re-run `prepare` at every
 // reconcile (see https://github.com/tektoncd/pipeline/issues/2473).
 func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1.TaskSpec, *resources.ResolvedTaskResources, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "prepare")
+	defer span.End()
 	logger := logging.FromContext(ctx)
 	tr.SetDefaults(ctx)
 
@@ -444,6 +562,8 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 // error but it does not sync updates back to etcd. It does not emit events.
 // `reconcile` consumes spec and resources returned by `prepare`
 func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) error {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "reconcile")
+	defer span.End()
 	defer c.durationAndCountMetrics(ctx, tr)
 	logger := logging.FromContext(ctx)
 	recorder := controller.GetEventRecorder(ctx)
@@ -535,10 +655,16 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	}
 
 	// Convert the Pod's status to the equivalent TaskRun Status.
+	done := tr.IsDone()
+	previousSteps := tr.Status.Steps
 	tr.Status, err = podconvert.MakeTaskRunStatus(logger, *tr, pod)
 	if err != nil {
 		return err
 	}
+	c.setStepImagePullTimes(ctx, tr, previousSteps, pod)
+	if !done && tr.IsDone() {
+		c.traceSteps(ctx, tr, pod)
+	}
 
 	if err := validateTaskRunResults(tr, rtr.TaskSpec); err != nil {
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
@@ -549,6 +675,75 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	return nil
 }
 
+// setStepImagePullTimes sets the time the steps of a TaskRun spent pulling their image, which
+// isn't reported in the status of the Pod. It's carried over from the previous status of the
+// steps, and read from the events of the Pod once their container started.
+func (c *Reconciler) setStepImagePullTimes(ctx context.Context, tr *v1beta1.TaskRun, previousSteps []v1beta1.StepState, pod *corev1.Pod) {
+	previous := map[string]*metav1.Duration{}
+	for _, s := range previousSteps {
+		if s.Timing != nil && s.Timing.ImagePull != nil {
+			previous[s.ContainerName] = s.Timing.ImagePull
+		}
+	}
+	missing := false
+	for i := range tr.Status.Steps {
+		s := &tr.Status.Steps[i]
+		if s.Timing == nil {
+			continue
+		}
+		if s.Timing.ImagePull = previous[s.ContainerName]; s.Timing.ImagePull == nil {
+			missing = true
+		}
+	}
+	if !missing {
+		return
+	}
+	events, err := c.KubeClientSet.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": pod.Name}.String(),
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to list the events of pod %q: %v", pod.Name, err)
+		return
+	}
+	pulls := podconvert.StepImagePullTimes(pod, events.Items)
+	for i := range tr.Status.Steps {
+		if s := &tr.Status.Steps[i]; s.Timing != nil && s.Timing.ImagePull == nil {
+			s.Timing.ImagePull = pulls[s.ContainerName]
+		}
+	}
+}
+
+// traceSteps records the time each step of a completed TaskRun ran as spans of the trace of
+// the TaskRun. The time taken to resolve the TaskRun up to the creation of its Pod, and the
+// time the Pod took to start its steps, which includes scheduling and pulling images, are
+// recorded as children of the span of its PipelineTask if any, or of the TaskRun otherwise.
+func (c *Reconciler) traceSteps(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) {
+	tracer := c.tracerProvider.Tracer(TracerName)
+	ptCtx := tracing.ContextWithParentSpan(ctx, &tr.ObjectMeta, tracing.PipelineRunSpanContextAnnotation)
+	if pod.CreationTimestamp.After(tr.CreationTimestamp.Time) {
+		_, span := tracer.Start(ptCtx, "resolution", trace.WithTimestamp(tr.CreationTimestamp.Time),
+			trace.WithAttributes(attribute.String("taskrun", tr.Name)))
+		span.End(trace.WithTimestamp(pod.CreationTimestamp.Time))
+	}
+	var firstStepStart time.Time
+	for _, step := range tr.Status.Steps {
+		if step.Terminated == nil || step.Terminated.StartedAt.IsZero() {
+			continue
+		}
+		if firstStepStart.IsZero() || step.Terminated.StartedAt.Time.Before(firstStepStart) {
+			firstStepStart = step.Terminated.StartedAt.Time
+		}
+		_, span := tracer.Start(ctx, "step:"+step.Name, trace.WithTimestamp(step.Terminated.StartedAt.Time),
+			trace.WithAttributes(attribute.String("step", step.Name), attribute.Int("exitCode", int(step.Terminated.ExitCode))))
+		span.End(trace.WithTimestamp(step.Terminated.FinishedAt.Time))
+	}
+	if firstStepStart.After(pod.CreationTimestamp.Time) {
+		_, span := tracer.Start(ptCtx, "podStartup", trace.WithTimestamp(pod.CreationTimestamp.Time),
+			trace.WithAttributes(attribute.String("pod", pod.Name)))
+		span.End(trace.WithTimestamp(firstStepStart))
+	}
+}
+
 func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr *v1beta1.TaskRun, taskSpec *v1beta1.TaskSpec) error {
 	configMap := config.FromContextOrDefaults(ctx)
 	defaults := configMap.Defaults
@@ -695,9 +890,22 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 	return nil
 }
 
+// addPodEvictionRetryHistory records the current status of the TaskRun, whose Pod
+// was evicted, in its retries status and clears the Pod so that a new one is created
+func addPodEvictionRetryHistory(tr *v1beta1.TaskRun, message string) {
+	retryStatus := *tr.Status.DeepCopy()
+	retryStatus.RetriesStatus = nil
+	retryStatus.MarkResourceFailed(v1beta1.TaskRunReasonPodEvicted, errors.New(message))
+	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, retryStatus)
+	tr.Status.PodName = ""
+	tr.Status.MarkResourceOngoing(podconvert.ReasonPending, message)
+}
+
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
 func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources, workspaceVolumes map[string]corev1.Volume) (*corev1.Pod, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createPod")
+	defer span.End()
 	logger := logging.FromContext(ctx)
 	inputResources, err := resourceImplBinding(rtr.Inputs, c.Images)
 	if err != nil {
@@ -856,7 +1064,7 @@ func resourceImplBinding(resources map[string]*resourcev1alpha1.PipelineResource
 func updateStoppedSidecarStatus(pod *corev1.Pod, tr *v1beta1.TaskRun) error {
 	tr.Status.Sidecars = []v1beta1.SidecarState{}
 	for _, s := range pod.Status.ContainerStatuses {
-		if !podconvert.IsContainerStep(s.Name) {
+		if !podconvert.IsContainerStep(s.Name) && !podconvert.IsContainerLogArchiver(s.Name) {
 			var sidecarState corev1.ContainerState
 			if s.LastTerminationState.Terminated != nil {
 				// Sidecar has successfully by terminated by nop image
@@ -44,12 +44,15 @@ import (
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	eventstest "github.com/tektoncd/pipeline/test/events"
 	"github.com/tektoncd/pipeline/test/names"
 	"github.com/tektoncd/pipeline/test/parse"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -477,6 +480,10 @@ func getTaskRunController(t *testing.T, d test.Data) (test.Assets, func()) {
 
 func initializeTaskRunControllerAssets(t *testing.T, d test.Data, opts pipeline.Options) (test.Assets, func()) {
 	ctx, _ := ttesting.SetupFakeContext(t)
+	return initializeTaskRunControllerAssetsWithContext(ctx, t, d, opts)
+}
+
+func initializeTaskRunControllerAssetsWithContext(ctx context.Context, t *testing.T, d test.Data, opts pipeline.Options) (test.Assets, func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	test.EnsureConfigurationConfigMapsExist(&d)
 	c, informers := test.SeedTestData(t, ctx, d)
@@ -500,6 +507,170 @@ func initializeTaskRunControllerAssets(t *testing.T, d test.Data, opts pipeline.
 	}, cancel
 }
 
+func TestReconcileWithTracing(t *testing.T) {
+	tr := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+  annotations:
+    tekton.dev/pipelinerunSpanContext: '{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}'
+spec:
+  taskRef:
+    name: test-task
+`)
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetTracingConfigName()},
+			Data:       map[string]string{"tracing.enabled": "true"},
+		}},
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	names.TestingSeed()
+	testAssets, cancel := initializeTaskRunControllerAssetsWithContext(tracing.WithExporter(ctx, exporter), t, d, pipeline.Options{Images: images})
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error. Got error %v", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	if _, ok := reconciledRun.Annotations[tracing.TaskRunSpanContextAnnotation]; !ok {
+		t.Errorf("expected the span context of the TaskRun to be stored in its annotations but got %v", reconciledRun.Annotations)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID().String() != "0af7651916cd43dd8448eb211c80319c" {
+			t.Errorf("expected span %s to be part of the trace of the PipelineRun", span.Name)
+		}
+		spans[span.Name] = span
+	}
+	if _, ok := spans["TaskRun"]; ok {
+		t.Errorf("expected the TaskRun span not to be exported before the TaskRun is done")
+	}
+	for _, name := range []string{"TaskRun:ReconcileKind", "prepare", "reconcile", "createPod"} {
+		if _, ok := spans[name]; !ok {
+			t.Errorf("expected span %s to be exported", name)
+		}
+	}
+	if spans["createPod"].Parent.SpanID() != spans["reconcile"].SpanContext.SpanID() {
+		t.Errorf("expected the createPod span to be a child of the reconcile span")
+	}
+}
+
+func TestReconcileWithTracingOnCompletion(t *testing.T) {
+	tr := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+  creationTimestamp: "2021-12-31T23:59:00Z"
+  annotations:
+    tekton.dev/pipelinerunSpanContext: '{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}'
+    tekton.dev/taskrunSpanContext: '{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-00f067aa0ba902b7-01"}'
+spec:
+  taskRef:
+    name: test-task
+status:
+  podName: test-taskrun-pod
+  startTime: "2021-12-31T23:59:00Z"
+  conditions:
+  - reason: Running
+    status: Unknown
+    type: Succeeded
+`)
+	pod, err := makePod(tr, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.CreationTimestamp = metav1.Date(2021, time.December, 31, 23, 59, 10, 0, time.UTC)
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodSucceeded,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "step-simple-step",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				StartedAt:  metav1.Date(2021, time.December, 31, 23, 59, 30, 0, time.UTC),
+				FinishedAt: metav1.Date(2021, time.December, 31, 23, 59, 50, 0, time.UTC),
+			}},
+		}},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetTracingConfigName()},
+			Data:       map[string]string{"tracing.enabled": "true"},
+		}},
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	names.TestingSeed()
+	testAssets, cancel := initializeTaskRunControllerAssetsWithContext(tracing.WithExporter(ctx, exporter), t, d, pipeline.Options{Images: images})
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Unexpected error when reconciling the completed TaskRun: %v", err)
+		}
+	}
+	reconciledRun, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	if !reconciledRun.IsDone() {
+		t.Fatalf("expected the TaskRun to be done but got %v", reconciledRun.Status.Conditions)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	root, ok := spans["TaskRun"]
+	if !ok {
+		t.Fatalf("expected the TaskRun span to be exported once the TaskRun is done")
+	}
+	if root.SpanContext.SpanID().String() != "00f067aa0ba902b7" || root.Parent.SpanID().String() != "b7ad6b7169203331" {
+		t.Errorf("expected the TaskRun span to be the root span stored in its annotations, child of the PipelineTask span")
+	}
+	if !root.StartTime.Equal(tr.CreationTimestamp.Time) || !root.EndTime.Equal(reconciledRun.Status.CompletionTime.Time) {
+		t.Errorf("expected the TaskRun span to last from its creation to its completion but got %v to %v", root.StartTime, root.EndTime)
+	}
+	for name, want := range map[string]struct{ start, end time.Time }{
+		"resolution": {tr.CreationTimestamp.Time, pod.CreationTimestamp.Time},
+		"podStartup": {pod.CreationTimestamp.Time, pod.Status.ContainerStatuses[0].State.Terminated.StartedAt.Time},
+	} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("expected span %s to be exported", name)
+			continue
+		}
+		if span.Parent.SpanID().String() != "b7ad6b7169203331" {
+			t.Errorf("expected the %s span to be a child of the PipelineTask span but its parent is %s", name, span.Parent.SpanID())
+		}
+		if !span.StartTime.Equal(want.start) || !span.EndTime.Equal(want.end) {
+			t.Errorf("expected the %s span to last from %v to %v but got %v to %v", name, want.start, want.end, span.StartTime, span.EndTime)
+		}
+	}
+	if step, ok := spans["step:simple-step"]; !ok {
+		t.Errorf("expected the span of the step to be exported")
+	} else if step.Parent.SpanID().String() == "b7ad6b7169203331" {
+		t.Errorf("expected the span of the step to be part of the trace of the TaskRun rather than of its PipelineTask")
+	}
+}
+
 func TestReconcile_ExplicitDefaultSA(t *testing.T) {
 	taskRunSuccess := parse.MustParseTaskRun(t, `
 metadata:
@@ -2212,6 +2383,284 @@ status:
 	}
 }
 
+func TestReconcileEvictedPod(t *testing.T) {
+	evictedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun-evicted-pod",
+			Namespace: "foo",
+		},
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}
+	for _, tc := range []struct {
+		name          string
+		taskRun       *v1beta1.TaskRun
+		wantRecreated bool
+	}{{
+		name: "pod eviction retries remaining",
+		taskRun: parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun-evicted
+  namespace: foo
+spec:
+  podEvictionRetries: 1
+  taskSpec:
+    steps:
+    - image: whatever
+      command:
+      - /mycmd
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  podName: test-taskrun-evicted-pod
+  startTime: "2022-01-01T00:00:00Z"
+`),
+		wantRecreated: true,
+	}, {
+		name: "pod eviction retries exhausted",
+		taskRun: parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun-evicted
+  namespace: foo
+spec:
+  podEvictionRetries: 1
+  taskSpec:
+    steps:
+    - image: whatever
+      command:
+      - /mycmd
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  podName: test-taskrun-evicted-pod
+  retriesStatus:
+  - conditions:
+    - reason: TaskRunPodEvicted
+      status: "False"
+      type: Succeeded
+    podName: test-taskrun-evicted-pod-old
+  startTime: "2022-01-01T00:00:00Z"
+`),
+	}, {
+		name: "no pod eviction retries",
+		taskRun: parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun-evicted
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - image: whatever
+      command:
+      - /mycmd
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  podName: test-taskrun-evicted-pod
+  startTime: "2022-01-01T00:00:00Z"
+`),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{tc.taskRun},
+				Pods:     []*corev1.Pod{evictedPod},
+				ServiceAccounts: []*corev1.ServiceAccount{{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(tc.taskRun)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+				}
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(tc.taskRun.Namespace).Get(testAssets.Ctx, tc.taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", tc.taskRun.Name, err)
+			}
+
+			if !tc.wantRecreated {
+				if !newTr.IsDone() || newTr.IsSuccessful() {
+					t.Errorf("Expected TaskRun to have failed, got condition %v", newTr.Status.GetCondition(apis.ConditionSucceeded))
+				}
+				if reason := newTr.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != v1beta1.TaskRunReasonEvicted.String() {
+					t.Errorf("Expected TaskRun to have failed with reason %q, got %q", v1beta1.TaskRunReasonEvicted, reason)
+				}
+				for _, action := range clients.Kube.Actions() {
+					if action.Matches("create", "pods") {
+						t.Errorf("Expected no pod to be created for TaskRun without pod eviction retries left")
+					}
+				}
+				return
+			}
+
+			if newTr.IsDone() {
+				t.Errorf("Expected TaskRun to be running, got condition %v", newTr.Status.GetCondition(apis.ConditionSucceeded))
+			}
+			if newTr.Status.PodName != "test-taskrun-evicted-pod-retry1" {
+				t.Errorf("Expected TaskRun to run in pod %q, got %q", "test-taskrun-evicted-pod-retry1", newTr.Status.PodName)
+			}
+			if len(newTr.Status.RetriesStatus) != 1 {
+				t.Fatalf("Expected one retry status, got %d", len(newTr.Status.RetriesStatus))
+			}
+			retry := newTr.Status.RetriesStatus[0]
+			if retry.PodName != evictedPod.Name {
+				t.Errorf("Expected retry status for pod %q, got %q", evictedPod.Name, retry.PodName)
+			}
+			if reason := retry.GetCondition(apis.ConditionSucceeded).Reason; reason != v1beta1.TaskRunReasonPodEvicted.String() {
+				t.Errorf("Expected retry status with reason %q, got %q", v1beta1.TaskRunReasonPodEvicted, reason)
+			}
+			if got := newTr.GetPodEvictionRetriesCount(); got != 1 {
+				t.Errorf("Expected 1 pod eviction retry, got %d", got)
+			}
+		})
+	}
+}
+
+func TestReconcilePendingTimeout(t *testing.T) {
+	taskRun := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun-pending
+  namespace: foo
+spec:
+  timeouts:
+    pending: 5m
+  taskSpec:
+    steps:
+    - image: whatever
+      command:
+      - /mycmd
+status:
+  conditions:
+  - reason: Pending
+    status: Unknown
+    type: Succeeded
+  podName: test-taskrun-pending-pod
+  startTime: "2021-12-31T23:50:00Z"
+`)
+	for _, tc := range []struct {
+		name           string
+		podCreation    time.Time
+		podStatus      corev1.PodStatus
+		wantReason     v1beta1.TaskRunReason
+		wantMessage    string
+		wantRequeueFor time.Duration
+	}{{
+		name:        "unschedulable pod",
+		podCreation: now.Add(-10 * time.Minute),
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/1 nodes are available: 1 node(s) didn't match Pod's node affinity/selector.",
+			}},
+		},
+		wantReason:  v1beta1.TaskRunReasonUnschedulable,
+		wantMessage: `The pod "test-taskrun-pending-pod" of TaskRun "test-taskrun-pending" could not be scheduled within the pending timeout "5m0s": pod status "PodScheduled":"False"; message: "0/1 nodes are available: 1 node(s) didn't match Pod's node affinity/selector."`,
+	}, {
+		name:        "pod failing to pull images",
+		podCreation: now.Add(-10 * time.Minute),
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-unnamed-0",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ErrImagePull",
+						Message: "rpc error: code = Unknown desc = failed to pull image",
+					},
+				},
+			}},
+		},
+		wantReason:  v1beta1.TaskRunReasonImagePullFailed,
+		wantMessage: `The pod "test-taskrun-pending-pod" of TaskRun "test-taskrun-pending" failed to pull its images within the pending timeout "5m0s": build step "step-unnamed-0" is pending with reason "rpc error: code = Unknown desc = failed to pull image"`,
+	}, {
+		name:        "pod pending for another reason",
+		podCreation: now.Add(-10 * time.Minute),
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+		},
+		wantReason:  v1beta1.TaskRunReasonPendingTimedOut,
+		wantMessage: `The pod "test-taskrun-pending-pod" of TaskRun "test-taskrun-pending" did not start running within the pending timeout "5m0s": Pending`,
+	}, {
+		name:        "pod pending within the pending timeout",
+		podCreation: now.Add(-2 * time.Minute),
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:   corev1.PodScheduled,
+				Status: corev1.ConditionFalse,
+				Reason: corev1.PodReasonUnschedulable,
+			}},
+		},
+		wantRequeueFor: 3 * time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-taskrun-pending-pod",
+					Namespace:         "foo",
+					CreationTimestamp: metav1.Time{Time: tc.podCreation},
+				},
+				Status: tc.podStatus,
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Pods:     []*corev1.Pod{pod},
+				ServiceAccounts: []*corev1.ServiceAccount{{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if tc.wantRequeueFor != 0 {
+				if ok, requeueFor := controller.IsRequeueKey(err); !ok || requeueFor != tc.wantRequeueFor {
+					t.Errorf("Expected a requeue after %s, got %v", tc.wantRequeueFor, err)
+				}
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.wantReason == "" {
+				if newTr.IsDone() {
+					t.Errorf("Expected TaskRun to be pending, got condition %v", condition)
+				}
+				return
+			}
+			expectedStatus := &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  tc.wantReason.String(),
+				Message: tc.wantMessage,
+			}
+			if d := cmp.Diff(expectedStatus, condition, ignoreLastTransitionTime); d != "" {
+				t.Errorf("Did not get expected condition %s", diff.PrintWantGot(d))
+			}
+			if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+				t.Errorf("Expected pod %s to be deleted, got %v", pod.Name, err)
+			}
+		})
+	}
+}
+
 func TestReconcilePodFailuresSidecarImagePullFailed(t *testing.T) {
 	taskRun := parse.MustParseTaskRun(t, `
 metadata:
@@ -2516,6 +2965,7 @@ spec:
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
 		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		tracerProvider:    trace.NewNoopTracerProvider(),
 	}
 
 	rtr := &resources.ResolvedTaskResources{
@@ -2625,6 +3075,7 @@ spec:
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
 		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		tracerProvider:    trace.NewNoopTracerProvider(),
 	}
 
 	rtr := &resources.ResolvedTaskResources{
@@ -4382,6 +4833,155 @@ status:
 	}
 }
 
+func TestStopSidecars_LogArchiver(t *testing.T) {
+	tr := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  podName: test-taskrun-pod
+  startTime: "2000-01-01T01:01:01Z"
+  steps:
+  - container: step-build
+    name: build
+    terminated:
+      exitCode: 0
+      finishedAt: "2000-01-01T01:01:01Z"
+      startedAt: "2000-01-01T01:01:01Z"
+  taskSpec:
+    steps:
+    - image: busybox
+      name: build
+`)
+	for _, tc := range []struct {
+		name        string
+		archiver    corev1.ContainerState
+		wantStopped bool
+		wantLogs    string
+	}{{
+		name:        "running archiver",
+		archiver:    corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		wantStopped: true,
+	}, {
+		name:     "terminated archiver",
+		archiver: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: `[{"step":"build"}]`}},
+		wantLogs: "s3://tekton-logs/foo/test-taskrun-pod/build.log",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-taskrun-pod",
+					Namespace: "foo",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-build"}, {
+						Name:    "tekton-log-archiver",
+						Image:   "entrypoint",
+						Command: []string{"/ko-app/entrypoint", "archive-logs", "s3://tekton-logs/foo/test-taskrun-pod", "build"},
+					}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-build",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+					}, {
+						Name:  "tekton-log-archiver",
+						State: tc.archiver,
+					}},
+				},
+			}
+
+			d := test.Data{
+				Pods:     []*corev1.Pod{pod},
+				TaskRuns: []*v1beta1.TaskRun{tr},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err != nil {
+				t.Errorf("Expected no error to be returned by reconciler: %v", err)
+			}
+
+			reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			if d := cmp.Diff(tc.wantLogs, reconciledRun.Status.Steps[0].Logs); d != "" {
+				t.Errorf("Wrong step logs %s", diff.PrintWantGot(d))
+			}
+			if len(reconciledRun.Status.Sidecars) != 0 {
+				t.Errorf("Expected the log archiver not to be reported as a sidecar, got %v", reconciledRun.Status.Sidecars)
+			}
+			updatedPod, err := clients.Kube.CoreV1().Pods(pod.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated pod: %v", err)
+			}
+			if stopped := updatedPod.Spec.Containers[1].Image == images.NopImage; stopped != tc.wantStopped {
+				t.Errorf("Expected the log archiver to be stopped: %t, got image %q", tc.wantStopped, updatedPod.Spec.Containers[1].Image)
+			}
+		})
+	}
+}
+
+func TestSetStepImagePullTimes(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-pod", Namespace: "foo"}}
+	pulled := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-pod.1", Namespace: "foo"},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "Pod", Name: "test-taskrun-pod", Namespace: "foo", FieldPath: "spec.containers{step-push}",
+		},
+		Reason:  "Pulled",
+		Message: `Successfully pulled image "registry" in 4s`,
+	}
+	seconds := func(s int) *metav1.Duration {
+		return &metav1.Duration{Duration: time.Duration(s) * time.Second}
+	}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{
+				Name: "build", ContainerName: "step-build", Timing: &v1beta1.StepTiming{Startup: seconds(10)},
+			}, {
+				Name: "push", ContainerName: "step-push", Timing: &v1beta1.StepTiming{Startup: seconds(5)},
+			}, {
+				// The container of the step hasn't started yet
+				Name: "notify", ContainerName: "step-notify",
+			}},
+		}},
+	}
+	previousSteps := []v1beta1.StepState{{
+		Name: "build", ContainerName: "step-build", Timing: &v1beta1.StepTiming{Startup: seconds(10), ImagePull: seconds(8)},
+	}}
+	kubeclient := fakekubeclientset.NewSimpleClientset(pulled)
+	c := &Reconciler{KubeClientSet: kubeclient}
+
+	c.setStepImagePullTimes(context.Background(), tr, previousSteps, pod)
+
+	want := map[string]*metav1.Duration{"build": seconds(8), "push": seconds(4)}
+	got := map[string]*metav1.Duration{}
+	for _, s := range tr.Status.Steps {
+		if s.Timing != nil {
+			got[s.Name] = s.Timing.ImagePull
+		}
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Wrong image pull times %s", diff.PrintWantGot(d))
+	}
+
+	// The events aren't listed again once the image pull time of all started steps is known
+	kubeclient.ClearActions()
+	c.setStepImagePullTimes(context.Background(), tr, tr.Status.Steps, pod)
+	if actions := kubeclient.Actions(); len(actions) != 0 {
+		t.Errorf("expected no events to be listed, got %v", actions)
+	}
+}
+
 func Test_validateTaskSpecRequestResources_ValidResources(t *testing.T) {
 	tcs := []struct {
 		name     string
@@ -58,6 +58,7 @@ func NewController(ctx context.Context, resolver Resolver, modifiers ...Reconcil
 		if err := resolver.Initialize(ctx); err != nil {
 			panic(err.Error())
 		}
+		registerMetricsViews(ctx)
 
 		r := &Reconciler{
 			LeaderAwareFuncs:           leaderAwareFuncs(rrInformer.Lister()),
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// resolutionStatusSuccess is the status of resolution requests resolved successfully
+	resolutionStatusSuccess = "success"
+	// resolutionStatusFailed is the status of resolution requests which failed
+	resolutionStatusFailed = "failed"
+	// resolutionStatusTimeout is the status of resolution requests which timed out
+	resolutionStatusTimeout = "timeout"
+)
+
+var (
+	resolverTag = tag.MustNewKey("resolver")
+	statusTag   = tag.MustNewKey("status")
+	reasonTag   = tag.MustNewKey("reason")
+
+	resolutionDuration = stats.Float64("resolution_duration_seconds",
+		"The time in seconds taken to resolve resolution requests",
+		stats.UnitDimensionless)
+	resolutionDurationView = &view.View{
+		Description: resolutionDuration.Description(),
+		Measure:     resolutionDuration,
+		Aggregation: view.Distribution(0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300),
+		TagKeys:     []tag.Key{resolverTag, statusTag},
+	}
+
+	resolutionCount = stats.Float64("resolution_count",
+		"number of resolution requests resolved, failed or timed out",
+		stats.UnitDimensionless)
+	resolutionCountView = &view.View{
+		Description: resolutionCount.Description(),
+		Measure:     resolutionCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{resolverTag, statusTag, reasonTag},
+	}
+
+	runningResolutionsCount = stats.Float64("running_resolutions_count",
+		"Number of resolution requests being resolved currently",
+		stats.UnitDimensionless)
+	runningResolutionsCountView = &view.View{
+		Description: runningResolutionsCount.Description(),
+		Measure:     runningResolutionsCount,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{resolverTag},
+	}
+
+	// The views are shared by all the resolvers of a binary, so they are registered once
+	registerViews sync.Once
+)
+
+func registerMetricsViews(ctx context.Context) {
+	registerViews.Do(func() {
+		if err := view.Register(resolutionDurationView, resolutionCountView, runningResolutionsCountView); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to register the resolution metrics views: %v", err)
+		}
+	})
+}
+
+// resolutionMetrics records the metrics of the resolution requests of a resolver
+type resolutionMetrics struct {
+	mutex   sync.Mutex
+	running map[string]int
+}
+
+// start records the start of the resolution of a request of the given type of resolver.
+func (m *resolutionMetrics) start(resolverType string) {
+	m.recordRunning(resolverType, 1)
+}
+
+// finish records the outcome and the duration of the resolution of a request of the
+// given type of resolver, given the error it failed with, if any.
+func (m *resolutionMetrics) finish(ctx context.Context, resolverType string, duration time.Duration, err error) {
+	m.recordRunning(resolverType, -1)
+
+	status, reason := resolutionStatusSuccess, resolutioncommon.ReasonResolutionSuccessful
+	var resolutionErr *resolutioncommon.Error
+	switch {
+	case err == nil:
+	case errors.Is(err, context.DeadlineExceeded):
+		status, reason = resolutionStatusTimeout, resolutioncommon.ReasonResolutionTimedOut
+	case errors.As(err, &resolutionErr):
+		status, reason = resolutionStatusFailed, resolutionErr.Reason
+	default:
+		status, reason = resolutionStatusFailed, resolutioncommon.ReasonResolutionFailed
+	}
+
+	statusCtx, tagErr := tag.New(context.Background(),
+		tag.Insert(resolverTag, resolverType),
+		tag.Insert(statusTag, status))
+	if tagErr != nil {
+		logging.FromContext(ctx).Warnf("Failed to record the resolution metrics: %v", tagErr)
+		return
+	}
+	metrics.Record(statusCtx, resolutionDuration.M(duration.Seconds()))
+	reasonCtx, tagErr := tag.New(statusCtx, tag.Insert(reasonTag, reason))
+	if tagErr != nil {
+		logging.FromContext(ctx).Warnf("Failed to record the resolution metrics: %v", tagErr)
+		return
+	}
+	metrics.Record(reasonCtx, resolutionCount.M(1))
+}
+
+func (m *resolutionMetrics) recordRunning(resolverType string, delta int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.running == nil {
+		m.running = map[string]int{}
+	}
+	m.running[resolverType] += delta
+	ctx, err := tag.New(context.Background(), tag.Insert(resolverTag, resolverType))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, runningResolutionsCount.M(float64(m.running[resolverType])))
+}
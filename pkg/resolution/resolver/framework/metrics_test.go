@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"go.opencensus.io/stats/view"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+func TestResolutionMetrics(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		err        error
+		wantStatus string
+		wantReason string
+	}{{
+		name:       "success",
+		wantStatus: "success",
+		wantReason: resolutioncommon.ReasonResolutionSuccessful,
+	}, {
+		name:       "failure",
+		err:        &resolutioncommon.ErrorGettingResource{ResolverName: "git", Key: "foo/rr", Original: errors.New("connection refused")},
+		wantStatus: "failed",
+		wantReason: resolutioncommon.ReasonResolutionFailed,
+	}, {
+		name:       "failure with reason",
+		err:        &resolutioncommon.ErrorGettingResource{ResolverName: "git", Key: "foo/rr", Original: resolutioncommon.NewError("RepositoryNotFound", errors.New("not found"))},
+		wantStatus: "failed",
+		wantReason: "RepositoryNotFound",
+	}, {
+		name:       "timeout",
+		err:        context.DeadlineExceeded,
+		wantStatus: "timeout",
+		wantReason: resolutioncommon.ReasonResolutionTimedOut,
+	}, {
+		name:       "timeout of the resolver",
+		err:        &resolutioncommon.ErrorGettingResource{ResolverName: "git", Key: "foo/rr", Original: fmt.Errorf("clone: %w", context.DeadlineExceeded)},
+		wantStatus: "timeout",
+		wantReason: resolutioncommon.ReasonResolutionTimedOut,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unregisterMetrics(t)
+			m := &resolutionMetrics{}
+
+			m.start("git")
+			m.start("git")
+			metricstest.CheckLastValueData(t, "running_resolutions_count", map[string]string{"resolver": "git"}, 2)
+
+			m.finish(context.Background(), "git", 3*time.Second, tc.err)
+			metricstest.CheckLastValueData(t, "running_resolutions_count", map[string]string{"resolver": "git"}, 1)
+			metricstest.CheckDistributionData(t, "resolution_duration_seconds", map[string]string{
+				"resolver": "git",
+				"status":   tc.wantStatus,
+			}, 1, 3, 3)
+			metricstest.CheckCountData(t, "resolution_count", map[string]string{
+				"resolver": "git",
+				"status":   tc.wantStatus,
+				"reason":   tc.wantReason,
+			}, 1)
+		})
+	}
+}
+
+func unregisterMetrics(t *testing.T) {
+	t.Helper()
+	metricstest.Unregister("resolution_duration_seconds", "resolution_count", "running_resolutions_count")
+	if err := view.Register(resolutionDurationView, resolutionCountView, runningResolutionsCountView); err != nil {
+		t.Fatalf("Failed to register the resolution metrics views: %v", err)
+	}
+}
@@ -56,6 +56,7 @@ type Reconciler struct {
 	resolutionRequestClientSet rrclient.Interface
 
 	configStore *ConfigStore
+	metrics     resolutionMetrics
 }
 
 var _ reconciler.LeaderAware = &Reconciler{}
@@ -103,6 +104,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 }
 
 func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.ResolutionRequest) error {
+	resolverType := rr.Labels[resolutioncommon.LabelKeyResolverType]
+	start := r.Clock.Now()
+	r.metrics.start(resolverType)
+	var resolutionErr error
+	defer func() {
+		r.metrics.finish(ctx, resolverType, r.Clock.Since(start), resolutionErr)
+	}()
+
 	errChan := make(chan error)
 	resourceChan := make(chan ResolvedResource)
 
@@ -141,17 +150,21 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 	select {
 	case err := <-errChan:
 		if err != nil {
+			resolutionErr = err
 			return r.OnError(ctx, rr, err)
 		}
 	case <-resolutionCtx.Done():
 		if err := resolutionCtx.Err(); err != nil {
+			resolutionErr = err
 			return r.OnError(ctx, rr, err)
 		}
 	case resource := <-resourceChan:
-		return r.writeResolvedData(ctx, rr, resource)
+		resolutionErr = r.writeResolvedData(ctx, rr, resource)
+		return resolutionErr
 	}
 
-	return errors.New("unknown error")
+	resolutionErr = errors.New("unknown error")
+	return resolutionErr
 }
 
 // OnError is used to handle any situation where a ResolutionRequest has
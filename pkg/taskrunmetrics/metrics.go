@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/metricslabels"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
@@ -48,6 +49,7 @@ var (
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
 	podTag         = tag.MustNewKey("pod")
+	stepTag        = tag.MustNewKey("step")
 
 	trDurationView      *view.View
 	prTRDurationView    *view.View
@@ -55,6 +57,10 @@ var (
 	runningTRsCountView *view.View
 	podLatencyView      *view.View
 	cloudEventsView     *view.View
+	stepStartupView     *view.View
+	stepImagePullView   *view.View
+	stepWaitingView     *view.View
+	stepExecutionView   *view.View
 
 	trDuration = stats.Float64(
 		"taskrun_duration_seconds",
@@ -81,6 +87,22 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	stepStartup = stats.Float64("taskrun_step_startup_duration_seconds",
+		"The time in seconds the steps of taskruns spent starting their container, including pulling their image",
+		stats.UnitDimensionless)
+
+	stepImagePull = stats.Float64("taskrun_step_image_pull_duration_seconds",
+		"The time in seconds the steps of taskruns spent pulling their image",
+		stats.UnitDimensionless)
+
+	stepWaiting = stats.Float64("taskrun_step_waiting_duration_seconds",
+		"The time in seconds the steps of taskruns spent waiting for the previous steps",
+		stats.UnitDimensionless)
+
+	stepExecution = stats.Float64("taskrun_step_execution_duration_seconds",
+		"The time in seconds the steps of taskruns spent running their command",
+		stats.UnitDimensionless)
 )
 
 // Recorder is used to actually record TaskRun metrics
@@ -95,6 +117,8 @@ type Recorder struct {
 
 	insertPipelineTag func(pipeline,
 		pipelinerun string) []tag.Mutator
+
+	labelTagger *metricslabels.Tagger
 }
 
 // We cannot register the view multiple times, so NewRecorder lazily
@@ -179,23 +203,26 @@ func viewRegister(cfg *config.Metrics) error {
 		}
 	}
 
+	r.labelTagger = metricslabels.NewTagger(cfg)
+	labelTag := r.labelTagger.TagKeys()
+
 	trDurationView = &view.View{
 		Description: trDuration.Description(),
 		Measure:     trDuration,
 		Aggregation: distribution,
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, trunTag...),
+		TagKeys:     append(append([]tag.Key{statusTag, namespaceTag}, trunTag...), labelTag...),
 	}
 	prTRDurationView = &view.View{
 		Description: prTRDuration.Description(),
 		Measure:     prTRDuration,
 		Aggregation: distribution,
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
+		TagKeys:     append(append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...), labelTag...),
 	}
 	trCountView = &view.View{
 		Description: trCount.Description(),
 		Measure:     trCount,
 		Aggregation: view.Count(),
-		TagKeys:     []tag.Key{statusTag},
+		TagKeys:     append([]tag.Key{statusTag}, labelTag...),
 	}
 	runningTRsCountView = &view.View{
 		Description: runningTRsCount.Description(),
@@ -214,6 +241,33 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.Sum(),
 		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
 	}
+
+	stepTags := []tag.Key{namespaceTag, taskTag, stepTag}
+	stepDistribution := view.Distribution(0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600)
+	stepStartupView = &view.View{
+		Description: stepStartup.Description(),
+		Measure:     stepStartup,
+		Aggregation: stepDistribution,
+		TagKeys:     stepTags,
+	}
+	stepImagePullView = &view.View{
+		Description: stepImagePull.Description(),
+		Measure:     stepImagePull,
+		Aggregation: stepDistribution,
+		TagKeys:     stepTags,
+	}
+	stepWaitingView = &view.View{
+		Description: stepWaiting.Description(),
+		Measure:     stepWaiting,
+		Aggregation: stepDistribution,
+		TagKeys:     stepTags,
+	}
+	stepExecutionView = &view.View{
+		Description: stepExecution.Description(),
+		Measure:     stepExecution,
+		Aggregation: stepDistribution,
+		TagKeys:     stepTags,
+	}
 	return view.Register(
 		trDurationView,
 		prTRDurationView,
@@ -221,6 +275,10 @@ func viewRegister(cfg *config.Metrics) error {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		stepStartupView,
+		stepImagePullView,
+		stepWaitingView,
+		stepExecutionView,
 	)
 }
 
@@ -232,6 +290,10 @@ func viewUnregister() {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		stepStartupView,
+		stepImagePullView,
+		stepWaitingView,
+		stepExecutionView,
 	)
 }
 
@@ -312,10 +374,11 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1beta1.TaskRun, be
 	if ok, pipeline, pipelinerun := IsPartOfPipeline(tr); ok {
 		ctx, err := tag.New(
 			ctx,
-			append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
+			append(append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
 				tag.Insert(statusTag, status)},
 				append(r.insertPipelineTag(pipeline, pipelinerun),
-					r.insertTaskTag(taskName, tr.Name)...)...)...)
+					r.insertTaskTag(taskName, tr.Name)...)...),
+				r.labelTagger.Mutators(tr)...)...)
 
 		if err != nil {
 			return err
@@ -328,9 +391,10 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1beta1.TaskRun, be
 
 	ctx, err := tag.New(
 		ctx,
-		append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
+		append(append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
 			tag.Insert(statusTag, status)},
-			r.insertTaskTag(taskName, tr.Name)...)...)
+			r.insertTaskTag(taskName, tr.Name)...),
+			r.labelTagger.Mutators(tr)...)...)
 	if err != nil {
 		return err
 	}
@@ -430,6 +494,57 @@ func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1
 	return nil
 }
 
+// StepTimings logs the time the steps of a TaskRun spent starting their container and
+// pulling their image, waiting for the previous steps and running their command, once the TaskRun
+// completes, i.e. when beforeCondition isn't done and the condition of the TaskRun is.
+// returns an error if it fails to log the metrics
+func (r *Recorder) StepTimings(ctx context.Context, tr *v1beta1.TaskRun, beforeCondition *apis.Condition) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if isDone(beforeCondition) || !isDone(afterCondition) {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	taskName := "anonymous"
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	for _, step := range tr.Status.Steps {
+		if step.Timing == nil {
+			continue
+		}
+		ctx, err := tag.New(
+			ctx,
+			tag.Insert(namespaceTag, tr.Namespace),
+			tag.Insert(taskTag, taskName),
+			tag.Insert(stepTag, step.Name))
+		if err != nil {
+			return err
+		}
+		if step.Timing.Startup != nil {
+			metrics.Record(ctx, stepStartup.M(step.Timing.Startup.Seconds()))
+		}
+		if step.Timing.ImagePull != nil {
+			metrics.Record(ctx, stepImagePull.M(step.Timing.ImagePull.Seconds()))
+		}
+		if step.Timing.Waiting != nil {
+			metrics.Record(ctx, stepWaiting.M(step.Timing.Waiting.Seconds()))
+		}
+		if step.Timing.Execution != nil {
+			metrics.Record(ctx, stepExecution.M(step.Timing.Execution.Seconds()))
+		}
+	}
+
+	return nil
+}
+
 // CloudEvents logs the number of cloud events sent for TaskRun
 // returns an error if it fails to log the metrics
 func (r *Recorder) CloudEvents(ctx context.Context, tr *v1beta1.TaskRun) error {
@@ -510,3 +625,8 @@ func getScheduledTime(pod *corev1.Pod) metav1.Time {
 
 	return metav1.Time{}
 }
+
+// isDone returns true if the condition is the Succeeded condition of a completed run.
+func isDone(c *apis.Condition) bool {
+	return c != nil && c.Status != corev1.ConditionUnknown
+}
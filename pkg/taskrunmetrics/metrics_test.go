@@ -78,6 +78,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.CloudEvents(ctx, &v1beta1.TaskRun{}); err == nil {
 		t.Error("Cloud Events recording expected to return error but got nil")
 	}
+	if err := metrics.StepTimings(ctx, &v1beta1.TaskRun{}, beforeCondition); err == nil {
+		t.Error("Step timings recording expected to return error but got nil")
+	}
 }
 
 func TestMetricsOnStore(t *testing.T) {
@@ -381,6 +384,59 @@ func TestRecordTaskRunDurationCount(t *testing.T) {
 	}
 }
 
+func TestRecordTaskRunDurationCountWithLabels(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	cfg := config.FromContextOrDefaults(ctx)
+	cfg.Metrics.Labels = []string{"team", "example.com/owner"}
+	ctx = config.ToContext(ctx, cfg)
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-1",
+			Namespace:   "ns",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"example.com/owner": "jdoe"},
+		},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+			},
+		},
+	}
+	if err := metrics.DurationAndCount(ctx, tr, nil); err != nil {
+		t.Errorf("DurationAndCount: %v", err)
+	}
+	metricstest.CheckLastValueData(t, "taskrun_duration_seconds", map[string]string{
+		"task":                    "task-1",
+		"taskrun":                 "taskrun-1",
+		"namespace":               "ns",
+		"status":                  "success",
+		"label_team":              "a",
+		"label_example_com_owner": "jdoe",
+	}, 60)
+	metricstest.CheckCountData(t, "taskrun_count", map[string]string{
+		"status":                  "success",
+		"label_team":              "a",
+		"label_example_com_owner": "jdoe",
+	}, 1)
+}
+
 func TestRecordRunningTaskRunsCount(t *testing.T) {
 	unregisterMetrics()
 	newTaskRun := func(status corev1.ConditionStatus) *v1beta1.TaskRun {
@@ -493,6 +549,61 @@ func TestRecordPodLatency(t *testing.T) {
 
 }
 
+func TestRecordStepTimings(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+		},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					Name: "build",
+					Timing: &v1beta1.StepTiming{
+						Startup:   &metav1.Duration{Duration: 12 * time.Second},
+						ImagePull: &metav1.Duration{Duration: 9 * time.Second},
+						Waiting:   &metav1.Duration{Duration: 2 * time.Second},
+						Execution: &metav1.Duration{Duration: 30 * time.Second},
+					},
+				}, {
+					// Steps without timing are ignored
+					Name: "push",
+				}},
+			},
+		},
+	}
+	running := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+	succeeded := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}
+
+	// The timings aren't recorded before the TaskRun completes
+	tr.Status.SetCondition(running)
+	if err := metrics.StepTimings(ctx, tr, nil); err != nil {
+		t.Errorf("StepTimings: %v", err)
+	}
+	tr.Status.SetCondition(succeeded)
+	if err := metrics.StepTimings(ctx, tr, running); err != nil {
+		t.Errorf("StepTimings: %v", err)
+	}
+	// nor recorded again once it completed
+	if err := metrics.StepTimings(ctx, tr, succeeded); err != nil {
+		t.Errorf("StepTimings: %v", err)
+	}
+
+	tags := map[string]string{"namespace": "ns", "task": "task-1", "step": "build"}
+	metricstest.CheckDistributionData(t, "taskrun_step_startup_duration_seconds", tags, 1, 12, 12)
+	metricstest.CheckDistributionData(t, "taskrun_step_image_pull_duration_seconds", tags, 1, 9, 9)
+	metricstest.CheckDistributionData(t, "taskrun_step_waiting_duration_seconds", tags, 1, 2, 2)
+	metricstest.CheckDistributionData(t, "taskrun_step_execution_duration_seconds", tags, 1, 30, 30)
+}
+
 func TestTaskRunIsOfPipelinerun(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -690,7 +801,8 @@ func TestRecordCloudEvents(t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count",
+		"taskrun_step_startup_duration_seconds", "taskrun_step_image_pull_duration_seconds", "taskrun_step_waiting_duration_seconds", "taskrun_step_execution_duration_seconds")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
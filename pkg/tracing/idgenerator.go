@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type spanContextKey struct{}
+
+// withSpanContext returns a context in which the next span started is recorded with the
+// given span context instead of new IDs. It lets spans spanning several reconciliations
+// be recorded once they end, with the span context their children already refer to.
+func withSpanContext(ctx context.Context, sc trace.SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// idGenerator generates random trace and span IDs, unless the context carries the span
+// context a span must be recorded with.
+type idGenerator struct {
+	mutex  sync.Mutex
+	random *rand.Rand
+}
+
+var _ sdktrace.IDGenerator = (*idGenerator)(nil)
+
+func newIDGenerator() *idGenerator {
+	var seed int64
+	_ = binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	return &idGenerator{random: rand.New(rand.NewSource(seed))} //nolint:gosec // trace IDs need not be cryptographically secure
+}
+
+// NewIDs returns the IDs of the span context carried by the context, or random ones.
+func (g *idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if sc, ok := ctx.Value(spanContextKey{}).(trace.SpanContext); ok {
+		return sc.TraceID(), sc.SpanID()
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	traceID := trace.TraceID{}
+	_, _ = g.random.Read(traceID[:])
+	spanID := trace.SpanID{}
+	_, _ = g.random.Read(spanID[:])
+	return traceID, spanID
+}
+
+// NewSpanID returns the span ID of the span context carried by the context if it belongs
+// to the given trace, or a random one.
+func (g *idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	if sc, ok := ctx.Value(spanContextKey{}).(trace.SpanContext); ok && sc.TraceID() == traceID {
+		return sc.SpanID()
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	spanID := trace.SpanID{}
+	_, _ = g.random.Read(spanID[:])
+	return spanID
+}
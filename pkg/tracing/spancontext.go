@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PipelineRunSpanContextAnnotation is the annotation holding the span context of the
+	// trace of a PipelineRun. On a TaskRun it holds the span context of the PipelineTask
+	// it was created for, which is the parent of the trace of the TaskRun.
+	PipelineRunSpanContextAnnotation = pipeline.GroupName + "/pipelinerunSpanContext"
+	// TaskRunSpanContextAnnotation is the annotation holding the span context of the trace of
+	// a TaskRun.
+	TaskRunSpanContextAnnotation = pipeline.GroupName + "/taskrunSpanContext"
+)
+
+var propagator = propagation.TraceContext{}
+
+// ContextWithRootSpan returns a context carrying the span context stored in the given
+// annotation of an object, so that the spans of all of its reconciliations belong to
+// the same trace. The first time, the span context of the root span of the trace is
+// allocated as a child of the span context stored in the parent annotation, if any, and
+// stored in the annotation. The root span itself is recorded by EndRootSpan once the
+// object is done, so that it covers the whole lifetime of the object.
+func ContextWithRootSpan(ctx context.Context, tracer trace.Tracer, meta *metav1.ObjectMeta, annotation, parentAnnotation, spanName string, attributes ...attribute.KeyValue) context.Context {
+	if sc := extract(meta.Annotations[annotation]); sc.IsValid() {
+		return trace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+
+	// The span is never ended: it only allocates the span context of the root span
+	_, span := tracer.Start(contextWithParent(ctx, meta, parentAnnotation), spanName, trace.WithAttributes(attributes...))
+	if !span.IsRecording() {
+		return ctx
+	}
+	sc := span.SpanContext()
+	inject(sc, meta, annotation)
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// EndRootSpan records the root span whose span context is stored in the given annotation
+// of an object, from start to end, as a child of the span context stored in the parent
+// annotation, if any. It is called once, when the object is done.
+func EndRootSpan(ctx context.Context, tracer trace.Tracer, meta *metav1.ObjectMeta, annotation, parentAnnotation, spanName string, start, end time.Time, attributes ...attribute.KeyValue) {
+	sc := extract(meta.Annotations[annotation])
+	if !sc.IsValid() {
+		return
+	}
+	recordSpan(contextWithParent(ctx, meta, parentAnnotation), tracer, sc, spanName, start, end, attributes...)
+}
+
+// InjectPipelineTaskSpanContext stores the span context of the span of a PipelineTask of
+// a PipelineRun in the PipelineRunSpanContextAnnotation of the TaskRun or Run created for
+// it, so that their traces are children of the span of the PipelineTask.
+func InjectPipelineTaskSpanContext(pr *metav1.ObjectMeta, pipelineTask string, child *metav1.ObjectMeta) {
+	if sc := pipelineTaskSpanContext(pr, pipelineTask); sc.IsValid() {
+		inject(sc, child, PipelineRunSpanContextAnnotation)
+	}
+}
+
+// EndPipelineTaskSpan records the span of a PipelineTask of a PipelineRun, from the time
+// it was scheduled to the time its TaskRuns or Runs were done, as a child of the root
+// span of the PipelineRun.
+func EndPipelineTaskSpan(ctx context.Context, tracer trace.Tracer, pr *metav1.ObjectMeta, pipelineTask string, start, end time.Time, attributes ...attribute.KeyValue) {
+	sc := pipelineTaskSpanContext(pr, pipelineTask)
+	if !sc.IsValid() {
+		return
+	}
+	recordSpan(contextWithParent(ctx, pr, PipelineRunSpanContextAnnotation), tracer, sc, "PipelineTask:"+pipelineTask, start, end, attributes...)
+}
+
+// ContextWithParentSpan returns a context carrying the span context stored in the given
+// annotation of an object, or the context itself if there is none.
+func ContextWithParentSpan(ctx context.Context, meta *metav1.ObjectMeta, annotation string) context.Context {
+	if sc := extract(meta.Annotations[annotation]); sc.IsValid() {
+		return trace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+	return ctx
+}
+
+// InjectSpanContext stores the span context of the span recorded in the context in the
+// given annotation of an object. It does nothing if no span is being recorded.
+func InjectSpanContext(ctx context.Context, meta *metav1.ObjectMeta, annotation string) {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		inject(span.SpanContext(), meta, annotation)
+	}
+}
+
+// pipelineTaskSpanContext returns the span context of the span of a PipelineTask: its
+// span ID is derived from the span context of the PipelineRun and the name of the
+// PipelineTask, so that it is the same in every reconciliation of the PipelineRun.
+func pipelineTaskSpanContext(pr *metav1.ObjectMeta, pipelineTask string) trace.SpanContext {
+	root := extract(pr.Annotations[PipelineRunSpanContextAnnotation])
+	if !root.IsValid() {
+		return trace.SpanContext{}
+	}
+	traceID, rootID := root.TraceID(), root.SpanID()
+	sum := sha256.Sum256([]byte(traceID.String() + rootID.String() + pipelineTask))
+	spanID := trace.SpanID{}
+	copy(spanID[:], sum[:])
+	if !spanID.IsValid() {
+		spanID[len(spanID)-1] = 1
+	}
+	return root.WithSpanID(spanID).WithRemote(false)
+}
+
+// contextWithParent returns a context in which spans are started as children of the
+// span context stored in the given annotation of an object, or as new roots if there is
+// none, regardless of the span carried by the context.
+func contextWithParent(ctx context.Context, meta *metav1.ObjectMeta, parentAnnotation string) context.Context {
+	return trace.ContextWithRemoteSpanContext(ctx, extract(meta.Annotations[parentAnnotation]))
+}
+
+// recordSpan records a span with the given span context, from start to end.
+func recordSpan(parent context.Context, tracer trace.Tracer, sc trace.SpanContext, spanName string, start, end time.Time, attributes ...attribute.KeyValue) {
+	_, span := tracer.Start(withSpanContext(parent, sc), spanName, trace.WithTimestamp(start), trace.WithAttributes(attributes...))
+	span.End(trace.WithTimestamp(end))
+}
+
+func inject(sc trace.SpanContext, meta *metav1.ObjectMeta, annotation string) {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	value, err := json.Marshal(carrier)
+	if err != nil {
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[annotation] = string(value)
+}
+
+func extract(value string) trace.SpanContext {
+	if value == "" {
+		return trace.SpanContext{}
+	}
+	carrier := propagation.MapCarrier{}
+	if err := json.Unmarshal([]byte(value), &carrier); err != nil {
+		return trace.SpanContext{}
+	}
+	return trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// shutdownTimeout is how long to wait for the spans of a previous configuration
+// to be exported when the tracing configuration changes
+const shutdownTimeout = 10 * time.Second
+
+// TracerProvider is a trace.TracerProvider which exports the spans of its tracers to
+// the OTLP collector configured in the observability ConfigMap, and drops them when
+// tracing is disabled. It is reconfigured by registering OnConfigMap as an observer
+// of the observability ConfigMap.
+type TracerProvider struct {
+	service  string
+	logger   *zap.SugaredLogger
+	exporter sdktrace.SpanExporter
+
+	mutex    sync.RWMutex
+	cfg      *config.Tracing
+	provider trace.TracerProvider
+	shutdown func(context.Context) error
+}
+
+var _ trace.TracerProvider = (*TracerProvider)(nil)
+
+type exporterKey struct{}
+
+// WithExporter returns a context in which the TracerProviders created with New export
+// their spans synchronously to the given exporter instead of the configured collector
+// when tracing is enabled, e.g. to an in-memory exporter in tests.
+func WithExporter(ctx context.Context, exporter sdktrace.SpanExporter) context.Context {
+	return context.WithValue(ctx, exporterKey{}, exporter)
+}
+
+// New returns a TracerProvider for the given service, which drops all spans until
+// tracing is enabled in the observability ConfigMap.
+func New(ctx context.Context, service string, logger *zap.SugaredLogger) *TracerProvider {
+	exporter, _ := ctx.Value(exporterKey{}).(sdktrace.SpanExporter)
+	return &TracerProvider{
+		service:  service,
+		logger:   logger,
+		exporter: exporter,
+		provider: trace.NewNoopTracerProvider(),
+	}
+}
+
+// Tracer returns a tracer of the currently configured provider.
+func (t *TracerProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.provider.Tracer(name, options...)
+}
+
+// OnConfigMap reconfigures the TracerProvider from the observability ConfigMap.
+func (t *TracerProvider) OnConfigMap(cm *corev1.ConfigMap) {
+	cfg, err := config.NewTracingFromConfigMap(cm)
+	if err != nil {
+		t.logger.Errorf("Failed to parse the tracing configuration: %v", err)
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if cfg.Equals(t.cfg) {
+		return
+	}
+
+	processor, err := t.spanProcessor(cfg)
+	if err != nil {
+		t.logger.Errorf("Failed to create the exporter of collector %s: %v", cfg.Endpoint, err)
+		return
+	}
+	if t.shutdown != nil {
+		go func(shutdown func(context.Context) error) {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				t.logger.Warnf("Failed to export the remaining spans: %v", err)
+			}
+		}(t.shutdown)
+		t.shutdown = nil
+	}
+
+	t.cfg = cfg
+	if processor == nil {
+		t.provider = trace.NewNoopTracerProvider()
+		return
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithIDGenerator(newIDGenerator()),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(t.service))),
+	)
+	t.provider = provider
+	t.shutdown = provider.Shutdown
+}
+
+// spanProcessor returns the span processor exporting the spans for the given
+// configuration, or nil if tracing is disabled.
+func (t *TracerProvider) spanProcessor(cfg *config.Tracing) (sdktrace.SpanProcessor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if t.exporter != nil {
+		return sdktrace.NewSimpleSpanProcessor(t.exporter), nil
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint.Host)}
+	if endpoint.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if endpoint.Path != "" && endpoint.Path != "/" {
+		options = append(options, otlptracehttp.WithURLPath(endpoint.Path))
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewBatchSpanProcessor(exporter), nil
+}
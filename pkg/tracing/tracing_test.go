@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func observabilityConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetTracingConfigName()},
+		Data:       data,
+	}
+}
+
+func TestTracerProvider(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := New(WithExporter(context.Background(), exporter), "test", zaptest.NewLogger(t).Sugar())
+
+	_, span := tp.Tracer("test").Start(context.Background(), "disabled")
+	span.End()
+	if got := len(exporter.GetSpans()); got != 0 {
+		t.Fatalf("expected no span to be exported before tracing is enabled but got %d", got)
+	}
+
+	tp.OnConfigMap(observabilityConfigMap(map[string]string{"tracing.enabled": "true"}))
+	_, span = tp.Tracer("test").Start(context.Background(), "enabled")
+	span.End()
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "enabled" {
+		t.Fatalf("expected the enabled span to be exported but got %v", spans.Snapshots())
+	}
+
+	tp.OnConfigMap(observabilityConfigMap(map[string]string{"tracing.enabled": "invalid"}))
+	_, span = tp.Tracer("test").Start(context.Background(), "invalid configuration")
+	if !span.IsRecording() {
+		t.Errorf("expected an invalid configuration to keep the previous one")
+	}
+	span.End()
+}
+
+func TestContextWithRootSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := New(WithExporter(context.Background(), exporter), "test", zaptest.NewLogger(t).Sugar())
+	tp.OnConfigMap(observabilityConfigMap(map[string]string{"tracing.enabled": "true"}))
+	tracer := tp.Tracer("test")
+	created := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	pr := &metav1.ObjectMeta{Name: "pipelinerun"}
+	prCtx := ContextWithRootSpan(context.Background(), tracer, pr, PipelineRunSpanContextAnnotation, "", "PipelineRun")
+	if _, ok := pr.Annotations[PipelineRunSpanContextAnnotation]; !ok {
+		t.Fatalf("expected the span context of the PipelineRun to be stored in its annotations but got %v", pr.Annotations)
+	}
+	_, span := tracer.Start(prCtx, "reconcile")
+	span.End()
+
+	// A later reconciliation of the PipelineRun continues the same trace without a new root span
+	again := ContextWithRootSpan(context.Background(), tracer, pr, PipelineRunSpanContextAnnotation, "", "PipelineRun")
+	_, span = tracer.Start(again, "createTaskRun")
+	tr := &metav1.ObjectMeta{Name: "taskrun"}
+	InjectPipelineTaskSpanContext(pr, "pipeline-task", tr)
+	span.End()
+
+	ContextWithRootSpan(context.Background(), tracer, tr, TaskRunSpanContextAnnotation, PipelineRunSpanContextAnnotation, "TaskRun")
+	if got := len(exporter.GetSpans()); got != 2 {
+		t.Fatalf("expected the root spans not to be exported before they end but got %d spans", got)
+	}
+
+	EndRootSpan(context.Background(), tracer, tr, TaskRunSpanContextAnnotation, PipelineRunSpanContextAnnotation, "TaskRun", created.Add(time.Second), created.Add(time.Minute))
+	EndPipelineTaskSpan(context.Background(), tracer, pr, "pipeline-task", created.Add(time.Second), created.Add(time.Minute))
+	EndRootSpan(context.Background(), tracer, pr, PipelineRunSpanContextAnnotation, "", "PipelineRun", created, created.Add(time.Hour))
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	root, pt, taskRun := spans["PipelineRun"], spans["PipelineTask:pipeline-task"], spans["TaskRun"]
+	if len(spans) != 5 {
+		t.Fatalf("expected 5 spans but got %v", spans)
+	}
+	if root.SpanContext.SpanID() != extract(pr.Annotations[PipelineRunSpanContextAnnotation]).SpanID() {
+		t.Errorf("expected the PipelineRun span to have the span context stored in its annotations")
+	}
+	if root.Parent.IsValid() {
+		t.Errorf("expected the PipelineRun span to be the root of its trace")
+	}
+	if !root.StartTime.Equal(created) || !root.EndTime.Equal(created.Add(time.Hour)) {
+		t.Errorf("expected the PipelineRun span to last from its creation to its completion but got %v to %v", root.StartTime, root.EndTime)
+	}
+	for _, name := range []string{"reconcile", "createTaskRun", "PipelineTask:pipeline-task"} {
+		if spans[name].Parent.SpanID() != root.SpanContext.SpanID() {
+			t.Errorf("expected the %s span to be a child of the PipelineRun span", name)
+		}
+	}
+	if pt.SpanContext.SpanID() != extract(tr.Annotations[PipelineRunSpanContextAnnotation]).SpanID() {
+		t.Errorf("expected the TaskRun to carry the span context of its PipelineTask")
+	}
+	if taskRun.Parent.SpanID() != pt.SpanContext.SpanID() {
+		t.Errorf("expected the TaskRun span to be a child of the PipelineTask span")
+	}
+	for name, span := range spans {
+		if span.SpanContext.TraceID() != root.SpanContext.TraceID() {
+			t.Errorf("expected the %s span to belong to the trace of the PipelineRun", name)
+		}
+	}
+}
+
+func TestContextWithRootSpanDisabled(t *testing.T) {
+	tp := New(context.Background(), "test", zaptest.NewLogger(t).Sugar())
+	pr := &metav1.ObjectMeta{Name: "pipelinerun"}
+	ContextWithRootSpan(context.Background(), tp.Tracer("test"), pr, PipelineRunSpanContextAnnotation, "", "PipelineRun")
+	if pr.Annotations != nil {
+		t.Errorf("expected no annotation when tracing is disabled but got %v", pr.Annotations)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	informersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1alpha1"
 	informersv1beta1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	fakeapprovaltaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/approvaltask/fake"
 	fakeruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run/fake"
 	fakeclustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask/fake"
 	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
@@ -77,6 +78,7 @@ type Data struct {
 	ClusterTasks       []*v1beta1.ClusterTask
 	PipelineResources  []*resourcev1alpha1.PipelineResource
 	Runs               []*v1alpha1.Run
+	ApprovalTasks      []*v1alpha1.ApprovalTask
 	Pods               []*corev1.Pod
 	Namespaces         []*corev1.Namespace
 	ConfigMaps         []*corev1.ConfigMap
@@ -100,6 +102,7 @@ type Informers struct {
 	Pipeline          informersv1beta1.PipelineInformer
 	TaskRun           informersv1beta1.TaskRunInformer
 	Run               informersv1alpha1.RunInformer
+	ApprovalTask      informersv1alpha1.ApprovalTaskInformer
 	Task              informersv1beta1.TaskInformer
 	ClusterTask       informersv1beta1.ClusterTaskInformer
 	PipelineResource  resourceinformersv1alpha1.PipelineResourceInformer
@@ -183,6 +186,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		Pipeline:          fakepipelineinformer.Get(ctx),
 		TaskRun:           faketaskruninformer.Get(ctx),
 		Run:               fakeruninformer.Get(ctx),
+		ApprovalTask:      fakeapprovaltaskinformer.Get(ctx),
 		Task:              faketaskinformer.Get(ctx),
 		ClusterTask:       fakeclustertaskinformer.Get(ctx),
 		PipelineResource:  fakeresourceinformer.Get(ctx),
@@ -245,6 +249,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Pipeline.PrependReactor("*", "approvaltasks", AddToInformer(t, i.ApprovalTask.Informer().GetIndexer()))
+	for _, at := range d.ApprovalTasks {
+		at := at.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().ApprovalTasks(at.Namespace).Create(ctx, at, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Kube.PrependReactor("*", "pods", AddToInformer(t, i.Pod.Informer().GetIndexer()))
 	for _, p := range d.Pods {
 		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.